@@ -9,6 +9,7 @@ import (
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/akamai"
 	_ "github.com/akamai/terraform-provider-akamai/v6/pkg/providers" // Load the providers
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/providers/registry"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/tracing"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
@@ -51,4 +52,9 @@ func main() {
 	if err = tf6server.Serve(akamai.ProviderRegistryPath, muxServer.ProviderServer, serveOpts...); err != nil {
 		log.Fatal(err)
 	}
+
+	// export spans which are still buffered
+	if err = tracing.Shutdown(context.Background()); err != nil {
+		log.Print(err)
+	}
 }
@@ -0,0 +1,162 @@
+package akamai
+
+import (
+	"context"
+	"strings"
+
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// accountSwitchKeyAttribute is added to every resource and data source, so that a single provider
+	// configuration can manage objects of many accounts
+	accountSwitchKeyAttribute = "account_switch_key"
+
+	accountSwitchKeyDescription = "The account switch key used to sign requests for this object, the account of the provider credentials when not set. " +
+		"Objects of other accounts can be imported with an ID followed by `?accountSwitchKey=<key>`."
+
+	// accountSwitchKeyImportSeparator separates the account switch key from the ID when importing
+	accountSwitchKeyImportSeparator = "?accountSwitchKey="
+)
+
+// splitImportID returns the ID and the account switch key of an import ID
+func splitImportID(id string) (string, string) {
+	if i := strings.LastIndex(id, accountSwitchKeyImportSeparator); i >= 0 {
+		return id[:i], id[i+len(accountSwitchKeyImportSeparator):]
+	}
+	return id, ""
+}
+
+// accountMeta returns the meta signing requests with the given account switch key
+func accountMeta(m any, accountSwitchKey string) (meta.Meta, error) {
+	return meta.Must(m).WithAccountSwitchKey(accountSwitchKey)
+}
+
+type accountSwitchKeyGetter interface {
+	Get(key string) any
+}
+
+func getAccountSwitchKey(d accountSwitchKeyGetter) string {
+	key, _ := d.Get(accountSwitchKeyAttribute).(string)
+	return key
+}
+
+// addSDKAccountSwitchKey adds the account_switch_key attribute to the resources and makes all their operations
+// use the meta signing requests with its value. The context of every operation carries the resource type
+// and the span of the operation.
+func addSDKAccountSwitchKey(resources map[string]*schema.Resource, forceNew bool) {
+	for name, r := range resources {
+		if _, ok := r.Schema[accountSwitchKeyAttribute]; ok {
+			continue
+		}
+		r.Schema[accountSwitchKeyAttribute] = &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    forceNew,
+			Description: accountSwitchKeyDescription,
+		}
+
+		r.CreateContext = withSDKAccountMeta(name, "create", r.CreateContext)
+		r.ReadContext = withSDKAccountMeta(name, "read", r.ReadContext)
+		r.UpdateContext = withSDKAccountMeta(name, "update", r.UpdateContext)
+		r.DeleteContext = withSDKAccountMeta(name, "delete", r.DeleteContext)
+		r.CustomizeDiff = withSDKAccountMetaDiff(name, r.CustomizeDiff)
+		for i, upgrader := range r.StateUpgraders {
+			r.StateUpgraders[i].Upgrade = withSDKAccountMetaUpgrade(name, upgrader.Upgrade)
+		}
+		if r.Importer != nil {
+			r.Importer = withSDKAccountMetaImporter(name, r.Importer)
+		}
+	}
+}
+
+func withSDKAccountMeta[F ~func(context.Context, *schema.ResourceData, any) diag.Diagnostics](resourceType, operation string, f F) F {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, m any) (diags diag.Diagnostics) {
+		ctx, span := startOperation(ctx, resourceType, operation, m)
+		defer func() { endOperation(ctx, span, sdkDiagsError(diags)) }()
+
+		accountMeta, err := accountMeta(m, getAccountSwitchKey(d))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		return f(ctx, d, accountMeta)
+	}
+}
+
+func withSDKAccountMetaDiff(resourceType string, f schema.CustomizeDiffFunc) schema.CustomizeDiffFunc {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceDiff, m any) (err error) {
+		ctx, span := startOperation(ctx, resourceType, "plan", m)
+		defer func() { endOperation(ctx, span, err) }()
+
+		accountMeta, err := accountMeta(m, getAccountSwitchKey(d))
+		if err != nil {
+			return err
+		}
+		return f(ctx, d, accountMeta)
+	}
+}
+
+func withSDKAccountMetaUpgrade(resourceType string, f schema.StateUpgradeFunc) schema.StateUpgradeFunc {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, rawState map[string]any, m any) (_ map[string]any, err error) {
+		ctx, span := startOperation(ctx, resourceType, "upgrade", m)
+		defer func() { endOperation(ctx, span, err) }()
+
+		key, _ := rawState[accountSwitchKeyAttribute].(string)
+		accountMeta, err := accountMeta(m, key)
+		if err != nil {
+			return nil, err
+		}
+		return f(ctx, rawState, accountMeta)
+	}
+}
+
+// withSDKAccountMetaImporter strips the account switch key from the import ID and sets it in the imported state
+func withSDKAccountMetaImporter(resourceType string, importer *schema.ResourceImporter) *schema.ResourceImporter {
+	importState := importer.StateContext
+	if importState == nil && importer.State != nil {
+		importState = func(_ context.Context, d *schema.ResourceData, m any) ([]*schema.ResourceData, error) {
+			return importer.State(d, m)
+		}
+	}
+	if importState == nil {
+		return importer
+	}
+
+	return &schema.ResourceImporter{
+		StateContext: func(ctx context.Context, d *schema.ResourceData, m any) (_ []*schema.ResourceData, err error) {
+			ctx, span := startOperation(ctx, resourceType, "import", m)
+			defer func() { endOperation(ctx, span, err) }()
+
+			id, key := splitImportID(d.Id())
+			accountMeta, err := accountMeta(m, key)
+			if err != nil {
+				return nil, err
+			}
+			d.SetId(id)
+
+			imported, err := importState(ctx, d, accountMeta)
+			if err != nil {
+				return nil, err
+			}
+			if key != "" {
+				for _, data := range imported {
+					if err := data.Set(accountSwitchKeyAttribute, key); err != nil {
+						return nil, err
+					}
+				}
+			}
+			return imported, nil
+		},
+	}
+}
@@ -0,0 +1,670 @@
+package akamai
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	dataschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+type (
+	// accountSwitchResource adds the account_switch_key attribute to the wrapped resource and configures it
+	// with the meta signing requests with its value before every operation. The wrapped resource never sees
+	// the attribute, so that its models do not have to declare it. The context of every operation carries the resource type
+	// and the span of the operation.
+	accountSwitchResource struct {
+		resource     resource.Resource
+		providerData any
+	}
+
+	// accountSwitchDataSource is the data source counterpart of accountSwitchResource
+	accountSwitchDataSource struct {
+		dataSource   datasource.DataSource
+		providerData any
+	}
+)
+
+var (
+	_ resource.ResourceWithConfigure        = &accountSwitchResource{}
+	_ resource.ResourceWithModifyPlan       = &accountSwitchResource{}
+	_ resource.ResourceWithImportState      = &accountSwitchResource{}
+	_ resource.ResourceWithValidateConfig   = &accountSwitchResource{}
+	_ resource.ResourceWithConfigValidators = &accountSwitchResource{}
+	_ resource.ResourceWithUpgradeState     = &accountSwitchResource{}
+	_ resource.ResourceWithMoveState        = &accountSwitchResource{}
+
+	_ datasource.DataSourceWithConfigure        = &accountSwitchDataSource{}
+	_ datasource.DataSourceWithValidateConfig   = &accountSwitchDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &accountSwitchDataSource{}
+)
+
+// withFrameworkAccountSwitchKey wraps the resource factory, so that created resources support account_switch_key
+func withFrameworkAccountSwitchKey(newResource func() resource.Resource) func() resource.Resource {
+	return func() resource.Resource {
+		return &accountSwitchResource{resource: newResource()}
+	}
+}
+
+// withFrameworkDataSourceAccountSwitchKey wraps the data source factory, so that created data sources support account_switch_key
+func withFrameworkDataSourceAccountSwitchKey(newDataSource func() datasource.DataSource) func() datasource.DataSource {
+	return func() datasource.DataSource {
+		return &accountSwitchDataSource{dataSource: newDataSource()}
+	}
+}
+
+// Metadata implements resource.Resource
+func (r *accountSwitchResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	r.resource.Metadata(ctx, req, resp)
+}
+
+// Schema implements resource.Resource
+func (r *accountSwitchResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	r.resource.Schema(ctx, req, resp)
+
+	attributes := withAccountSwitchKeyAttribute(resp.Schema.Attributes)
+	addFrameworkProviderDefaults(r.typeName(ctx), attributes)
+	resp.Schema.Attributes = attributes
+}
+
+// withAccountSwitchKeyAttribute returns a copy of the resource attributes with account_switch_key added
+func withAccountSwitchKeyAttribute(attributes map[string]schema.Attribute) map[string]schema.Attribute {
+	withKey := make(map[string]schema.Attribute, len(attributes)+1)
+	for name, attr := range attributes {
+		withKey[name] = attr
+	}
+	withKey[accountSwitchKeyAttribute] = schema.StringAttribute{
+		Optional:      true,
+		Description:   accountSwitchKeyDescription,
+		PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+	}
+	return withKey
+}
+
+// Configure implements resource.ResourceWithConfigure
+func (r *accountSwitchResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.providerData = req.ProviderData
+	if configurable, ok := r.resource.(resource.ResourceWithConfigure); ok {
+		configurable.Configure(ctx, req, resp)
+	}
+}
+
+// Create implements resource.Resource
+func (r *accountSwitchResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startOperation(ctx, r.typeName(ctx), "create", r.providerData)
+	defer func() { endOperation(ctx, span, frameworkDiagsError(resp.Diagnostics)) }()
+
+	s, diags := r.schemas(ctx)
+	if resp.Diagnostics.Append(diags...); diags.HasError() {
+		return
+	}
+	config, _ := s.strip(req.Config.Raw)
+	plan, key := s.strip(req.Plan.Raw)
+	state, _ := s.strip(resp.State.Raw)
+	if resp.Diagnostics.Append(r.configure(ctx, key)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	innerReq := req
+	innerReq.Config.Schema, innerReq.Config.Raw = s.inner.Schema, config
+	innerReq.Plan.Schema, innerReq.Plan.Raw = s.inner.Schema, plan
+	innerResp := resource.CreateResponse{Private: resp.Private}
+	innerResp.State.Schema, innerResp.State.Raw = s.inner.Schema, state
+
+	r.resource.Create(ctx, innerReq, &innerResp)
+
+	resp.Diagnostics.Append(innerResp.Diagnostics...)
+	resp.Private = innerResp.Private
+	resp.State.Raw = s.join(innerResp.State.Raw, key)
+}
+
+// Read implements resource.Resource
+func (r *accountSwitchResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startOperation(ctx, r.typeName(ctx), "read", r.providerData)
+	defer func() { endOperation(ctx, span, frameworkDiagsError(resp.Diagnostics)) }()
+
+	s, diags := r.schemas(ctx)
+	if resp.Diagnostics.Append(diags...); diags.HasError() {
+		return
+	}
+	prior, key := s.strip(req.State.Raw)
+	state, _ := s.strip(resp.State.Raw)
+	if resp.Diagnostics.Append(r.configure(ctx, key)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	innerReq := req
+	innerReq.State.Schema, innerReq.State.Raw = s.inner.Schema, prior
+	innerResp := resource.ReadResponse{Private: resp.Private, Deferred: resp.Deferred}
+	innerResp.State.Schema, innerResp.State.Raw = s.inner.Schema, state
+
+	r.resource.Read(ctx, innerReq, &innerResp)
+
+	resp.Diagnostics.Append(innerResp.Diagnostics...)
+	resp.Private = innerResp.Private
+	resp.Deferred = innerResp.Deferred
+	resp.State.Raw = s.join(innerResp.State.Raw, key)
+}
+
+// Update implements resource.Resource
+func (r *accountSwitchResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startOperation(ctx, r.typeName(ctx), "update", r.providerData)
+	defer func() { endOperation(ctx, span, frameworkDiagsError(resp.Diagnostics)) }()
+
+	s, diags := r.schemas(ctx)
+	if resp.Diagnostics.Append(diags...); diags.HasError() {
+		return
+	}
+	config, _ := s.strip(req.Config.Raw)
+	plan, key := s.strip(req.Plan.Raw)
+	prior, _ := s.strip(req.State.Raw)
+	state, _ := s.strip(resp.State.Raw)
+	if resp.Diagnostics.Append(r.configure(ctx, key)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	innerReq := req
+	innerReq.Config.Schema, innerReq.Config.Raw = s.inner.Schema, config
+	innerReq.Plan.Schema, innerReq.Plan.Raw = s.inner.Schema, plan
+	innerReq.State.Schema, innerReq.State.Raw = s.inner.Schema, prior
+	innerResp := resource.UpdateResponse{Private: resp.Private}
+	innerResp.State.Schema, innerResp.State.Raw = s.inner.Schema, state
+
+	r.resource.Update(ctx, innerReq, &innerResp)
+
+	resp.Diagnostics.Append(innerResp.Diagnostics...)
+	resp.Private = innerResp.Private
+	resp.State.Raw = s.join(innerResp.State.Raw, key)
+}
+
+// Delete implements resource.Resource
+func (r *accountSwitchResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startOperation(ctx, r.typeName(ctx), "delete", r.providerData)
+	defer func() { endOperation(ctx, span, frameworkDiagsError(resp.Diagnostics)) }()
+
+	s, diags := r.schemas(ctx)
+	if resp.Diagnostics.Append(diags...); diags.HasError() {
+		return
+	}
+	prior, key := s.strip(req.State.Raw)
+	state, _ := s.strip(resp.State.Raw)
+	if resp.Diagnostics.Append(r.configure(ctx, key)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	innerReq := req
+	innerReq.State.Schema, innerReq.State.Raw = s.inner.Schema, prior
+	innerResp := resource.DeleteResponse{Private: resp.Private}
+	innerResp.State.Schema, innerResp.State.Raw = s.inner.Schema, state
+
+	r.resource.Delete(ctx, innerReq, &innerResp)
+
+	resp.Diagnostics.Append(innerResp.Diagnostics...)
+	resp.Private = innerResp.Private
+	resp.State.Raw = s.join(innerResp.State.Raw, key)
+}
+
+// ModifyPlan implements resource.ResourceWithModifyPlan
+func (r *accountSwitchResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	plan, err := setFrameworkDefaults(r.typeName(ctx), req.Config.Raw, req.State.Raw, resp.Plan.Raw, providerDefaults(r.providerData))
+	if err != nil {
+		resp.Diagnostics.AddError("setting provider defaults failed", err.Error())
+		return
+	}
+	req.Plan.Raw, resp.Plan.Raw = plan, plan
+
+	modifier, ok := r.resource.(resource.ResourceWithModifyPlan)
+	if !ok {
+		return
+	}
+	ctx, span := startOperation(ctx, r.typeName(ctx), "plan", r.providerData)
+	defer func() { endOperation(ctx, span, frameworkDiagsError(resp.Diagnostics)) }()
+
+	s, diags := r.schemas(ctx)
+	if resp.Diagnostics.Append(diags...); diags.HasError() {
+		return
+	}
+	config, _ := s.strip(req.Config.Raw)
+	prior, priorKey := s.strip(req.State.Raw)
+	plan, key := s.strip(req.Plan.Raw)
+	modified, modifiedKey := s.strip(resp.Plan.Raw)
+	if req.Plan.Raw.IsNull() {
+		// destroy plan
+		key = priorKey
+	}
+	if key.IsKnown() {
+		if resp.Diagnostics.Append(r.configure(ctx, key)...); resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	innerReq := req
+	innerReq.Config.Schema, innerReq.Config.Raw = s.inner.Schema, config
+	innerReq.State.Schema, innerReq.State.Raw = s.inner.Schema, prior
+	innerReq.Plan.Schema, innerReq.Plan.Raw = s.inner.Schema, plan
+	innerResp := resource.ModifyPlanResponse{Private: resp.Private, RequiresReplace: resp.RequiresReplace, Deferred: resp.Deferred}
+	innerResp.Plan.Schema, innerResp.Plan.Raw = s.inner.Schema, modified
+
+	modifier.ModifyPlan(ctx, innerReq, &innerResp)
+
+	resp.Diagnostics.Append(innerResp.Diagnostics...)
+	resp.Private = innerResp.Private
+	resp.RequiresReplace = innerResp.RequiresReplace
+	resp.Deferred = innerResp.Deferred
+	resp.Plan.Raw = s.join(innerResp.Plan.Raw, modifiedKey)
+}
+
+// ImportState implements resource.ResourceWithImportState
+func (r *accountSwitchResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, span := startOperation(ctx, r.typeName(ctx), "import", r.providerData)
+	defer func() { endOperation(ctx, span, frameworkDiagsError(resp.Diagnostics)) }()
+
+	importer, ok := r.resource.(resource.ResourceWithImportState)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Resource Import Not Implemented",
+			"This resource does not support import. Please contact the provider developer for additional information.",
+		)
+		return
+	}
+	s, diags := r.schemas(ctx)
+	if resp.Diagnostics.Append(diags...); diags.HasError() {
+		return
+	}
+	id, accountSwitchKey := splitImportID(req.ID)
+	key := tftypes.NewValue(tftypes.String, nil)
+	if accountSwitchKey != "" {
+		key = tftypes.NewValue(tftypes.String, accountSwitchKey)
+	}
+	state, _ := s.strip(resp.State.Raw)
+	if resp.Diagnostics.Append(r.configure(ctx, key)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	innerReq := req
+	innerReq.ID = id
+	innerResp := resource.ImportStateResponse{Private: resp.Private, Deferred: resp.Deferred}
+	innerResp.State.Schema, innerResp.State.Raw = s.inner.Schema, state
+
+	importer.ImportState(ctx, innerReq, &innerResp)
+
+	resp.Diagnostics.Append(innerResp.Diagnostics...)
+	resp.Private = innerResp.Private
+	resp.Deferred = innerResp.Deferred
+	resp.State.Raw = s.join(innerResp.State.Raw, key)
+}
+
+// UpgradeState implements resource.ResourceWithUpgradeState. The prior state is upgraded by the wrapped resource
+// without account_switch_key, which is kept as is.
+func (r *accountSwitchResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	upgrader, ok := r.resource.(resource.ResourceWithUpgradeState)
+	if !ok {
+		return nil
+	}
+	upgraders := upgrader.UpgradeState(ctx)
+	wrapped := make(map[int64]resource.StateUpgrader, len(upgraders))
+	for version, u := range upgraders {
+		wrapped[version] = r.stateUpgrader(u)
+	}
+	return wrapped
+}
+
+func (r *accountSwitchResource) stateUpgrader(u resource.StateUpgrader) resource.StateUpgrader {
+	var priorSchema *schema.Schema
+	if u.PriorSchema != nil {
+		prior := *u.PriorSchema
+		prior.Attributes = withAccountSwitchKeyAttribute(prior.Attributes)
+		priorSchema = &prior
+	}
+
+	return resource.StateUpgrader{
+		PriorSchema: priorSchema,
+		StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+			s, diags := r.schemas(ctx)
+			if resp.Diagnostics.Append(diags...); diags.HasError() {
+				return
+			}
+			rawState, key, err := stripRawState(req.RawState)
+			if err != nil {
+				resp.Diagnostics.AddError("reading account_switch_key of the prior state failed", err.Error())
+				return
+			}
+
+			innerReq := resource.UpgradeStateRequest{RawState: rawState}
+			if req.State != nil && priorSchema != nil {
+				prior := accountSwitchSchemas{
+					innerType: u.PriorSchema.Type().TerraformType(ctx),
+					outerType: priorSchema.Type().TerraformType(ctx),
+				}
+				state, _ := prior.strip(req.State.Raw)
+				innerReq.State = &tfsdk.State{Schema: *u.PriorSchema, Raw: state}
+			}
+			var innerResp resource.UpgradeStateResponse
+			innerResp.State.Schema = s.inner.Schema
+
+			u.StateUpgrader(ctx, innerReq, &innerResp)
+
+			if resp.Diagnostics.Append(innerResp.Diagnostics...); resp.Diagnostics.HasError() {
+				return
+			}
+			if innerResp.DynamicValue != nil {
+				if innerResp.State.Raw, err = innerResp.DynamicValue.Unmarshal(s.innerType); err != nil {
+					resp.Diagnostics.AddError("reading upgraded state failed", err.Error())
+					return
+				}
+			}
+			if innerResp.State.Raw.Type() != nil {
+				resp.State.Raw = s.join(innerResp.State.Raw, key)
+			}
+		},
+	}
+}
+
+// MoveState implements resource.ResourceWithMoveState. The state is moved by the wrapped resource, and account_switch_key
+// is taken from the source state, if it has one.
+func (r *accountSwitchResource) MoveState(ctx context.Context) []resource.StateMover {
+	mover, ok := r.resource.(resource.ResourceWithMoveState)
+	if !ok {
+		return nil
+	}
+	movers := mover.MoveState(ctx)
+	wrapped := make([]resource.StateMover, 0, len(movers))
+	for _, m := range movers {
+		m := m
+		wrapped = append(wrapped, resource.StateMover{
+			SourceSchema: m.SourceSchema,
+			StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+				s, diags := r.schemas(ctx)
+				if resp.Diagnostics.Append(diags...); diags.HasError() {
+					return
+				}
+				_, key, err := stripRawState(req.SourceRawState)
+				if err != nil {
+					resp.Diagnostics.AddError("reading account_switch_key of the source state failed", err.Error())
+					return
+				}
+				target, _ := s.strip(resp.TargetState.Raw)
+
+				innerResp := resource.MoveStateResponse{TargetPrivate: resp.TargetPrivate}
+				innerResp.TargetState.Schema, innerResp.TargetState.Raw = s.inner.Schema, target
+
+				m.StateMover(ctx, req, &innerResp)
+
+				resp.Diagnostics.Append(innerResp.Diagnostics...)
+				resp.TargetPrivate = innerResp.TargetPrivate
+				resp.TargetState.Raw = s.join(innerResp.TargetState.Raw, key)
+			},
+		})
+	}
+	return wrapped
+}
+
+// ValidateConfig implements resource.ResourceWithValidateConfig
+func (r *accountSwitchResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	validator, ok := r.resource.(resource.ResourceWithValidateConfig)
+	if !ok {
+		return
+	}
+	s, diags := r.schemas(ctx)
+	if resp.Diagnostics.Append(diags...); diags.HasError() {
+		return
+	}
+	config, _ := s.strip(req.Config.Raw)
+
+	innerReq := req
+	innerReq.Config.Schema, innerReq.Config.Raw = s.inner.Schema, config
+	validator.ValidateConfig(ctx, innerReq, resp)
+}
+
+// ConfigValidators implements resource.ResourceWithConfigValidators
+func (r *accountSwitchResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	if validators, ok := r.resource.(resource.ResourceWithConfigValidators); ok {
+		return validators.ConfigValidators(ctx)
+	}
+	return nil
+}
+
+// configure configures the wrapped resource with the meta signing requests with the account switch key
+func (r *accountSwitchResource) configure(ctx context.Context, key tftypes.Value) diag.Diagnostics {
+	configurable, ok := r.resource.(resource.ResourceWithConfigure)
+	if !ok || r.providerData == nil {
+		return nil
+	}
+	providerData, diags := accountProviderData(r.providerData, key)
+	if diags.HasError() {
+		return diags
+	}
+	var resp resource.ConfigureResponse
+	configurable.Configure(ctx, resource.ConfigureRequest{ProviderData: providerData}, &resp)
+	return resp.Diagnostics
+}
+
+// typeName returns the type of the wrapped resource
+func (r *accountSwitchResource) typeName(ctx context.Context) string {
+	var resp resource.MetadataResponse
+	r.resource.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: providerTypeName}, &resp)
+	return resp.TypeName
+}
+
+func (r *accountSwitchResource) schemas(ctx context.Context) (accountSwitchSchemas, diag.Diagnostics) {
+	var inner, outer resource.SchemaResponse
+	r.resource.Schema(ctx, resource.SchemaRequest{}, &inner)
+	r.Schema(ctx, resource.SchemaRequest{}, &outer)
+	inner.Diagnostics.Append(outer.Diagnostics...)
+
+	return accountSwitchSchemas{
+		inner:     tfsdk.State{Schema: inner.Schema},
+		innerType: inner.Schema.Type().TerraformType(ctx),
+		outerType: outer.Schema.Type().TerraformType(ctx),
+	}, inner.Diagnostics
+}
+
+// Metadata implements datasource.DataSource
+func (d *accountSwitchDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	d.dataSource.Metadata(ctx, req, resp)
+}
+
+// Schema implements datasource.DataSource
+func (d *accountSwitchDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	d.dataSource.Schema(ctx, req, resp)
+
+	attributes := make(map[string]dataschema.Attribute, len(resp.Schema.Attributes)+1)
+	for name, attr := range resp.Schema.Attributes {
+		attributes[name] = attr
+	}
+	attributes[accountSwitchKeyAttribute] = dataschema.StringAttribute{
+		Optional:    true,
+		Description: accountSwitchKeyDescription,
+	}
+	resp.Schema.Attributes = attributes
+}
+
+// Configure implements datasource.DataSourceWithConfigure
+func (d *accountSwitchDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.providerData = req.ProviderData
+	if configurable, ok := d.dataSource.(datasource.DataSourceWithConfigure); ok {
+		configurable.Configure(ctx, req, resp)
+	}
+}
+
+// Read implements datasource.DataSource
+func (d *accountSwitchDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := startOperation(ctx, d.typeName(ctx), "read", d.providerData)
+	defer func() { endOperation(ctx, span, frameworkDiagsError(resp.Diagnostics)) }()
+
+	s, diags := d.schemas(ctx)
+	if resp.Diagnostics.Append(diags...); diags.HasError() {
+		return
+	}
+	config, key := s.strip(req.Config.Raw)
+	state, _ := s.strip(resp.State.Raw)
+	if configurable, ok := d.dataSource.(datasource.DataSourceWithConfigure); ok && d.providerData != nil {
+		providerData, diags := accountProviderData(d.providerData, key)
+		if resp.Diagnostics.Append(diags...); diags.HasError() {
+			return
+		}
+		var configureResp datasource.ConfigureResponse
+		configurable.Configure(ctx, datasource.ConfigureRequest{ProviderData: providerData}, &configureResp)
+		if resp.Diagnostics.Append(configureResp.Diagnostics...); resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	innerReq := req
+	innerReq.Config.Schema, innerReq.Config.Raw = s.inner.Schema, config
+	innerResp := datasource.ReadResponse{Deferred: resp.Deferred}
+	innerResp.State.Schema, innerResp.State.Raw = s.inner.Schema, state
+
+	d.dataSource.Read(ctx, innerReq, &innerResp)
+
+	resp.Diagnostics.Append(innerResp.Diagnostics...)
+	resp.Deferred = innerResp.Deferred
+	resp.State.Raw = s.join(innerResp.State.Raw, key)
+}
+
+// ValidateConfig implements datasource.DataSourceWithValidateConfig
+func (d *accountSwitchDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	validator, ok := d.dataSource.(datasource.DataSourceWithValidateConfig)
+	if !ok {
+		return
+	}
+	s, diags := d.schemas(ctx)
+	if resp.Diagnostics.Append(diags...); diags.HasError() {
+		return
+	}
+	config, _ := s.strip(req.Config.Raw)
+
+	innerReq := req
+	innerReq.Config.Schema, innerReq.Config.Raw = s.inner.Schema, config
+	validator.ValidateConfig(ctx, innerReq, resp)
+}
+
+// ConfigValidators implements datasource.DataSourceWithConfigValidators
+func (d *accountSwitchDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	if validators, ok := d.dataSource.(datasource.DataSourceWithConfigValidators); ok {
+		return validators.ConfigValidators(ctx)
+	}
+	return nil
+}
+
+// typeName returns the type of the wrapped data source
+func (d *accountSwitchDataSource) typeName(ctx context.Context) string {
+	var resp datasource.MetadataResponse
+	d.dataSource.Metadata(ctx, datasource.MetadataRequest{ProviderTypeName: providerTypeName}, &resp)
+	return resp.TypeName
+}
+
+func (d *accountSwitchDataSource) schemas(ctx context.Context) (accountSwitchSchemas, diag.Diagnostics) {
+	var inner, outer datasource.SchemaResponse
+	d.dataSource.Schema(ctx, datasource.SchemaRequest{}, &inner)
+	d.Schema(ctx, datasource.SchemaRequest{}, &outer)
+	inner.Diagnostics.Append(outer.Diagnostics...)
+
+	return accountSwitchSchemas{
+		inner:     tfsdk.State{Schema: inner.Schema},
+		innerType: inner.Schema.Type().TerraformType(ctx),
+		outerType: outer.Schema.Type().TerraformType(ctx),
+	}, inner.Diagnostics
+}
+
+// accountProviderData returns the meta signing requests with the account switch key
+func accountProviderData(providerData any, key tftypes.Value) (any, diag.Diagnostics) {
+	var accountSwitchKey string
+	if key.IsKnown() && !key.IsNull() {
+		if err := key.As(&accountSwitchKey); err != nil {
+			return nil, diag.Diagnostics{diag.NewErrorDiagnostic("reading account_switch_key failed", err.Error())}
+		}
+	}
+	m, err := accountMeta(providerData, accountSwitchKey)
+	if err != nil {
+		return nil, diag.Diagnostics{diag.NewErrorDiagnostic("configuring account_switch_key failed", err.Error())}
+	}
+	return m, nil
+}
+
+// accountSwitchSchemas converts values between the schema of the wrapped resource or data source
+// and the schema extended with account_switch_key
+type accountSwitchSchemas struct {
+	// inner holds the schema of the wrapped resource or data source
+	inner     tfsdk.State
+	innerType tftypes.Type
+	outerType tftypes.Type
+}
+
+// strip returns the value without account_switch_key, and the value of account_switch_key
+func (s accountSwitchSchemas) strip(v tftypes.Value) (tftypes.Value, tftypes.Value) {
+	key := tftypes.NewValue(tftypes.String, nil)
+	if v.IsNull() {
+		return tftypes.NewValue(s.innerType, nil), key
+	}
+	if !v.IsKnown() {
+		return tftypes.NewValue(s.innerType, tftypes.UnknownValue), tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+	}
+
+	var attributes map[string]tftypes.Value
+	if err := v.As(&attributes); err != nil {
+		// not an object, leave it to the wrapped resource to report
+		return v, key
+	}
+	if k, ok := attributes[accountSwitchKeyAttribute]; ok {
+		key = k
+		delete(attributes, accountSwitchKeyAttribute)
+	}
+	return tftypes.NewValue(s.innerType, attributes), key
+}
+
+// stripRawState returns the JSON state without account_switch_key, and the value of account_switch_key
+func stripRawState(rawState *tfprotov6.RawState) (*tfprotov6.RawState, tftypes.Value, error) {
+	key := tftypes.NewValue(tftypes.String, nil)
+	if rawState == nil || rawState.JSON == nil {
+		return rawState, key, nil
+	}
+
+	var attributes map[string]json.RawMessage
+	if err := json.Unmarshal(rawState.JSON, &attributes); err != nil {
+		return nil, key, err
+	}
+	rawKey, ok := attributes[accountSwitchKeyAttribute]
+	if !ok {
+		return rawState, key, nil
+	}
+	var accountSwitchKey *string
+	if err := json.Unmarshal(rawKey, &accountSwitchKey); err != nil {
+		return nil, key, err
+	}
+	if accountSwitchKey != nil {
+		key = tftypes.NewValue(tftypes.String, *accountSwitchKey)
+	}
+	delete(attributes, accountSwitchKeyAttribute)
+
+	stripped, err := json.Marshal(attributes)
+	if err != nil {
+		return nil, key, err
+	}
+	return &tfprotov6.RawState{JSON: stripped, Flatmap: rawState.Flatmap}, key, nil
+}
+
+// join returns the value with account_switch_key set to key
+func (s accountSwitchSchemas) join(v, key tftypes.Value) tftypes.Value {
+	if v.IsNull() {
+		return tftypes.NewValue(s.outerType, nil)
+	}
+	if !v.IsKnown() {
+		return tftypes.NewValue(s.outerType, tftypes.UnknownValue)
+	}
+
+	var attributes map[string]tftypes.Value
+	if err := v.As(&attributes); err != nil {
+		return v
+	}
+	attributes[accountSwitchKeyAttribute] = key
+	return tftypes.NewValue(s.outerType, attributes)
+}
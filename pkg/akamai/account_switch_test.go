@@ -0,0 +1,358 @@
+package akamai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/apex/log"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	dataschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	sdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testAccountMeta is a meta which only tracks the account switch key, the provider defaults and the change freeze
+type testAccountMeta struct {
+	accountSwitchKey string
+	defaults         meta.Defaults
+	changeFreeze     []meta.FreezeWindow
+}
+
+func (m testAccountMeta) Log(...interface{}) log.Interface { return log.Log }
+
+func (m testAccountMeta) OperationID() string { return "" }
+
+func (m testAccountMeta) Session() session.Session { return nil }
+
+func (m testAccountMeta) AccountSwitchKey() string { return m.accountSwitchKey }
+
+func (m testAccountMeta) WithAccountSwitchKey(accountSwitchKey string) (meta.Meta, error) {
+	return testAccountMeta{accountSwitchKey: accountSwitchKey, defaults: m.defaults, changeFreeze: m.changeFreeze}, nil
+}
+
+func (m testAccountMeta) Defaults() meta.Defaults { return m.defaults }
+
+func (m testAccountMeta) ChangeFreeze() []meta.FreezeWindow { return m.changeFreeze }
+
+func TestSplitImportID(t *testing.T) {
+	tests := map[string]struct {
+		id, expectedID, expectedKey string
+	}{
+		"no account switch key": {
+			id:         "prp_1,ctr_1,grp_1",
+			expectedID: "prp_1,ctr_1,grp_1",
+		},
+		"account switch key": {
+			id:          "12345:678?accountSwitchKey=1-ABCD:Z-XYZ",
+			expectedID:  "12345:678",
+			expectedKey: "1-ABCD:Z-XYZ",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			id, key := splitImportID(test.id)
+			assert.Equal(t, test.expectedID, id)
+			assert.Equal(t, test.expectedKey, key)
+		})
+	}
+}
+
+func TestSDKAccountSwitchKey(t *testing.T) {
+	var usedKeys []string
+	read := func(ctx context.Context, d *sdkschema.ResourceData, m any) diag.Diagnostics {
+		assert.Equal(t, "akamai_test", tf.ResourceTypeFromContext(ctx))
+		usedKeys = append(usedKeys, meta.Must(m).AccountSwitchKey())
+		return nil
+	}
+	res := &sdkschema.Resource{
+		Schema: map[string]*sdkschema.Schema{
+			"name": {Type: sdkschema.TypeString, Required: true, ForceNew: true},
+		},
+		CreateContext: read,
+		ReadContext:   read,
+		DeleteContext: read,
+		Importer: &sdkschema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *sdkschema.ResourceData, m any) ([]*sdkschema.ResourceData, error) {
+				usedKeys = append(usedKeys, meta.Must(m).AccountSwitchKey())
+				return []*sdkschema.ResourceData{d}, nil
+			},
+		},
+	}
+	resources := map[string]*sdkschema.Resource{"akamai_test": res}
+	addSDKAccountSwitchKey(resources, true)
+	// wrapping twice has no effect
+	addSDKAccountSwitchKey(resources, true)
+	require.NoError(t, res.InternalValidate(nil, true))
+	assert.True(t, res.Schema[accountSwitchKeyAttribute].ForceNew)
+
+	d := sdkschema.TestResourceDataRaw(t, res.Schema, map[string]any{"name": "test", accountSwitchKeyAttribute: "1-ABCD"})
+	assert.False(t, res.ReadContext(context.Background(), d, testAccountMeta{}).HasError())
+	assert.False(t, res.DeleteContext(context.Background(), d, testAccountMeta{}).HasError())
+
+	d = sdkschema.TestResourceDataRaw(t, res.Schema, map[string]any{})
+	d.SetId("123?accountSwitchKey=1-EFGH")
+	imported, err := res.Importer.StateContext(context.Background(), d, testAccountMeta{})
+	require.NoError(t, err)
+	require.Len(t, imported, 1)
+	assert.Equal(t, "123", imported[0].Id())
+	assert.Equal(t, "1-EFGH", imported[0].Get(accountSwitchKeyAttribute))
+
+	assert.Equal(t, []string{"1-ABCD", "1-ABCD", "1-EFGH"}, usedKeys)
+}
+
+type testAccountResource struct {
+	meta meta.Meta
+	// usedKeys is shared by all instances to track keys used for every operation
+	usedKeys *[]string
+}
+
+type testAccountResourceModel struct {
+	ID   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+}
+
+func (r *testAccountResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "akamai_test"
+}
+
+func (r *testAccountResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":   schema.StringAttribute{Computed: true},
+			"name": schema.StringAttribute{Required: true},
+		},
+	}
+}
+
+func (r *testAccountResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData != nil {
+		r.meta = meta.Must(req.ProviderData)
+	}
+}
+
+func (r *testAccountResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if tf.ResourceTypeFromContext(ctx) != "akamai_test" {
+		resp.Diagnostics.AddError("missing resource type", tf.ResourceTypeFromContext(ctx))
+	}
+	*r.usedKeys = append(*r.usedKeys, r.meta.AccountSwitchKey())
+	var data testAccountResourceModel
+	if resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...); resp.Diagnostics.HasError() {
+		return
+	}
+	data.ID = types.StringValue("1")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *testAccountResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	*r.usedKeys = append(*r.usedKeys, r.meta.AccountSwitchKey())
+	var data testAccountResourceModel
+	if resp.Diagnostics.Append(req.State.Get(ctx, &data)...); resp.Diagnostics.HasError() {
+		return
+	}
+	data.Name = types.StringValue("read")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *testAccountResource) Update(context.Context, resource.UpdateRequest, *resource.UpdateResponse) {
+}
+
+func (r *testAccountResource) Delete(context.Context, resource.DeleteRequest, *resource.DeleteResponse) {
+}
+
+func (r *testAccountResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	*r.usedKeys = append(*r.usedKeys, r.meta.AccountSwitchKey())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &testAccountResourceModel{ID: types.StringValue(req.ID), Name: types.StringNull()})...)
+}
+
+// UpgradeState upgrades state of version 0, which had title instead of name
+func (r *testAccountResource) UpgradeState(context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id":    schema.StringAttribute{Computed: true},
+					"title": schema.StringAttribute{Required: true},
+				},
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var id, title types.String
+				resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &id)...)
+				resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("title"), &title)...)
+				resp.Diagnostics.Append(resp.State.Set(ctx, &testAccountResourceModel{ID: id, Name: title})...)
+			},
+		},
+	}
+}
+
+// MoveState moves state of akamai_old_test, which had label instead of name
+func (r *testAccountResource) MoveState(context.Context) []resource.StateMover {
+	return []resource.StateMover{{
+		SourceSchema: &schema.Schema{
+			Attributes: map[string]schema.Attribute{
+				"id":    schema.StringAttribute{Computed: true},
+				"label": schema.StringAttribute{Required: true},
+			},
+		},
+		StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+			if req.SourceTypeName != "akamai_old_test" {
+				return
+			}
+			var id, label types.String
+			resp.Diagnostics.Append(req.SourceState.GetAttribute(ctx, path.Root("id"), &id)...)
+			resp.Diagnostics.Append(req.SourceState.GetAttribute(ctx, path.Root("label"), &label)...)
+			resp.Diagnostics.Append(resp.TargetState.Set(ctx, &testAccountResourceModel{ID: id, Name: label})...)
+		},
+	}}
+}
+
+type testAccountDataSource struct {
+	meta     meta.Meta
+	usedKeys *[]string
+}
+
+func (d *testAccountDataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "akamai_test"
+}
+
+func (d *testAccountDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = dataschema.Schema{
+		Attributes: map[string]dataschema.Attribute{
+			"id":   dataschema.StringAttribute{Computed: true},
+			"name": dataschema.StringAttribute{Required: true},
+		},
+	}
+}
+
+func (d *testAccountDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData != nil {
+		d.meta = meta.Must(req.ProviderData)
+	}
+}
+
+func (d *testAccountDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	*d.usedKeys = append(*d.usedKeys, d.meta.AccountSwitchKey())
+	var data testAccountResourceModel
+	if resp.Diagnostics.Append(req.Config.Get(ctx, &data)...); resp.Diagnostics.HasError() {
+		return
+	}
+	data.ID = types.StringValue("1")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func TestFrameworkAccountSwitchKey(t *testing.T) {
+	ctx := context.Background()
+	var usedKeys []string
+
+	res := withFrameworkAccountSwitchKey(func() resource.Resource {
+		return &testAccountResource{usedKeys: &usedKeys}
+	})()
+	var schemaResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+	require.Contains(t, schemaResp.Schema.Attributes, accountSwitchKeyAttribute)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	object := func(id, name, key any) tftypes.Value {
+		return tftypes.NewValue(objectType, map[string]tftypes.Value{
+			"id":                      tftypes.NewValue(tftypes.String, id),
+			"name":                    tftypes.NewValue(tftypes.String, name),
+			accountSwitchKeyAttribute: tftypes.NewValue(tftypes.String, key),
+		})
+	}
+	state := func(raw tftypes.Value) tfsdk.State {
+		return tfsdk.State{Schema: schemaResp.Schema, Raw: raw}
+	}
+
+	res.(resource.ResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{ProviderData: testAccountMeta{}}, &resource.ConfigureResponse{})
+
+	t.Run("create", func(t *testing.T) {
+		plan := object(tftypes.UnknownValue, "test", "1-ABCD")
+		resp := resource.CreateResponse{State: state(tftypes.NewValue(objectType, nil))}
+		res.Create(ctx, resource.CreateRequest{
+			Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: object(nil, "test", "1-ABCD")},
+			Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: plan},
+		}, &resp)
+		require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+		assert.True(t, object("1", "test", "1-ABCD").Equal(resp.State.Raw), resp.State.Raw.String())
+	})
+
+	t.Run("read", func(t *testing.T) {
+		prior := object("1", "test", "1-EFGH")
+		resp := resource.ReadResponse{State: state(prior)}
+		res.Read(ctx, resource.ReadRequest{State: state(prior)}, &resp)
+		require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+		assert.True(t, object("1", "read", "1-EFGH").Equal(resp.State.Raw), resp.State.Raw.String())
+	})
+
+	t.Run("import", func(t *testing.T) {
+		resp := resource.ImportStateResponse{State: state(tftypes.NewValue(objectType, nil))}
+		res.(resource.ResourceWithImportState).ImportState(ctx, resource.ImportStateRequest{ID: "2?accountSwitchKey=1-IJKL"}, &resp)
+		require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+		assert.True(t, object("2", nil, "1-IJKL").Equal(resp.State.Raw), resp.State.Raw.String())
+	})
+
+	t.Run("upgrade state", func(t *testing.T) {
+		upgrader, ok := res.(resource.ResourceWithUpgradeState).UpgradeState(ctx)[0]
+		require.True(t, ok)
+		require.Contains(t, upgrader.PriorSchema.Attributes, accountSwitchKeyAttribute)
+
+		rawState := &tfprotov6.RawState{JSON: []byte(`{"id":"1","title":"old","account_switch_key":"1-MNOP"}`)}
+		prior, err := rawState.Unmarshal(upgrader.PriorSchema.Type().TerraformType(ctx))
+		require.NoError(t, err)
+		resp := resource.UpgradeStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		upgrader.StateUpgrader(ctx, resource.UpgradeStateRequest{
+			RawState: rawState,
+			State:    &tfsdk.State{Schema: *upgrader.PriorSchema, Raw: prior},
+		}, &resp)
+		require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+		assert.True(t, object("1", "old", "1-MNOP").Equal(resp.State.Raw), resp.State.Raw.String())
+	})
+
+	t.Run("move state", func(t *testing.T) {
+		movers := res.(resource.ResourceWithMoveState).MoveState(ctx)
+		require.Len(t, movers, 1)
+
+		rawState := &tfprotov6.RawState{JSON: []byte(`{"id":"3","label":"moved","account_switch_key":"1-QRST"}`)}
+		source, err := rawState.UnmarshalWithOpts(movers[0].SourceSchema.Type().TerraformType(ctx),
+			tfprotov6.UnmarshalOpts{ValueFromJSONOpts: tftypes.ValueFromJSONOpts{IgnoreUndefinedAttributes: true}})
+		require.NoError(t, err)
+		resp := resource.MoveStateResponse{TargetState: state(tftypes.NewValue(objectType, nil))}
+		movers[0].StateMover(ctx, resource.MoveStateRequest{
+			SourceRawState: rawState,
+			SourceState:    &tfsdk.State{Schema: *movers[0].SourceSchema, Raw: source},
+			SourceTypeName: "akamai_old_test",
+		}, &resp)
+		require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+		assert.True(t, object("3", "moved", "1-QRST").Equal(resp.TargetState.Raw), resp.TargetState.Raw.String())
+	})
+
+	t.Run("data source", func(t *testing.T) {
+		ds := withFrameworkDataSourceAccountSwitchKey(func() datasource.DataSource {
+			return &testAccountDataSource{usedKeys: &usedKeys}
+		})()
+		var dsSchemaResp datasource.SchemaResponse
+		ds.Schema(ctx, datasource.SchemaRequest{}, &dsSchemaResp)
+		require.Contains(t, dsSchemaResp.Schema.Attributes, accountSwitchKeyAttribute)
+		ds.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: testAccountMeta{}}, &datasource.ConfigureResponse{})
+
+		config := object(nil, "test", nil)
+		resp := datasource.ReadResponse{State: tfsdk.State{Schema: dsSchemaResp.Schema, Raw: config}}
+		ds.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: dsSchemaResp.Schema, Raw: config}}, &resp)
+		require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+		assert.True(t, object("1", "test", nil).Equal(resp.State.Raw), resp.State.Raw.String())
+	})
+
+	assert.Equal(t, []string{"1-ABCD", "1-EFGH", "1-IJKL", ""}, usedKeys)
+}
@@ -0,0 +1,19 @@
+package akamai
+
+const (
+	// maxConcurrentActivationsEnv is the environment variable holding the maximum number of concurrent activations,
+	// used when the max_concurrent_activations argument is not configured
+	maxConcurrentActivationsEnv = "AKAMAI_MAX_CONCURRENT_ACTIVATIONS"
+
+	// maxConcurrentActivationsPerNetworkEnv is the environment variable holding the maximum number of concurrent activations
+	// on each network, used when the max_concurrent_activations_per_network argument is not configured
+	maxConcurrentActivationsPerNetworkEnv = "AKAMAI_MAX_CONCURRENT_ACTIVATIONS_PER_NETWORK"
+
+	maxConcurrentActivationsDescription = "The maximum number of activations of all resources, e.g. properties and network lists, " +
+		"submitted and awaited at the same time on all networks (0 for no limit). Other activations wait for a free slot before being submitted. " +
+		"The time waiting counts toward the create, update and delete timeouts of their resources"
+
+	maxConcurrentActivationsPerNetworkDescription = "The maximum number of activations of all resources submitted and awaited " +
+		"at the same time on each network, staging and production separately (0 for no limit). " +
+		"The time waiting for a free slot counts toward the create, update and delete timeouts of their resources"
+)
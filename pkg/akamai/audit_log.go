@@ -0,0 +1,147 @@
+package akamai
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/apex/log"
+)
+
+const (
+	// auditLogPathEnv is the environment variable holding the path of the audit log,
+	// used when the audit_log_path argument is not configured
+	auditLogPathEnv = "AKAMAI_AUDIT_LOG_PATH"
+
+	auditLogPathDescription = "The path of a file to which one JSON line is appended for every API request which may modify objects, " +
+		"with its operation ID, resource type, method, path, response status, Akamai request ID and a digest of the redacted body"
+)
+
+var (
+	// ErrAuditLog is returned when the audit log cannot be opened or written
+	ErrAuditLog = errors.New("audit log")
+
+	// auditLogs holds audit logs opened by this process per path, so that all sessions share the same file
+	auditLogs      = make(map[string]*auditLogWriter)
+	auditLogsMutex sync.Mutex
+
+	// auditRequestIDHeaders are response headers holding the ID Akamai assigned to the request
+	auditRequestIDHeaders = []string{"X-Akamai-Request-ID", "X-Trace-ID"}
+)
+
+type (
+	// auditTransport appends an entry to the audit log for every request which may modify objects.
+	// Retried requests are logged once per attempt, as each of them may have been processed.
+	auditTransport struct {
+		base        http.RoundTripper
+		log         *auditLogWriter
+		operationID string
+		logger      log.Interface
+	}
+
+	auditLogWriter struct {
+		mu   sync.Mutex
+		file *os.File
+	}
+
+	auditEntry struct {
+		Timestamp    string `json:"timestamp"`
+		OperationID  string `json:"operation_id"`
+		ResourceType string `json:"resource_type,omitempty"`
+		Method       string `json:"method"`
+		Path         string `json:"path"`
+		Status       int    `json:"status"`
+		RequestID    string `json:"request_id,omitempty"`
+		BodyDigest   string `json:"body_digest,omitempty"`
+		Error        string `json:"error,omitempty"`
+	}
+)
+
+// newAuditTransport wraps base with the audit log written to the given path
+func newAuditTransport(base http.RoundTripper, path, operationID string, logger log.Interface) (*auditTransport, error) {
+	auditLogsMutex.Lock()
+	defer auditLogsMutex.Unlock()
+
+	w, ok := auditLogs[path]
+	if !ok {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrAuditLog, err)
+		}
+		w = &auditLogWriter{file: file}
+		auditLogs[path] = w
+	}
+
+	return &auditTransport{base: base, log: w, operationID: operationID, logger: logger}, nil
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *auditTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if isReadOnlyRequest(r) {
+		return t.base.RoundTrip(r)
+	}
+
+	body, err := readBody(&r.Body)
+	if err != nil {
+		return nil, err
+	}
+	entry := auditEntry{
+		Timestamp:    time.Now().UTC().Format(time.RFC3339Nano),
+		OperationID:  t.operationID,
+		ResourceType: tf.ResourceTypeFromContext(r.Context()),
+		Method:       r.Method,
+		Path:         r.URL.Path,
+		BodyDigest:   bodyDigest(body),
+	}
+
+	resp, err := t.base.RoundTrip(r)
+	if err != nil {
+		entry.Error = err.Error()
+	} else {
+		entry.Status = resp.StatusCode
+		for _, h := range auditRequestIDHeaders {
+			if id := resp.Header.Get(h); id != "" {
+				entry.RequestID = id
+				break
+			}
+		}
+	}
+
+	// the request was already sent, so failing it would only hide a change which may have been applied
+	if writeErr := t.log.write(entry); writeErr != nil && t.logger != nil {
+		t.logger.WithError(writeErr).Errorf("Could not audit %s %s", entry.Method, entry.Path)
+	}
+	return resp, err
+}
+
+func (w *auditLogWriter) write(entry auditEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrAuditLog, err)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, err := w.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("%w: %s", ErrAuditLog, err)
+	}
+	return nil
+}
+
+// bodyDigest returns the SHA-256 digest of the body with secrets redacted, so that the body
+// can be matched against a known one without being written to the audit log
+func bodyDigest(body []byte) string {
+	normalized := normalizeBody(body)
+	if normalized == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(normalized))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
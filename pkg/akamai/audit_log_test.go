@@ -0,0 +1,107 @@
+package akamai
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditTransport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	base := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		if r.Method == http.MethodDelete {
+			return nil, errors.New("connection reset")
+		}
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Header:     http.Header{"X-Akamai-Request-Id": []string{"req-" + r.Method}},
+			Body:       io.NopCloser(strings.NewReader(string(body))),
+		}, nil
+	})
+	transport, err := newAuditTransport(base, path, "op-1", nil)
+	require.NoError(t, err)
+
+	send := func(method, path, body string) {
+		ctx := tf.WithResourceType(context.Background(), "akamai_dns_zone")
+		req := httptest.NewRequest(method, "https://akaa-xxx.luna.akamaiapis.net"+path, strings.NewReader(body)).WithContext(ctx)
+		resp, err := transport.RoundTrip(req)
+		if method == http.MethodDelete {
+			assert.Error(t, err)
+			return
+		}
+		require.NoError(t, err)
+		sent, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, body, string(sent))
+	}
+	send(http.MethodGet, "/config-dns/v2/zones", "")
+	send(http.MethodPost, "/papi/v1/search/find-by-value", `{"propertyName": "test"}`)
+	send(http.MethodPost, "/config-dns/v2/zones", `{"zone": "example.com", "tsigKey": {"secret": "s3cr3t"}}`)
+	send(http.MethodPut, "/config-dns/v2/zones/example.com", `{ "tsigKey": {"secret": "other"}, "zone": "example.com"}`)
+	send(http.MethodDelete, "/config-dns/v2/zones/example.com", "")
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "s3cr3t")
+
+	var entries []auditEntry
+	scanner := bufio.NewScanner(strings.NewReader(string(content)))
+	for scanner.Scan() {
+		var entry auditEntry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		assert.NotEmpty(t, entry.Timestamp)
+		entry.Timestamp = ""
+		entries = append(entries, entry)
+	}
+	require.Len(t, entries, 3)
+
+	// redacted bodies of both writes are the same, apart from whitespace and order of keys
+	digest := entries[0].BodyDigest
+	assert.True(t, strings.HasPrefix(digest, "sha256:"))
+	assert.Equal(t, []auditEntry{
+		{
+			OperationID:  "op-1",
+			ResourceType: "akamai_dns_zone",
+			Method:       http.MethodPost,
+			Path:         "/config-dns/v2/zones",
+			Status:       http.StatusCreated,
+			RequestID:    "req-POST",
+			BodyDigest:   digest,
+		},
+		{
+			OperationID:  "op-1",
+			ResourceType: "akamai_dns_zone",
+			Method:       http.MethodPut,
+			Path:         "/config-dns/v2/zones/example.com",
+			Status:       http.StatusCreated,
+			RequestID:    "req-PUT",
+			BodyDigest:   digest,
+		},
+		{
+			OperationID:  "op-1",
+			ResourceType: "akamai_dns_zone",
+			Method:       http.MethodDelete,
+			Path:         "/config-dns/v2/zones/example.com",
+			Error:        "connection reset",
+		},
+	}, entries)
+}
+
+func TestAuditTransportInvalidPath(t *testing.T) {
+	_, err := newAuditTransport(http.DefaultTransport, filepath.Join(t.TempDir(), "missing", "audit.log"), "op-1", nil)
+	assert.ErrorIs(t, err, ErrAuditLog)
+}
@@ -0,0 +1,48 @@
+package akamai
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// caCertFileEnv is the environment variable pointing to a PEM file of CA certificates trusted in addition to
+// the system ones, e.g. the certificate of a fake API used in tests or of a TLS intercepting proxy
+const caCertFileEnv = "AKAMAI_CA_CERT_FILE"
+
+// ErrCACertFile is returned when the CA certificates of AKAMAI_CA_CERT_FILE cannot be trusted
+var ErrCACertFile = errors.New("additional CA certificates")
+
+// caCertTransport returns base trusting the CA certificates of the AKAMAI_CA_CERT_FILE file, base itself when not set
+func caCertTransport(base http.RoundTripper) (http.RoundTripper, error) {
+	path := os.Getenv(caCertFileEnv)
+	if path == "" {
+		return base, nil
+	}
+
+	certs, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrCACertFile, err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(certs) {
+		return nil, fmt.Errorf("%w: %s has no PEM encoded certificates", ErrCACertFile, path)
+	}
+
+	transport, ok := base.(*http.Transport)
+	if !ok {
+		return nil, fmt.Errorf("%w: transport %T cannot be configured", ErrCACertFile, base)
+	}
+	transport = transport.Clone()
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	transport.TLSClientConfig.RootCAs = pool
+	return transport, nil
+}
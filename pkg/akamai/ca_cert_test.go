@@ -0,0 +1,67 @@
+package akamai
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/edgegrid"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/papi"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/testutils/fakeapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCACertTransport(t *testing.T) {
+	t.Run("not set", func(t *testing.T) {
+		t.Setenv(caCertFileEnv, "")
+		transport, err := caCertTransport(http.DefaultTransport)
+		require.NoError(t, err)
+		assert.Same(t, http.DefaultTransport, transport)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		t.Setenv(caCertFileEnv, filepath.Join(t.TempDir(), "missing.pem"))
+		_, err := caCertTransport(http.DefaultTransport)
+		assert.ErrorIs(t, err, ErrCACertFile)
+	})
+
+	t.Run("no certificates", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "ca.pem")
+		require.NoError(t, os.WriteFile(path, []byte("not a certificate"), 0600))
+		t.Setenv(caCertFileEnv, path)
+		_, err := caCertTransport(http.DefaultTransport)
+		assert.ErrorIs(t, err, ErrCACertFile)
+		assert.ErrorContains(t, err, "has no PEM encoded certificates")
+	})
+}
+
+func TestCACertFileSession(t *testing.T) {
+	server := fakeapi.NewTest(t)
+	newClient := func(t *testing.T, retryDisabled bool) papi.PAPI {
+		meta, err := configureContext(contextConfig{
+			edgegridConfig: &edgegrid.Config{Host: server.Host(), ClientToken: "t", ClientSecret: "s", AccessToken: "a"},
+			ctx:            context.Background(),
+			retryDisabled:  retryDisabled,
+			retryMax:       1,
+		})
+		require.NoError(t, err)
+		return papi.Client(meta.Session())
+	}
+
+	for name, retryDisabled := range map[string]bool{"with retries": false, "without retries": true} {
+		t.Run(name, func(t *testing.T) {
+			contracts, err := newClient(t, retryDisabled).GetContracts(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, fakeapi.ContractID, contracts.Contracts.Items[0].ContractID)
+		})
+	}
+
+	t.Run("untrusted certificate", func(t *testing.T) {
+		t.Setenv(caCertFileEnv, "")
+		_, err := newClient(t, true).GetContracts(context.Background())
+		assert.ErrorContains(t, err, "certificate")
+	})
+}
@@ -0,0 +1,297 @@
+package akamai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	// time zones of change freeze windows have to be known also on systems without the time zone database
+	_ "time/tzdata"
+
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// changeFreezeEnv is the environment variable holding change freeze windows as a JSON array,
+	// used when the change_freeze block is not configured
+	changeFreezeEnv = "AKAMAI_CHANGE_FREEZE"
+
+	// changeFreezeOverrideAttribute is added to every resource activating changes, so that emergency changes
+	// can be applied during a change freeze
+	changeFreezeOverrideAttribute = "change_freeze_override"
+
+	changeFreezeDescription         = "Windows of a change freeze during which activations to their networks fail at plan time"
+	changeFreezeStartDescription    = "The start of the window, e.g. 2025-11-28T00:00:00, 2025-11-28 00:00, 2025-11-28 or 2025-11-28T00:00:00-05:00"
+	changeFreezeEndDescription      = "The end of the window, excluded, in the same format as start"
+	changeFreezeTimezoneDescription = "The IANA time zone of start and end without an offset, e.g. America/New_York, UTC when not set"
+	changeFreezeNetworksDescription = "The frozen networks, staging or production, all networks when not set"
+	changeFreezeReasonDescription   = "The reason of the change freeze shown to users, e.g. Black Friday"
+
+	changeFreezeOverrideDescription = "The reason of an emergency change applied during a change freeze of the provider configuration. " +
+		"The change freeze is not enforced for the resource when set. To destroy the resource during a change freeze, apply it first."
+)
+
+var (
+	// ErrChangeFreeze is returned when a change freeze window is invalid, or a change is planned during a change freeze
+	ErrChangeFreeze = errors.New("change freeze")
+
+	// frozenResources holds, per resource type, the network a planned change of the resource is activated on
+	frozenResources = map[string]frozenNetwork{
+		"akamai_property_activation":                            networkAttribute("network"),
+		"akamai_property_include_activation":                    networkAttribute("network"),
+		"akamai_appsec_activations":                             networkAttribute("network"),
+		"akamai_networklist_activations":                        networkAttribute("network"),
+		"akamai_clientlist_activation":                          networkAttribute("network"),
+		"akamai_cloudlets_policy_activation":                    networkAttribute("network"),
+		"akamai_cloudlets_application_load_balancer_activation": networkAttribute("network"),
+		"akamai_edgeworkers_activation":                         networkAttribute("network"),
+		// changes of GTM domains are propagated to production right away
+		"akamai_gtm_domain":     fixedNetwork("production"),
+		"akamai_gtm_property":   fixedNetwork("production"),
+		"akamai_gtm_datacenter": fixedNetwork("production"),
+		"akamai_gtm_resource":   fixedNetwork("production"),
+		"akamai_gtm_asmap":      fixedNetwork("production"),
+		"akamai_gtm_geomap":     fixedNetwork("production"),
+		"akamai_gtm_cidrmap":    fixedNetwork("production"),
+	}
+
+	// changeFreezeIgnoredAttributes are attributes whose changes alone are not activated
+	changeFreezeIgnoredAttributes = []string{changeFreezeOverrideAttribute, "timeouts"}
+
+	// changeFreezeLayouts are the layouts of start and end of windows interpreted in the window time zone
+	changeFreezeLayouts = []string{"2006-01-02T15:04:05", "2006-01-02 15:04", "2006-01-02"}
+
+	// now returns the time the change freeze is checked against
+	now = time.Now
+)
+
+type (
+	// frozenNetwork returns the network, staging or production, a planned change, or the deletion, of the resource is activated on
+	frozenNetwork func(d resourceGetter) string
+
+	// resourceGetter reads attributes of a planned change, schema.ResourceDiff, or of the state of a deleted resource, schema.ResourceData
+	resourceGetter interface {
+		Get(key string) any
+	}
+
+	// changeFreezeWindow is a change freeze window as configured, in the change_freeze block or the environment variable
+	changeFreezeWindow struct {
+		Start    string   `json:"start"`
+		End      string   `json:"end"`
+		Timezone string   `json:"timezone"`
+		Networks []string `json:"networks"`
+		Reason   string   `json:"reason"`
+	}
+)
+
+func networkAttribute(name string) frozenNetwork {
+	return func(d resourceGetter) string {
+		network, _ := d.Get(name).(string)
+		return network
+	}
+}
+
+func fixedNetwork(network string) frozenNetwork {
+	return func(resourceGetter) string {
+		return network
+	}
+}
+
+// changeFreezeFromEnv reads change freeze windows from the AKAMAI_CHANGE_FREEZE environment variable, if set
+func changeFreezeFromEnv() ([]changeFreezeWindow, error) {
+	v := os.Getenv(changeFreezeEnv)
+	if v == "" {
+		return nil, nil
+	}
+	var windows []changeFreezeWindow
+	if err := json.Unmarshal([]byte(v), &windows); err != nil {
+		return nil, fmt.Errorf("%w: cannot parse %s: %s", ErrChangeFreeze, changeFreezeEnv, err)
+	}
+	return windows, nil
+}
+
+// newChangeFreeze returns the configured windows with their start and end resolved in their time zones,
+// and their networks normalized
+func newChangeFreeze(windows []changeFreezeWindow) ([]meta.FreezeWindow, error) {
+	freeze := make([]meta.FreezeWindow, 0, len(windows))
+	for i, w := range windows {
+		location := time.UTC
+		if w.Timezone != "" {
+			var err error
+			if location, err = time.LoadLocation(w.Timezone); err != nil {
+				return nil, fmt.Errorf("%w: time zone %q of window %d is unknown", ErrChangeFreeze, w.Timezone, i)
+			}
+		}
+		start, err := parseChangeFreezeTime(w.Start, location)
+		if err != nil {
+			return nil, fmt.Errorf("%w: start of window %d: %s", ErrChangeFreeze, i, err)
+		}
+		end, err := parseChangeFreezeTime(w.End, location)
+		if err != nil {
+			return nil, fmt.Errorf("%w: end of window %d: %s", ErrChangeFreeze, i, err)
+		}
+		if !end.After(start) {
+			return nil, fmt.Errorf("%w: end %q of window %d has to be after its start %q", ErrChangeFreeze, w.End, i, w.Start)
+		}
+
+		var networks []string
+		for _, network := range w.Networks {
+			normalized := tf.StateNetwork(network)
+			if normalized != "staging" && normalized != "production" {
+				return nil, fmt.Errorf("%w: network %q of window %d has to be staging or production", ErrChangeFreeze, network, i)
+			}
+			networks = append(networks, normalized)
+		}
+		freeze = append(freeze, meta.FreezeWindow{Start: start, End: end, Networks: networks, Reason: w.Reason})
+	}
+	return freeze, nil
+}
+
+func parseChangeFreezeTime(value string, location *time.Location) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	for _, layout := range changeFreezeLayouts {
+		if t, err := time.ParseInLocation(layout, value, location); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%q is not a time like 2025-11-28T00:00:00, 2025-11-28 00:00, 2025-11-28 or 2025-11-28T00:00:00-05:00", value)
+}
+
+// addSDKChangeFreeze adds the change_freeze_override attribute to the resources activating changes, and makes
+// planning their changes, and deleting them, fail during the change freeze windows of their networks
+func addSDKChangeFreeze(resources map[string]*schema.Resource) {
+	for name, network := range frozenResources {
+		r, ok := resources[name]
+		if !ok {
+			continue
+		}
+		r.Schema[changeFreezeOverrideAttribute] = &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: changeFreezeOverrideDescription,
+		}
+		r.CustomizeDiff = withSDKChangeFreeze(name, network, r.CustomizeDiff)
+		r.UpdateContext = withoutChangeFreezeOverrideUpdate(r.UpdateContext)
+		r.DeleteContext = withChangeFreezeDelete(name, network, r.DeleteContext)
+	}
+}
+
+func withSDKChangeFreeze(resourceType string, network frozenNetwork, f schema.CustomizeDiffFunc) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, m any) error {
+		if f != nil {
+			if err := f(ctx, d, m); err != nil {
+				return err
+			}
+		}
+		if !hasActivatedChanges(d) {
+			return nil
+		}
+		window, frozen := changeFreezeWindowOf(providerChangeFreeze(m), network(d), now())
+		if !frozen {
+			return nil
+		}
+		if changeFreezeOverridden(resourceType, "change", d, m) {
+			return nil
+		}
+		return changeFreezeError(resourceType, network(d), window)
+	}
+}
+
+// withChangeFreezeDelete makes deleting the resource, e.g. deactivating it, fail during the change freeze windows of its network.
+// Deletion is planned without the configuration, so the override has to be applied to the state before.
+func withChangeFreezeDelete(resourceType string, network frozenNetwork, f schema.DeleteContextFunc) schema.DeleteContextFunc {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+		window, frozen := changeFreezeWindowOf(providerChangeFreeze(m), network(d), now())
+		if frozen && !changeFreezeOverridden(resourceType, "deletion", d, m) {
+			return diag.FromErr(changeFreezeError(resourceType, network(d), window))
+		}
+		return f(ctx, d, m)
+	}
+}
+
+// changeFreezeOverridden tells if change_freeze_override is set, logging its reason
+func changeFreezeOverridden(resourceType, change string, d resourceGetter, m any) bool {
+	override, _ := d.Get(changeFreezeOverrideAttribute).(string)
+	if override == "" {
+		return false
+	}
+	if providerMeta, ok := m.(meta.Meta); ok {
+		providerMeta.Log("ChangeFreeze", resourceType).Warnf("%s of %s during a change freeze overridden: %s", change, resourceType, override)
+	}
+	return true
+}
+
+// withoutChangeFreezeOverrideUpdate skips updates changing only change_freeze_override, so that setting it
+// does not activate anything by itself
+func withoutChangeFreezeOverrideUpdate(f schema.UpdateContextFunc) schema.UpdateContextFunc {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+		if !d.HasChangeExcept(changeFreezeOverrideAttribute) {
+			return nil
+		}
+		return f(ctx, d, m)
+	}
+}
+
+// hasActivatedChanges tells if the resource is planned to be created or changed, not counting ignored attributes
+func hasActivatedChanges(d *schema.ResourceDiff) bool {
+	if d.Id() == "" {
+		return true
+	}
+	for _, key := range d.GetChangedKeysPrefix("") {
+		attribute, _, _ := strings.Cut(key, ".")
+		if !slices.Contains(changeFreezeIgnoredAttributes, attribute) {
+			return true
+		}
+	}
+	return false
+}
+
+// changeFreezeWindowOf returns the window freezing the network at the given time, if any
+func changeFreezeWindowOf(windows []meta.FreezeWindow, network string, at time.Time) (meta.FreezeWindow, bool) {
+	network = tf.StateNetwork(network)
+	for _, w := range windows {
+		if at.Before(w.Start) || !at.Before(w.End) {
+			continue
+		}
+		if len(w.Networks) == 0 || slices.Contains(w.Networks, network) {
+			return w, true
+		}
+	}
+	return meta.FreezeWindow{}, false
+}
+
+func changeFreezeError(resourceType, network string, window meta.FreezeWindow) error {
+	const layout = "2006-01-02 15:04 MST"
+	reason := ""
+	if window.Reason != "" {
+		reason = fmt.Sprintf(" (%s)", window.Reason)
+	}
+	return fmt.Errorf("%w: %s cannot be changed on the %s network from %s until %s%s. "+
+		"Set %q to the reason of an emergency change to apply it anyway",
+		ErrChangeFreeze, resourceType, tf.StateNetwork(network), window.Start.Format(layout), window.End.Format(layout), reason,
+		changeFreezeOverrideAttribute)
+}
+
+// providerChangeFreeze returns the change freeze windows of the meta, none before the provider is configured
+func providerChangeFreeze(m any) []meta.FreezeWindow {
+	if providerMeta, ok := m.(meta.Meta); ok {
+		return providerMeta.ChangeFreeze()
+	}
+	return nil
+}
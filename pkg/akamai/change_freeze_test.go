@@ -0,0 +1,260 @@
+package akamai
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/providers/registry"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	sdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewChangeFreeze(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		windows   []changeFreezeWindow
+		expected  []meta.FreezeWindow
+		withError string
+	}{
+		"no windows": {
+			expected: []meta.FreezeWindow{},
+		},
+		"window in a time zone": {
+			windows: []changeFreezeWindow{{
+				Start:    "2025-11-28",
+				End:      "2025-12-01 06:00",
+				Timezone: "America/New_York",
+				Networks: []string{"PROD", "staging"},
+				Reason:   "Black Friday",
+			}},
+			expected: []meta.FreezeWindow{{
+				Start:    time.Date(2025, 11, 28, 0, 0, 0, 0, newYork),
+				End:      time.Date(2025, 12, 1, 6, 0, 0, 0, newYork),
+				Networks: []string{"production", "staging"},
+				Reason:   "Black Friday",
+			}},
+		},
+		"window with offsets": {
+			windows: []changeFreezeWindow{{Start: "2025-11-28T00:00:00Z", End: "2025-11-29T00:00:00+01:00"}},
+			expected: []meta.FreezeWindow{{
+				Start: time.Date(2025, 11, 28, 0, 0, 0, 0, time.UTC),
+				End:   time.Date(2025, 11, 28, 23, 0, 0, 0, time.UTC),
+			}},
+		},
+		"unknown time zone": {
+			windows:   []changeFreezeWindow{{Start: "2025-11-28", End: "2025-11-29", Timezone: "Mars/Olympus"}},
+			withError: `time zone "Mars/Olympus" of window 0 is unknown`,
+		},
+		"invalid start": {
+			windows:   []changeFreezeWindow{{Start: "28.11.2025", End: "2025-11-29"}},
+			withError: `start of window 0: "28.11.2025" is not a time`,
+		},
+		"end before start": {
+			windows:   []changeFreezeWindow{{Start: "2025-11-29", End: "2025-11-28"}},
+			withError: `end "2025-11-28" of window 0 has to be after its start "2025-11-29"`,
+		},
+		"invalid network": {
+			windows:   []changeFreezeWindow{{Start: "2025-11-28", End: "2025-11-29", Networks: []string{"qa"}}},
+			withError: `network "qa" of window 0 has to be staging or production`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			freeze, err := newChangeFreeze(test.windows)
+			if test.withError != "" {
+				assert.ErrorIs(t, err, ErrChangeFreeze)
+				assert.ErrorContains(t, err, test.withError)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, freeze, len(test.expected))
+			for i, w := range test.expected {
+				assert.True(t, w.Start.Equal(freeze[i].Start), "start %s, got %s", w.Start, freeze[i].Start)
+				assert.True(t, w.End.Equal(freeze[i].End), "end %s, got %s", w.End, freeze[i].End)
+				assert.Equal(t, w.Networks, freeze[i].Networks)
+				assert.Equal(t, w.Reason, freeze[i].Reason)
+			}
+		})
+	}
+}
+
+func TestChangeFreezeFromEnv(t *testing.T) {
+	t.Setenv(changeFreezeEnv, `[{"start": "2025-11-28", "end": "2025-12-01", "networks": ["production"]}]`)
+	windows, err := changeFreezeFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, []changeFreezeWindow{{Start: "2025-11-28", End: "2025-12-01", Networks: []string{"production"}}}, windows)
+
+	t.Setenv(changeFreezeEnv, `{`)
+	_, err = changeFreezeFromEnv()
+	assert.ErrorIs(t, err, ErrChangeFreeze)
+}
+
+func TestChangeFreezeWindowOf(t *testing.T) {
+	start := time.Date(2025, 11, 28, 0, 0, 0, 0, time.UTC)
+	windows := []meta.FreezeWindow{
+		{Start: start, End: start.Add(24 * time.Hour), Networks: []string{"production"}, Reason: "production only"},
+		{Start: start.Add(48 * time.Hour), End: start.Add(72 * time.Hour), Reason: "all networks"},
+	}
+
+	tests := map[string]struct {
+		network  string
+		at       time.Time
+		expected string
+	}{
+		"before windows":             {network: "PRODUCTION", at: start.Add(-time.Second)},
+		"start of production window": {network: "PRODUCTION", at: start, expected: "production only"},
+		"production alias":           {network: "prod", at: start.Add(time.Hour), expected: "production only"},
+		"staging is not frozen":      {network: "STAGING", at: start.Add(time.Hour)},
+		"end is excluded":            {network: "PRODUCTION", at: start.Add(24 * time.Hour)},
+		"all networks":               {network: "STAGING", at: start.Add(50 * time.Hour), expected: "all networks"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			window, frozen := changeFreezeWindowOf(windows, test.network, test.at)
+			assert.Equal(t, test.expected != "", frozen)
+			assert.Equal(t, test.expected, window.Reason)
+		})
+	}
+}
+
+func TestSDKChangeFreeze(t *testing.T) {
+	noop := func(context.Context, *sdkschema.ResourceData, any) diag.Diagnostics { return nil }
+	var updates, deletes int
+	res := &sdkschema.Resource{
+		Schema: map[string]*sdkschema.Schema{
+			"version": {Type: sdkschema.TypeInt, Required: true},
+			"network": {Type: sdkschema.TypeString, Required: true, ForceNew: true},
+		},
+		CreateContext: noop,
+		ReadContext:   noop,
+		UpdateContext: func(context.Context, *sdkschema.ResourceData, any) diag.Diagnostics {
+			updates++
+			return nil
+		},
+		DeleteContext: func(context.Context, *sdkschema.ResourceData, any) diag.Diagnostics {
+			deletes++
+			return nil
+		},
+	}
+	addSDKChangeFreeze(map[string]*sdkschema.Resource{"akamai_property_activation": res})
+	require.NoError(t, res.InternalValidate(nil, true))
+
+	start := time.Date(2025, 11, 28, 0, 0, 0, 0, time.UTC)
+	defer func() { now = time.Now }()
+	now = func() time.Time { return start.Add(time.Hour) }
+
+	freeze := testAccountMeta{changeFreeze: []meta.FreezeWindow{
+		{Start: start, End: start.Add(24 * time.Hour), Networks: []string{"production"}, Reason: "Black Friday"},
+	}}
+
+	// diff plans the resource like the SDK does, with the raw config passed along the prior state
+	diff := func(state *terraform.InstanceState, version int, network, override string) (*terraform.InstanceDiff, error) {
+		overrideValue := cty.NullVal(cty.String)
+		if override != "" {
+			overrideValue = cty.StringVal(override)
+		}
+		config := cty.ObjectVal(map[string]cty.Value{
+			"id":                          cty.NullVal(cty.String),
+			"version":                     cty.NumberIntVal(int64(version)),
+			"network":                     cty.StringVal(network),
+			changeFreezeOverrideAttribute: overrideValue,
+		})
+		state.RawConfig = config
+		return res.Diff(context.Background(), state, terraform.NewResourceConfigShimmed(config, res.CoreConfigSchema()), freeze)
+	}
+	existing := func() *terraform.InstanceState {
+		return &terraform.InstanceState{
+			ID:         "1",
+			Attributes: map[string]string{"id": "1", "version": "1", "network": "PRODUCTION"},
+		}
+	}
+
+	t.Run("new activation on a frozen network", func(t *testing.T) {
+		_, err := diff(&terraform.InstanceState{}, 1, "PRODUCTION", "")
+		assert.ErrorIs(t, err, ErrChangeFreeze)
+		assert.ErrorContains(t, err, "akamai_property_activation cannot be changed on the production network "+
+			"from 2025-11-28 00:00 UTC until 2025-11-29 00:00 UTC (Black Friday)")
+	})
+
+	t.Run("other network is not frozen", func(t *testing.T) {
+		_, err := diff(&terraform.InstanceState{}, 1, "STAGING", "")
+		assert.NoError(t, err)
+	})
+
+	t.Run("changed activation", func(t *testing.T) {
+		_, err := diff(existing(), 2, "PRODUCTION", "")
+		assert.ErrorIs(t, err, ErrChangeFreeze)
+	})
+
+	t.Run("unchanged activation", func(t *testing.T) {
+		planned, err := diff(existing(), 1, "PRODUCTION", "")
+		require.NoError(t, err)
+		assert.Nil(t, planned)
+	})
+
+	t.Run("override", func(t *testing.T) {
+		planned, err := diff(existing(), 2, "PRODUCTION", "hotfix of a broken origin")
+		require.NoError(t, err)
+		assert.Equal(t, "2", planned.Attributes["version"].New)
+
+		updates = 0
+		_, diags := res.Apply(context.Background(), existing(), planned, freeze)
+		require.False(t, diags.HasError())
+		assert.Equal(t, 1, updates)
+	})
+
+	t.Run("only override changes", func(t *testing.T) {
+		planned, err := diff(existing(), 1, "PRODUCTION", "hotfix of a broken origin")
+		require.NoError(t, err)
+		require.NotNil(t, planned)
+
+		updates = 0
+		_, diags := res.Apply(context.Background(), existing(), planned, freeze)
+		require.False(t, diags.HasError())
+		assert.Zero(t, updates, "setting the override alone does not update the resource")
+	})
+
+	t.Run("deletion on a frozen network", func(t *testing.T) {
+		deletes = 0
+		_, diags := res.Apply(context.Background(), existing(), &terraform.InstanceDiff{Destroy: true}, freeze)
+		require.True(t, diags.HasError())
+		assert.Contains(t, diags[0].Summary, "akamai_property_activation cannot be changed on the production network")
+		assert.Zero(t, deletes)
+	})
+
+	t.Run("deletion on other network", func(t *testing.T) {
+		state := existing()
+		state.Attributes["network"] = "STAGING"
+
+		deletes = 0
+		_, diags := res.Apply(context.Background(), state, &terraform.InstanceDiff{Destroy: true}, freeze)
+		require.False(t, diags.HasError())
+		assert.Equal(t, 1, deletes)
+	})
+
+	t.Run("overridden deletion", func(t *testing.T) {
+		state := existing()
+		state.Attributes[changeFreezeOverrideAttribute] = "rollback of a broken origin"
+
+		deletes = 0
+		_, diags := res.Apply(context.Background(), state, &terraform.InstanceDiff{Destroy: true}, freeze)
+		require.False(t, diags.HasError())
+		assert.Equal(t, 1, deletes)
+	})
+}
+
+func TestFrozenResources(t *testing.T) {
+	sdkProvider := NewSDKProvider(registry.Subproviders()...)()
+	for resourceType := range frozenResources {
+		require.Contains(t, sdkProvider.ResourcesMap, resourceType)
+		assert.Contains(t, sdkProvider.ResourcesMap[resourceType].Schema, changeFreezeOverrideAttribute, resourceType)
+	}
+}
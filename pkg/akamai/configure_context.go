@@ -13,9 +13,12 @@ import (
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/edgegrid"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/session"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/cache"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/activation"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/versionnotes"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/logger"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/retryablehttp"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/tracing"
 	"github.com/apex/log"
 	"github.com/google/uuid"
 	"github.com/spf13/cast"
@@ -27,43 +30,121 @@ type contextConfig struct {
 	ctx            context.Context
 	requestLimit   int
 	enableCache    bool
+	cacheDir       string
+	cacheTTL       time.Duration
 	retryMax       int
 	retryWaitMin   time.Duration
 	retryWaitMax   time.Duration
 	retryDisabled  bool
+	retryRules     []retryRule
+	readOnly       bool
+	auditLogPath   string
+	tracingConfig  tracing.Config
+	defaults       meta.Defaults
+	changeFreeze   []meta.FreezeWindow
+	// versionNotes configures the notes of versions and activations of the whole plugin process
+	versionNotes versionnotes.Config
+	// activationLimits limit concurrent activations of the whole plugin process
+	activationLimits activation.Limits
+	// operationID is set by configureContext for the sessions it creates
+	operationID string
 }
 
 func configureContext(cfg contextConfig) (*meta.OperationMeta, error) {
 	operationID := uuid.NewString()
-	log := logger.FromContext(cfg.ctx, "OperationID", operationID)
+	log := logger.FromContext(cfg.ctx, logger.OperationIDKey, operationID)
+	cfg.operationID = operationID
 
+	if err := tracing.Configure(cfg.ctx, cfg.tracingConfig); err != nil {
+		return nil, err
+	}
+	if err := activation.Configure(cfg.activationLimits); err != nil {
+		return nil, err
+	}
+	if err := versionnotes.Configure(cfg.versionNotes); err != nil {
+		return nil, err
+	}
+
+	sess, err := newSession(cfg, cfg.edgegridConfig, log)
+	if err != nil {
+		return nil, err
+	}
+	// sessions for account switch keys of particular resources are signed with the same credentials
+	accountSession := func(accountSwitchKey string) (session.Session, error) {
+		edgegridConfig := *cfg.edgegridConfig
+		edgegridConfig.AccountKey = accountSwitchKey
+		return newSession(cfg, &edgegridConfig, log.WithField("AccountSwitchKey", accountSwitchKey))
+	}
+
+	cache.Enable(cfg.enableCache)
+	if err = configureCache(cfg); err != nil {
+		return nil, err
+	}
+
+	return meta.New(sess, log.HCLog(), operationID, meta.WithSessionFactory(accountSession), meta.WithDefaults(cfg.defaults),
+		meta.WithChangeFreeze(cfg.changeFreeze))
+}
+
+func newSession(cfg contextConfig, edgegridConfig *edgegrid.Config, log log.Interface) (session.Session, error) {
 	opts := []session.Option{
-		session.WithSigner(cfg.edgegridConfig),
+		session.WithSigner(edgegridConfig),
 		session.WithUserAgent(cfg.userAgent),
 		session.WithLog(log),
 		session.WithHTTPTracing(cast.ToBool(os.Getenv("AKAMAI_HTTP_TRACE_ENABLED"))),
-		session.WithRequestLimit(cfg.requestLimit),
 	}
-	var sess session.Session
-	var err error
 	if cfg.retryDisabled {
-		sess, err = sessionWithoutRetry(opts)
-	} else {
-		sess, err = sessionWithRetry(cfg, opts)
+		return sessionWithoutRetry(cfg, opts, log)
 	}
+	return sessionWithRetry(cfg, opts, log)
+}
+
+// configureCache sets up the default ttl and the on-disk cache backend, if configured.
+// Persistent entries are kept per EdgeGrid host and account key, so that data from different accounts never mixes.
+func configureCache(cfg contextConfig) error {
+	if err := cache.SetDefaultTTL(cfg.cacheTTL); err != nil {
+		return fmt.Errorf("wrong cache values: %w", err)
+	}
+
+	namespace := fmt.Sprintf("%s:%s", cfg.edgegridConfig.Host, cfg.edgegridConfig.AccountKey)
+	if err := cache.EnablePersistent(cfg.cacheDir, namespace); err != nil {
+		return fmt.Errorf("configuring persistent cache failed: %w", err)
+	}
+	return nil
+}
+
+// sessionTransport wraps base with the per API family rate limiting and, if requested, HTTP record/replay
+// and the audit log. In the read-only mode requests which may modify objects are rejected before being
+// audited, throttled or recorded.
+func sessionTransport(cfg contextConfig, base http.RoundTripper, log log.Interface) (http.RoundTripper, error) {
+	base, err := caCertTransport(base)
 	if err != nil {
 		return nil, err
 	}
-	cache.Enable(cfg.enableCache)
-
-	return meta.New(sess, log.HCLog(), operationID)
+	transport, err := cassetteTransport(newRateLimitTransport(base, cfg.requestLimit, log))
+	if err != nil {
+		return nil, err
+	}
+	if cfg.auditLogPath != "" {
+		if transport, err = newAuditTransport(transport, cfg.auditLogPath, cfg.operationID, log); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.readOnly {
+		return readOnlyTransport{base: transport}, nil
+	}
+	return transport, nil
 }
 
-func sessionWithoutRetry(opts []session.Option) (session.Session, error) {
+func sessionWithoutRetry(cfg contextConfig, opts []session.Option, log log.Interface) (session.Session, error) {
+	transport, err := sessionTransport(cfg, http.DefaultTransport, log)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, session.WithClient(&http.Client{Transport: requestTracingTransport{base: transport}}))
 	return session.New(opts...)
 }
 
-func overrideRetryPolicy(basePolicy retryablehttp.CheckRetry) retryablehttp.CheckRetry {
+func overrideRetryPolicy(basePolicy retryablehttp.CheckRetry, rules []retryRule) retryablehttp.CheckRetry {
 	return func(ctx context.Context, resp *http.Response, err error) (bool, error) {
 
 		// do not retry on context.Canceled or context.DeadlineExceeded
@@ -71,13 +152,21 @@ func overrideRetryPolicy(basePolicy retryablehttp.CheckRetry) retryablehttp.Chec
 			return false, ctx.Err()
 		}
 
-		// Retry all PAPI requests resulting status code 429
+		// Retry all requests resulting status code 429, as the request was rejected before being processed
 		// The backoff time is calculated in getXRateLimitBackoff
-		is429 := resp != nil && resp.StatusCode == http.StatusTooManyRequests
-		if is429 && strings.HasPrefix(resp.Request.URL.Path, "/papi/") {
+		if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
 			return true, nil
 		}
 
+		// Retry requests configured as retryable by the user, e.g. idempotent writes resulting in 5xx
+		if resp != nil {
+			for _, rule := range rules {
+				if rule.matches(resp) {
+					return true, nil
+				}
+			}
+		}
+
 		var urlErr *url.Error
 		if (resp != nil && resp.Request.Method == http.MethodGet) ||
 			(resp == nil && errors.As(err, &urlErr) && strings.ToUpper(urlErr.Op) == http.MethodGet) {
@@ -147,7 +236,7 @@ func overrideBackoff(baseBackoff retryablehttp.Backoff, logger log.Interface) re
 	}
 }
 
-func sessionWithRetry(cfg contextConfig, opts []session.Option) (session.Session, error) {
+func sessionWithRetry(cfg contextConfig, opts []session.Option, log log.Interface) (session.Session, error) {
 	if cfg.retryMax == 0 {
 		cfg.retryMax = 10
 	}
@@ -168,6 +257,13 @@ func sessionWithRetry(cfg contextConfig, opts []session.Option) (session.Session
 	retryClient.RetryWaitMin = cfg.retryWaitMin
 	retryClient.RetryWaitMax = cfg.retryWaitMax
 
+	// rate limit, record or replay each attempt separately, so that retries are throttled and replayed as they happened
+	transport, err := sessionTransport(cfg, retryClient.HTTPClient.Transport, log)
+	if err != nil {
+		return nil, err
+	}
+	retryClient.HTTPClient.Transport = transport
+
 	opts = append(opts, session.WithClient(retryClient.StandardClient()))
 	sess, err := session.New(opts...)
 	if err != nil {
@@ -182,7 +278,7 @@ func sessionWithRetry(cfg contextConfig, opts []session.Option) (session.Session
 		return sess.Sign(req)
 	}
 
-	retryClient.CheckRetry = overrideRetryPolicy(retryablehttp.DefaultRetryPolicy)
+	retryClient.CheckRetry = overrideRetryPolicy(retryablehttp.DefaultRetryPolicy, cfg.retryRules)
 	l := sess.Log(cfg.ctx)
 	retryClient.Backoff = overrideBackoff(retryablehttp.DefaultBackoff, l)
 	retryClient.Logger = session.GetRetryableLogger(l)
@@ -209,5 +305,5 @@ func validateRetryConfiguration(cfg contextConfig) error {
 		return fmt.Errorf("wrong retry values: retry wait time too long, minimum retry wait time (%v) cannot be higher than %v or maximum retry wait time (%v) cannot be higher than %v", cfg.retryWaitMin, maxWaitTime, cfg.retryWaitMax, maxWaitTime)
 
 	}
-	return nil
+	return validateRetryRules(cfg.retryRules)
 }
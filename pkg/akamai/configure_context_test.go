@@ -103,6 +103,13 @@ func Test_validateRetryConfiguration(t *testing.T) {
 			wantErr: true,
 			errMsg:  "wrong retry values: retry wait time too long, minimum retry wait time (1ns) cannot be higher than 24h0m0s or maximum retry wait time (25h0m0s) cannot be higher than 24h0m0s",
 		},
+		"invalid values - retry rule": {
+			args: contextConfig{
+				retryRules: []retryRule{{PathPrefix: "/appsec/", Methods: []string{"PUT"}, StatusCodes: []int{600}}},
+			},
+			wantErr: true,
+			errMsg:  "wrong retry policy: status code 600 of rule 0 has to be between 400 and 599",
+		},
 	}
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -126,7 +133,9 @@ func TestOverrideRetryPolicy(t *testing.T) {
 	basePolicy := func(ctx context.Context, resp *http.Response, err error) (bool, error) {
 		return false, errors.New("base policy: dummy, not implemented")
 	}
-	policy := overrideRetryPolicy(basePolicy)
+	policy := overrideRetryPolicy(basePolicy, []retryRule{
+		{PathPrefix: "/appsec/", Methods: []string{"put"}, StatusCodes: []int{http.StatusBadGateway, http.StatusServiceUnavailable}},
+	})
 
 	tests := map[string]struct {
 		ctx            context.Context
@@ -151,6 +160,54 @@ func TestOverrideRetryPolicy(t *testing.T) {
 			},
 			expectedResult: true,
 		},
+		"should retry for Application Security PUT with status 429": {
+			ctx: context.Background(),
+			resp: &http.Response{
+				Request:    newRequest(t, http.MethodPut, "/appsec/v1/configs/1/versions/2"),
+				StatusCode: http.StatusTooManyRequests,
+			},
+			expectedResult: true,
+		},
+		"should retry for DNS DELETE with status 429": {
+			ctx: context.Background(),
+			resp: &http.Response{
+				Request:    newRequest(t, http.MethodDelete, "/config-dns/v2/zones/example.com"),
+				StatusCode: http.StatusTooManyRequests,
+			},
+			expectedResult: true,
+		},
+		"should retry for PUT matching retry rule": {
+			ctx: context.Background(),
+			resp: &http.Response{
+				Request:    newRequest(t, http.MethodPut, "/appsec/v1/configs/1/versions/2/security-policies/p_1/mode"),
+				StatusCode: http.StatusBadGateway,
+			},
+			expectedResult: true,
+		},
+		"should not retry for PUT with status code not matching retry rule": {
+			ctx: context.Background(),
+			resp: &http.Response{
+				Request:    newRequest(t, http.MethodPut, "/appsec/v1/configs/1/versions/2/security-policies/p_1/mode"),
+				StatusCode: http.StatusInternalServerError,
+			},
+			expectedResult: false,
+		},
+		"should not retry for POST with method not matching retry rule": {
+			ctx: context.Background(),
+			resp: &http.Response{
+				Request:    newRequest(t, http.MethodPost, "/appsec/v1/configs/1/versions"),
+				StatusCode: http.StatusBadGateway,
+			},
+			expectedResult: false,
+		},
+		"should not retry for PUT with path not matching retry rule": {
+			ctx: context.Background(),
+			resp: &http.Response{
+				Request:    newRequest(t, http.MethodPut, "/config-dns/v2/zones/example.com/recordsets/www/A"),
+				StatusCode: http.StatusBadGateway,
+			},
+			expectedResult: false,
+		},
 		"should not retry for PAPI POST with other 4xx status": {
 			ctx: context.Background(),
 			resp: &http.Response{
@@ -306,7 +363,7 @@ func mockSession(t *testing.T, mockServer *httptest.Server) session.Session {
 	certPool := x509.NewCertPool()
 	certPool.AddCert(mockServer.Certificate())
 	rt := meta.Session().Client().Transport.(*retryablehttp.RoundTripper)
-	transport := rt.Client.HTTPClient.Transport.(*http.Transport)
+	transport := rt.Client.HTTPClient.Transport.(*rateLimitTransport).base.(*http.Transport)
 	transport.TLSClientConfig = &tls.Config{
 		RootCAs: certPool,
 	}
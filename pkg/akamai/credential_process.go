@@ -0,0 +1,156 @@
+package akamai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// credentialProcessEnv is the environment variable holding the credential process command,
+	// used when the credential_process argument is not configured
+	credentialProcessEnv = "AKAMAI_CREDENTIAL_PROCESS"
+
+	credentialProcessDescription = "The command printing EdgeGrid credentials as a JSON object with host, client_token, client_secret, access_token and optionally account_key and max_body fields"
+
+	credentialProcessTimeout = time.Minute
+	// credentialProcessMaxStderr limits the output of a failed process included in diagnostics
+	credentialProcessMaxStderr = 1024
+)
+
+var (
+	// ErrCredentialProcess is returned when the credential process fails or returns invalid credentials
+	ErrCredentialProcess = errors.New("credential process")
+
+	// credentialProcessCache holds credentials returned by each command for the lifetime of the plugin process,
+	// so that the command is run once even though both the SDK and framework providers are configured
+	credentialProcessCache      = make(map[string]configBearer)
+	credentialProcessCacheMutex sync.Mutex
+)
+
+// credentialProcessOutput is the JSON document a credential process writes to its standard output
+type credentialProcessOutput struct {
+	Host         string `json:"host"`
+	ClientToken  string `json:"client_token"`
+	ClientSecret string `json:"client_secret"`
+	AccessToken  string `json:"access_token"`
+	AccountKey   string `json:"account_key"`
+	MaxBody      int    `json:"max_body"`
+}
+
+// credentialProcessConfig runs the credential process command, or returns credentials it returned before
+func credentialProcessConfig(ctx context.Context, command string) (configBearer, error) {
+	credentialProcessCacheMutex.Lock()
+	defer credentialProcessCacheMutex.Unlock()
+
+	if config, ok := credentialProcessCache[command]; ok {
+		return config, nil
+	}
+
+	config, err := runCredentialProcess(ctx, command)
+	if err != nil {
+		return configBearer{}, err
+	}
+	credentialProcessCache[command] = config
+
+	return config, nil
+}
+
+func runCredentialProcess(ctx context.Context, command string) (configBearer, error) {
+	args, err := splitCommand(command)
+	if err != nil {
+		return configBearer{}, fmt.Errorf("%w: %s", ErrCredentialProcess, err)
+	}
+	if len(args) == 0 {
+		return configBearer{}, fmt.Errorf("%w: command is empty", ErrCredentialProcess)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, credentialProcessTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		switch {
+		case errors.Is(ctx.Err(), context.DeadlineExceeded):
+			err = fmt.Errorf("timed out after %v", credentialProcessTimeout)
+		case errors.Is(ctx.Err(), context.Canceled):
+			err = fmt.Errorf("canceled")
+		}
+		msg := strings.TrimSpace(stderr.String())
+		if len(msg) > credentialProcessMaxStderr {
+			msg = msg[:credentialProcessMaxStderr] + "..."
+		}
+		if msg == "" {
+			return configBearer{}, fmt.Errorf("%w: running %q failed: %s", ErrCredentialProcess, args[0], err)
+		}
+		return configBearer{}, fmt.Errorf("%w: running %q failed: %s: %s", ErrCredentialProcess, args[0], err, msg)
+	}
+
+	var output credentialProcessOutput
+	if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		// the output is never included, as it may contain secrets
+		return configBearer{}, fmt.Errorf("%w: output of %q is not a valid JSON object: %s", ErrCredentialProcess, args[0], err)
+	}
+
+	config := configBearer{
+		accessToken:  output.AccessToken,
+		accountKey:   output.AccountKey,
+		clientSecret: output.ClientSecret,
+		clientToken:  output.ClientToken,
+		host:         output.Host,
+		maxBody:      output.MaxBody,
+	}
+	if !config.valid() {
+		return configBearer{}, fmt.Errorf("%w: output of %q has to contain host, client_token, client_secret and access_token", ErrCredentialProcess, args[0])
+	}
+
+	return config, nil
+}
+
+// splitCommand splits the command into arguments separated by whitespace.
+// Arguments containing whitespace can be enclosed in single or double quotes.
+func splitCommand(command string) ([]string, error) {
+	var args []string
+	var arg strings.Builder
+	var quote rune
+	inArg := false
+
+	for _, r := range command {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			arg.WriteRune(r)
+		case r == '"' || r == '\'':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		default:
+			arg.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote in command %q", command)
+	}
+	if inArg {
+		args = append(args, arg.String())
+	}
+
+	return args, nil
+}
@@ -0,0 +1,138 @@
+package akamai
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const credentialProcessHelperEnv = "AKAMAI_TEST_CREDENTIAL_PROCESS"
+
+// TestCredentialProcessHelper is not a real test, it is run as the credential process by other tests
+func TestCredentialProcessHelper(t *testing.T) {
+	switch os.Getenv(credentialProcessHelperEnv) {
+	case "":
+		t.Skip("run only as a credential process")
+	case "valid":
+		fmt.Printf(`{"host": "process.com", "client_token": "token", "client_secret": "s3cr3t", "access_token": "access", "account_key": "%s"}`, os.Args[len(os.Args)-1])
+	case "incomplete":
+		fmt.Print(`{"host": "process.com", "client_secret": "s3cr3t"}`)
+	case "invalid":
+		fmt.Print(`client_secret=s3cr3t`)
+	case "failing":
+		fmt.Fprint(os.Stderr, "not logged in")
+		os.Exit(3)
+	case "hanging":
+		time.Sleep(time.Minute)
+	}
+	os.Exit(0)
+}
+
+func credentialProcessHelperCommand(t *testing.T, mode, arg string) string {
+	t.Setenv(credentialProcessHelperEnv, mode)
+	return fmt.Sprintf("'%s' -test.run=TestCredentialProcessHelper -- '%s'", os.Args[0], arg)
+}
+
+func TestCredentialProcessConfig(t *testing.T) {
+	t.Run("valid credentials are cached", func(t *testing.T) {
+		command := credentialProcessHelperCommand(t, "valid", "account with spaces")
+
+		config, err := credentialProcessConfig(context.Background(), command)
+		require.NoError(t, err)
+		assert.Equal(t, configBearer{
+			host:         "process.com",
+			clientToken:  "token",
+			clientSecret: "s3cr3t",
+			accessToken:  "access",
+			accountKey:   "account with spaces",
+		}, config)
+
+		t.Setenv(credentialProcessHelperEnv, "failing")
+		cached, err := credentialProcessConfig(context.Background(), command)
+		require.NoError(t, err)
+		assert.Equal(t, config, cached)
+	})
+
+	tests := map[string]struct {
+		mode   string
+		errMsg string
+	}{
+		"failing process": {
+			mode:   "failing",
+			errMsg: "failed: exit status 3: not logged in",
+		},
+		"incomplete credentials": {
+			mode:   "incomplete",
+			errMsg: "has to contain host, client_token, client_secret and access_token",
+		},
+		"invalid output": {
+			mode:   "invalid",
+			errMsg: "is not a valid JSON object",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := credentialProcessConfig(context.Background(), credentialProcessHelperCommand(t, test.mode, name))
+			assert.ErrorIs(t, err, ErrCredentialProcess)
+			assert.ErrorContains(t, err, test.errMsg)
+			assert.NotContains(t, err.Error(), "s3cr3t")
+		})
+	}
+
+	t.Run("process stopped when context is canceled", func(t *testing.T) {
+		canceled, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		time.AfterFunc(100*time.Millisecond, cancel)
+
+		start := time.Now()
+		_, err := credentialProcessConfig(canceled, credentialProcessHelperCommand(t, "hanging", "canceled"))
+		assert.ErrorIs(t, err, ErrCredentialProcess)
+		assert.ErrorContains(t, err, "canceled")
+		assert.Less(t, time.Since(start), 10*time.Second)
+	})
+
+	t.Run("missing command", func(t *testing.T) {
+		_, err := credentialProcessConfig(context.Background(), "/non/existent/command")
+		assert.ErrorIs(t, err, ErrCredentialProcess)
+	})
+}
+
+func TestSplitCommand(t *testing.T) {
+	tests := map[string]struct {
+		command  string
+		expected []string
+		withErr  bool
+	}{
+		"empty": {
+			command: "  ",
+		},
+		"arguments": {
+			command:  "vault  read\t-format=json secret/akamai",
+			expected: []string{"vault", "read", "-format=json", "secret/akamai"},
+		},
+		"quoted arguments": {
+			command:  `"/opt/my tools/creds" --name 'ci runner' --empty ""`,
+			expected: []string{"/opt/my tools/creds", "--name", "ci runner", "--empty", ""},
+		},
+		"unterminated quote": {
+			command: `creds "ci runner`,
+			withErr: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			args, err := splitCommand(test.command)
+			if test.withErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, args)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/subprovider"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// deletionProtectionEnv is the environment variable holding the default deletion protection,
+	// used when the deletion_protection provider argument is not configured
+	deletionProtectionEnv = "AKAMAI_DELETION_PROTECTION"
+
+	// deletionProtectionAttribute is added to every resource which can be active on the network
+	deletionProtectionAttribute = "deletion_protection"
+
+	defaultDeletionProtectionDescription = "The deletion protection of resources which can be active on the network, e.g. `akamai_property`, " +
+		"whose `deletion_protection` is not set. Protected resources refuse to be deleted while active. Defaults to false"
+
+	deletionProtectionDescription = "Whether deletion of the resource is refused while it is active on the staging or production network. " +
+		"Turning it off has to be applied before deleting the resource. Defaults to the `deletion_protection` provider argument."
+)
+
+// ErrDeletionProtection is returned when deleting a protected resource which is active
+var ErrDeletionProtection = errors.New("deletion protection")
+
+// addSDKDeletionProtection adds the deletion_protection attribute to the resources which can be active on the network,
+// and makes their deletion fail while they are active and protected
+func addSDKDeletionProtection(resources map[string]*schema.Resource, activeVersions map[string]subprovider.ActiveVersionsFunc) {
+	for name, versions := range activeVersions {
+		r, ok := resources[name]
+		if !ok {
+			continue
+		}
+		r.Schema[deletionProtectionAttribute] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Computed:    true,
+			Description: deletionProtectionDescription,
+		}
+		r.CustomizeDiff = withSDKDeletionProtectionDefault(r.CustomizeDiff)
+		r.UpdateContext = withoutDeletionProtectionUpdate(r.UpdateContext)
+		r.DeleteContext = withSDKDeletionProtection(name, versions, r.DeleteContext)
+	}
+}
+
+// withSDKDeletionProtectionDefault plans deletion_protection to follow the provider default when it is not configured,
+// so that the default in effect when the resource is deleted is the one of its last apply
+func withSDKDeletionProtectionDefault(f schema.CustomizeDiffFunc) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, m any) error {
+		config := d.GetRawConfig()
+		if config.IsKnown() && !config.IsNull() && config.GetAttr(deletionProtectionAttribute).IsNull() {
+			protection := providerDefaults(m).DeletionProtection
+			if current, _ := d.Get(deletionProtectionAttribute).(bool); d.Id() == "" || current != protection {
+				if err := d.SetNew(deletionProtectionAttribute, protection); err != nil {
+					return err
+				}
+			}
+		}
+		if f == nil {
+			return nil
+		}
+		return f(ctx, d, m)
+	}
+}
+
+// withoutDeletionProtectionUpdate skips updates changing only deletion_protection, which is kept in the state only
+func withoutDeletionProtectionUpdate(f schema.UpdateContextFunc) schema.UpdateContextFunc {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+		if !d.HasChangeExcept(deletionProtectionAttribute) {
+			return nil
+		}
+		return f(ctx, d, m)
+	}
+}
+
+// withSDKDeletionProtection refuses deletion of the protected resource while any of its versions is active
+func withSDKDeletionProtection(resourceType string, activeVersions subprovider.ActiveVersionsFunc, f schema.DeleteContextFunc) schema.DeleteContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+		if protected, _ := d.Get(deletionProtectionAttribute).(bool); protected {
+			versions, err := activeVersions(ctx, d, m)
+			if err != nil {
+				return diag.Errorf("%s: checking whether %s %q is active failed: %s", ErrDeletionProtection, resourceType, d.Id(), err)
+			}
+			if versions.Staging != "" || versions.Production != "" {
+				return deletionProtectionDiagnostics(resourceType, d.Id(), versions)
+			}
+		}
+		return f(ctx, d, m)
+	}
+}
+
+func deletionProtectionDiagnostics(resourceType, id string, versions subprovider.ActiveVersions) diag.Diagnostics {
+	var active []string
+	if versions.Production != "" {
+		active = append(active, fmt.Sprintf("%s on production", versions.Production))
+	}
+	if versions.Staging != "" {
+		active = append(active, fmt.Sprintf("%s on staging", versions.Staging))
+	}
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("%s: %s %q is active", ErrDeletionProtection, resourceType, id),
+		Detail: fmt.Sprintf("Active: %s. Deactivate the resource, or set %q to false and apply it before deleting the resource.",
+			strings.Join(active, ", "), deletionProtectionAttribute),
+	}}
+}
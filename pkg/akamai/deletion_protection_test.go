@@ -0,0 +1,159 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/subprovider"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	sdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSDKDeletionProtection(t *testing.T) {
+	var updated, deleted bool
+	var active subprovider.ActiveVersions
+	var activeErr error
+	noop := func(context.Context, *sdkschema.ResourceData, any) diag.Diagnostics { return nil }
+	res := &sdkschema.Resource{
+		Schema: map[string]*sdkschema.Schema{
+			"name": {Type: sdkschema.TypeString, Required: true},
+		},
+		CreateContext: noop,
+		ReadContext:   noop,
+		UpdateContext: func(context.Context, *sdkschema.ResourceData, any) diag.Diagnostics {
+			updated = true
+			return nil
+		},
+		DeleteContext: func(context.Context, *sdkschema.ResourceData, any) diag.Diagnostics {
+			deleted = true
+			return nil
+		},
+	}
+	activeVersions := func(context.Context, *sdkschema.ResourceData, any) (subprovider.ActiveVersions, error) {
+		return active, activeErr
+	}
+	addSDKDeletionProtection(map[string]*sdkschema.Resource{"akamai_property": res},
+		map[string]subprovider.ActiveVersionsFunc{"akamai_property": activeVersions})
+	require.NoError(t, res.InternalValidate(nil, true))
+
+	// diff plans the resource like the SDK does, with the raw config passed along the prior state
+	diff := func(state *terraform.InstanceState, name, protection cty.Value, m any) (*terraform.InstanceDiff, error) {
+		config := cty.ObjectVal(map[string]cty.Value{
+			"id":                        cty.NullVal(cty.String),
+			"name":                      name,
+			deletionProtectionAttribute: protection,
+		})
+		state.RawConfig = config
+		return res.Diff(context.Background(), state, terraform.NewResourceConfigShimmed(config, res.CoreConfigSchema()), m)
+	}
+	existing := func(protection string) *terraform.InstanceState {
+		return &terraform.InstanceState{
+			ID:         "prp_1",
+			Attributes: map[string]string{"id": "prp_1", "name": "test", deletionProtectionAttribute: protection},
+		}
+	}
+	protected := testAccountMeta{defaults: meta.Defaults{DeletionProtection: true}}
+
+	t.Run("provider default of a new resource", func(t *testing.T) {
+		planned, err := diff(&terraform.InstanceState{}, cty.StringVal("test"), cty.NullVal(cty.Bool), protected)
+		require.NoError(t, err)
+		assert.Equal(t, "true", planned.Attributes[deletionProtectionAttribute].New)
+
+		planned, err = diff(&terraform.InstanceState{}, cty.StringVal("test"), cty.NullVal(cty.Bool), testAccountMeta{})
+		require.NoError(t, err)
+		assert.Equal(t, "false", planned.Attributes[deletionProtectionAttribute].New)
+	})
+
+	t.Run("configured value takes precedence", func(t *testing.T) {
+		planned, err := diff(&terraform.InstanceState{}, cty.StringVal("test"), cty.False, protected)
+		require.NoError(t, err)
+		assert.Equal(t, "false", planned.Attributes[deletionProtectionAttribute].New)
+	})
+
+	t.Run("existing resource follows the provider default", func(t *testing.T) {
+		planned, err := diff(existing("true"), cty.StringVal("test"), cty.NullVal(cty.Bool), protected)
+		require.NoError(t, err)
+		assert.Nil(t, planned)
+
+		planned, err = diff(existing("true"), cty.StringVal("test"), cty.NullVal(cty.Bool), testAccountMeta{})
+		require.NoError(t, err)
+		assert.Equal(t, "false", planned.Attributes[deletionProtectionAttribute].New)
+	})
+
+	t.Run("resource created before deletion protection", func(t *testing.T) {
+		state := existing("")
+		delete(state.Attributes, deletionProtectionAttribute)
+		planned, err := diff(state, cty.StringVal("test"), cty.NullVal(cty.Bool), testAccountMeta{})
+		require.NoError(t, err)
+		assert.Nil(t, planned)
+	})
+
+	t.Run("update of deletion protection only", func(t *testing.T) {
+		updated = false
+		state := existing("true")
+		planned, err := diff(state, cty.StringVal("test"), cty.False, testAccountMeta{})
+		require.NoError(t, err)
+		newState, diags := res.Apply(context.Background(), state, planned, testAccountMeta{})
+		require.False(t, diags.HasError())
+		assert.False(t, updated)
+		assert.Equal(t, "false", newState.Attributes[deletionProtectionAttribute])
+
+		planned, err = diff(state, cty.StringVal("renamed"), cty.False, testAccountMeta{})
+		require.NoError(t, err)
+		_, diags = res.Apply(context.Background(), state, planned, testAccountMeta{})
+		require.False(t, diags.HasError())
+		assert.True(t, updated)
+	})
+
+	tests := map[string]struct {
+		protection    string
+		active        subprovider.ActiveVersions
+		activeErr     error
+		expectDeleted bool
+		expectError   string
+	}{
+		"protected and active": {
+			protection:  "true",
+			active:      subprovider.ActiveVersions{Staging: "version 4", Production: "version 3"},
+			expectError: `deletion protection: akamai_property "prp_1" is active: Active: version 3 on production, version 4 on staging.`,
+		},
+		"protected and active on staging": {
+			protection:  "true",
+			active:      subprovider.ActiveVersions{Staging: "version 4"},
+			expectError: "Active: version 4 on staging.",
+		},
+		"protected and inactive": {
+			protection:    "true",
+			expectDeleted: true,
+		},
+		"active but not protected": {
+			protection:    "false",
+			active:        subprovider.ActiveVersions{Production: "version 3"},
+			expectDeleted: true,
+		},
+		"status unknown": {
+			protection:  "true",
+			activeErr:   errors.New("oops"),
+			expectError: `deletion protection: checking whether akamai_property "prp_1" is active failed: oops`,
+		},
+	}
+	for name, test := range tests {
+		t.Run("delete "+name, func(t *testing.T) {
+			deleted, active, activeErr = false, test.active, test.activeErr
+			_, diags := res.Apply(context.Background(), existing(test.protection), &terraform.InstanceDiff{Destroy: true}, testAccountMeta{})
+			assert.Equal(t, test.expectDeleted, deleted)
+			if test.expectError == "" {
+				assert.False(t, diags.HasError())
+				return
+			}
+			require.True(t, diags.HasError())
+			assert.Contains(t, diags[0].Summary+": "+diags[0].Detail, test.expectError)
+		})
+	}
+}
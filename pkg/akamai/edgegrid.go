@@ -1,6 +1,7 @@
 package akamai
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -52,10 +53,15 @@ func (c configBearer) valid() bool {
 // It evaluates possibility of creating the config in the following order:
 //  1. Environmental variables
 //  2. Config block
-//  3. Edgerc file
+//  3. Credential process
+//  4. Edgerc file
 //
+// If the credential process is provided, but fails, the edgerc file is not evaluated.
 // If edgerc path or section are not provided, it uses the edgegrid defaults.
-func newEdgegridConfig(path, section string, config configBearer) (*edgegrid.Config, error) {
+// When none of them is available while replaying recorded HTTP interactions,
+// placeholder credentials are used. The credential process is stopped when the context is canceled,
+// e.g. when the provider is stopped.
+func newEdgegridConfig(ctx context.Context, path, section string, config configBearer, credentialProcess string) (*edgegrid.Config, error) {
 	envEdgerc := &edgegrid.Config{}
 	err := envEdgerc.FromEnv(edgercSectionOrDefault(section))
 	if err == nil {
@@ -67,11 +73,27 @@ func newEdgegridConfig(path, section string, config configBearer) (*edgegrid.Con
 		return validateEdgerc(configEdgerc)
 	}
 
+	if credentialProcess != "" {
+		processConfig, err := credentialProcessConfig(ctx, credentialProcess)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrWrongEdgeGridConfiguration, err)
+		}
+		processEdgerc, err := processConfig.toEdgegridConfig()
+		if err != nil {
+			return nil, err
+		}
+		return validateEdgerc(processEdgerc)
+	}
+
 	fileEdgerc := &edgegrid.Config{}
 	err = fileEdgerc.FromFile(edgercPathOrDefault(path), edgercSectionOrDefault(section))
 	if err == nil {
 		return validateEdgerc(fileEdgerc)
 	}
+
+	if replayEdgerc, ok := replayEdgegridConfig(); ok {
+		return replayEdgerc, nil
+	}
 	return nil, fmt.Errorf("%w: %s", ErrWrongEdgeGridConfiguration, err)
 }
 
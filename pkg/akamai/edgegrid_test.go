@@ -1,6 +1,7 @@
 package akamai
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -33,7 +34,7 @@ func TestNewEdgegridConfig(t *testing.T) {
 		t.Setenv("AKAMAI_CLIENT_TOKEN", clientToken)
 		t.Setenv("AKAMAI_CLIENT_SECRET", clientSecret)
 
-		edgegridConfig, err := newEdgegridConfig("", "", config)
+		edgegridConfig, err := newEdgegridConfig(context.Background(), "", "", config, "")
 		require.NoError(t, err)
 		assert.Equal(t, envHost, edgegridConfig.Host)
 	})
@@ -44,7 +45,7 @@ func TestNewEdgegridConfig(t *testing.T) {
 		t.Setenv("AKAMAI_CLIENT_TOKEN", clientToken)
 		t.Setenv("AKAMAI_CLIENT_SECRET", clientSecret)
 
-		edgegridConfig, err := newEdgegridConfig(edgercPath, section, configBearer{})
+		edgegridConfig, err := newEdgegridConfig(context.Background(), edgercPath, section, configBearer{}, "")
 		require.NoError(t, err)
 		assert.Equal(t, envHost, edgegridConfig.Host)
 	})
@@ -58,13 +59,13 @@ func TestNewEdgegridConfig(t *testing.T) {
 		t.Setenv(fmt.Sprintf("AKAMAI_%s_CLIENT_TOKEN", testSection), clientToken)
 		t.Setenv(fmt.Sprintf("AKAMAI_%s_CLIENT_SECRET", testSection), clientSecret)
 
-		edgegridConfig, err := newEdgegridConfig("", testSection, config)
+		edgegridConfig, err := newEdgegridConfig(context.Background(), "", testSection, config, "")
 		require.NoError(t, err)
 		assert.Equal(t, host, edgegridConfig.Host)
 	})
 
 	t.Run("uses config when provided and env not set", func(t *testing.T) {
-		edgegridConfig, err := newEdgegridConfig("", "", config)
+		edgegridConfig, err := newEdgegridConfig(context.Background(), "", "", config, "")
 		require.NoError(t, err)
 		assert.Equal(t, configHost, edgegridConfig.Host)
 	})
@@ -73,19 +74,19 @@ func TestNewEdgegridConfig(t *testing.T) {
 		t.Setenv("AKAMAI_HOST", "env.com")
 		t.Setenv("AKAMAI_ACCESS_TOKEN", accessToken)
 
-		edgegridConfig, err := newEdgegridConfig("", "", config)
+		edgegridConfig, err := newEdgegridConfig(context.Background(), "", "", config, "")
 		require.NoError(t, err)
 		assert.Equal(t, configHost, edgegridConfig.Host)
 	})
 
 	t.Run("config is prioritized over edgerc file", func(t *testing.T) {
-		edgegridConfig, err := newEdgegridConfig(edgercPath, section, config)
+		edgegridConfig, err := newEdgegridConfig(context.Background(), edgercPath, section, config, "")
 		require.NoError(t, err)
 		assert.Equal(t, configHost, edgegridConfig.Host)
 	})
 
 	t.Run("uses edgerc file when env and config not provided", func(t *testing.T) {
-		edgegridConfig, err := newEdgegridConfig(edgercPath, section, configBearer{})
+		edgegridConfig, err := newEdgegridConfig(context.Background(), edgercPath, section, configBearer{}, "")
 		require.NoError(t, err)
 		assert.Equal(t, fileHost, edgegridConfig.Host)
 	})
@@ -94,14 +95,32 @@ func TestNewEdgegridConfig(t *testing.T) {
 		t.Setenv("AKAMAI_HOST", "env.com")
 		t.Setenv("AKAMAI_ACCESS_TOKEN", accessToken)
 
-		edgegridConfig, err := newEdgegridConfig(edgercPath, section, configBearer{})
+		edgegridConfig, err := newEdgegridConfig(context.Background(), edgercPath, section, configBearer{}, "")
 		require.NoError(t, err)
 		assert.Equal(t, fileHost, edgegridConfig.Host)
 
 	})
 
+	t.Run("config is prioritized over credential process", func(t *testing.T) {
+		edgegridConfig, err := newEdgegridConfig(context.Background(), edgercPath, section, config, credentialProcessHelperCommand(t, "failing", ""))
+		require.NoError(t, err)
+		assert.Equal(t, configHost, edgegridConfig.Host)
+	})
+
+	t.Run("credential process is prioritized over edgerc file", func(t *testing.T) {
+		edgegridConfig, err := newEdgegridConfig(context.Background(), edgercPath, section, configBearer{}, credentialProcessHelperCommand(t, "valid", "precedence"))
+		require.NoError(t, err)
+		assert.Equal(t, "process.com", edgegridConfig.Host)
+	})
+
+	t.Run("edgerc file is not used when credential process fails", func(t *testing.T) {
+		_, err := newEdgegridConfig(context.Background(), edgercPath, section, configBearer{}, credentialProcessHelperCommand(t, "failing", "no fallback"))
+		assert.ErrorIs(t, err, ErrWrongEdgeGridConfiguration)
+		assert.ErrorContains(t, err, "not logged in")
+	})
+
 	t.Run("uses default edgerc path and section when none provided", func(t *testing.T) {
-		edgegridConfig, err := newEdgegridConfig("", "", configBearer{})
+		edgegridConfig, err := newEdgegridConfig(context.Background(), "", "", configBearer{}, "")
 		require.NoError(t, err)
 		assert.Equal(t, fileHost, edgegridConfig.Host)
 	})
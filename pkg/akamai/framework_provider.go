@@ -6,11 +6,16 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/activation"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf/validators"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/versionnotes"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/subprovider"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/tracing"
 	"github.com/akamai/terraform-provider-akamai/v6/version"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -18,7 +23,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-var _ provider.Provider = &Provider{}
+var _ provider.ProviderWithFunctions = &Provider{}
+
+// providerTypeName is the prefix of all resource and data source types
+const providerTypeName = "akamai"
 
 // Provider is the implementation of akamai terraform provider which uses terraform-plugin-framework
 type Provider struct {
@@ -27,15 +35,48 @@ type Provider struct {
 
 // ProviderModel represents the model of Provider configuration
 type ProviderModel struct {
-	EdgercPath    types.String `tfsdk:"edgerc"`
-	EdgercSection types.String `tfsdk:"config_section"`
-	EdgercConfig  types.Set    `tfsdk:"config"`
-	CacheEnabled  types.Bool   `tfsdk:"cache_enabled"`
-	RequestLimit  types.Int64  `tfsdk:"request_limit"`
-	RetryMax      types.Int64  `tfsdk:"retry_max"`
-	RetryWaitMin  types.Int64  `tfsdk:"retry_wait_min"`
-	RetryWaitMax  types.Int64  `tfsdk:"retry_wait_max"`
-	RetryDisabled types.Bool   `tfsdk:"retry_disabled"`
+	EdgercPath                         types.String `tfsdk:"edgerc"`
+	EdgercSection                      types.String `tfsdk:"config_section"`
+	EdgercConfig                       types.Set    `tfsdk:"config"`
+	CredentialProcess                  types.String `tfsdk:"credential_process"`
+	ReadOnly                           types.Bool   `tfsdk:"read_only"`
+	AuditLogPath                       types.String `tfsdk:"audit_log_path"`
+	TracingEndpoint                    types.String `tfsdk:"tracing_endpoint"`
+	TracingFile                        types.String `tfsdk:"tracing_file"`
+	DefaultContractID                  types.String `tfsdk:"default_contract_id"`
+	DefaultGroupID                     types.String `tfsdk:"default_group_id"`
+	DefaultNotificationEmails          types.Set    `tfsdk:"default_notification_emails"`
+	DeletionProtection                 types.Bool   `tfsdk:"deletion_protection"`
+	VersionNotesTemplate               types.String `tfsdk:"version_notes_template"`
+	ChangeID                           types.String `tfsdk:"change_id"`
+	MaxConcurrentActivations           types.Int64  `tfsdk:"max_concurrent_activations"`
+	MaxConcurrentActivationsPerNetwork types.Int64  `tfsdk:"max_concurrent_activations_per_network"`
+	CacheEnabled                       types.Bool   `tfsdk:"cache_enabled"`
+	CacheDir                           types.String `tfsdk:"cache_dir"`
+	CacheTTL                           types.Int64  `tfsdk:"cache_ttl"`
+	RequestLimit                       types.Int64  `tfsdk:"request_limit"`
+	RetryMax                           types.Int64  `tfsdk:"retry_max"`
+	RetryWaitMin                       types.Int64  `tfsdk:"retry_wait_min"`
+	RetryWaitMax                       types.Int64  `tfsdk:"retry_wait_max"`
+	RetryDisabled                      types.Bool   `tfsdk:"retry_disabled"`
+	RetryPolicy                        types.List   `tfsdk:"retry_policy"`
+	ChangeFreeze                       types.List   `tfsdk:"change_freeze"`
+}
+
+// ChangeFreezeWindowModel represents the model of change_freeze block
+type ChangeFreezeWindowModel struct {
+	Start    types.String `tfsdk:"start"`
+	End      types.String `tfsdk:"end"`
+	Timezone types.String `tfsdk:"timezone"`
+	Networks types.Set    `tfsdk:"networks"`
+	Reason   types.String `tfsdk:"reason"`
+}
+
+// RetryRuleModel represents the model of retry_policy block
+type RetryRuleModel struct {
+	PathPrefix  types.String `tfsdk:"path_prefix"`
+	Methods     types.Set    `tfsdk:"methods"`
+	StatusCodes types.Set    `tfsdk:"status_codes"`
 }
 
 // ConfigModel represents the model of edgegrid configuration block
@@ -59,7 +100,7 @@ func NewFrameworkProvider(subproviders ...subprovider.Subprovider) func() provid
 
 // Metadata configures provider's metadata
 func (p *Provider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
-	resp.TypeName = "akamai"
+	resp.TypeName = providerTypeName
 	resp.Version = version.ProviderVersion
 }
 
@@ -74,11 +115,72 @@ func (p *Provider) Schema(_ context.Context, _ provider.SchemaRequest, resp *pro
 				Description: "The section of the edgerc file to use for configuration",
 				Optional:    true,
 			},
+			"credential_process": schema.StringAttribute{
+				Description: credentialProcessDescription,
+				Optional:    true,
+			},
+			"read_only": schema.BoolAttribute{
+				Description: readOnlyDescription,
+				Optional:    true,
+			},
+			"audit_log_path": schema.StringAttribute{
+				Description: auditLogPathDescription,
+				Optional:    true,
+			},
+			"tracing_endpoint": schema.StringAttribute{
+				Description: tracingEndpointDescription,
+				Optional:    true,
+			},
+			"tracing_file": schema.StringAttribute{
+				Description: tracingFileDescription,
+				Optional:    true,
+			},
+			"default_contract_id": schema.StringAttribute{
+				Description: defaultContractIDDescription,
+				Optional:    true,
+			},
+			"default_group_id": schema.StringAttribute{
+				Description: defaultGroupIDDescription,
+				Optional:    true,
+			},
+			"default_notification_emails": schema.SetAttribute{
+				Description: defaultNotificationEmailsDescription,
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"deletion_protection": schema.BoolAttribute{
+				Description: defaultDeletionProtectionDescription,
+				Optional:    true,
+			},
+			"version_notes_template": schema.StringAttribute{
+				Description: versionNotesTemplateDescription,
+				Optional:    true,
+			},
+			"change_id": schema.StringAttribute{
+				Description: changeIDDescription,
+				Optional:    true,
+			},
+			"max_concurrent_activations": schema.Int64Attribute{
+				Description: maxConcurrentActivationsDescription,
+				Optional:    true,
+			},
+			"max_concurrent_activations_per_network": schema.Int64Attribute{
+				Description: maxConcurrentActivationsPerNetworkDescription,
+				Optional:    true,
+			},
 			"cache_enabled": schema.BoolAttribute{
 				Optional: true,
 			},
+			"cache_dir": schema.StringAttribute{
+				Description: "The directory of the persistent cache shared across Terraform runs (in-memory cache only when not set)",
+				Optional:    true,
+			},
+			"cache_ttl": schema.Int64Attribute{
+				Description: "The time in seconds after which cache entries expire, unless their bucket declares its own, default is 600 sec",
+				Optional:    true,
+			},
 			"request_limit": schema.Int64Attribute{
-				Description: "The maximum number of API requests to be made per second (0 for no limit)",
+				Description: "The maximum number of API requests to be made per second to each API, e.g. PAPI or Application Security (0 for no limit)",
 				Optional:    true,
 			},
 			"retry_max": schema.Int64Attribute{
@@ -127,6 +229,55 @@ func (p *Provider) Schema(_ context.Context, _ provider.SchemaRequest, resp *pro
 					},
 				},
 			},
+			"change_freeze": schema.ListNestedBlock{
+				Description: changeFreezeDescription,
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"start": schema.StringAttribute{
+							Description: changeFreezeStartDescription,
+							Required:    true,
+						},
+						"end": schema.StringAttribute{
+							Description: changeFreezeEndDescription,
+							Required:    true,
+						},
+						"timezone": schema.StringAttribute{
+							Description: changeFreezeTimezoneDescription,
+							Optional:    true,
+						},
+						"networks": schema.SetAttribute{
+							Description: changeFreezeNetworksDescription,
+							ElementType: types.StringType,
+							Optional:    true,
+						},
+						"reason": schema.StringAttribute{
+							Description: changeFreezeReasonDescription,
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"retry_policy": schema.ListNestedBlock{
+				Description: retryPolicyDescription,
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"path_prefix": schema.StringAttribute{
+							Description: retryPolicyPathPrefixDescription,
+							Required:    true,
+						},
+						"methods": schema.SetAttribute{
+							Description: retryPolicyMethodsDescription,
+							ElementType: types.StringType,
+							Required:    true,
+						},
+						"status_codes": schema.SetAttribute{
+							Description: retryPolicyStatusCodesDescription,
+							ElementType: types.Int64Type,
+							Required:    true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -184,7 +335,8 @@ func (p *Provider) Configure(ctx context.Context, req provider.ConfigureRequest,
 
 	}
 
-	edgegridConfig, err := newEdgegridConfig(data.EdgercPath.ValueString(), data.EdgercSection.ValueString(), edgegridConfigBearer)
+	credentialProcess := getFrameworkConfigString(data.CredentialProcess, credentialProcessEnv)
+	edgegridConfig, err := newEdgegridConfig(ctx, data.EdgercPath.ValueString(), data.EdgercSection.ValueString(), edgegridConfigBearer, credentialProcess)
 	if err != nil {
 		resp.Diagnostics.Append(diag.NewErrorDiagnostic("configuring context failed", err.Error()))
 		return
@@ -220,16 +372,74 @@ func (p *Provider) Configure(ctx context.Context, req provider.ConfigureRequest,
 		return
 	}
 
+	retryRules, diags := getFrameworkRetryRules(ctx, data.RetryPolicy)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	readOnly, err := getFrameworkConfigBool(data.ReadOnly, readOnlyEnv)
+	if err != nil {
+		resp.Diagnostics.Append(diag.NewErrorDiagnostic("configuring context failed", err.Error()))
+		return
+	}
+
+	defaults, diags := getFrameworkDefaults(ctx, data)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	changeFreeze, diags := getFrameworkChangeFreeze(ctx, data.ChangeFreeze)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	maxConcurrentActivations, err := getFrameworkConfigInt(data.MaxConcurrentActivations, maxConcurrentActivationsEnv)
+	if err != nil {
+		resp.Diagnostics.Append(diag.NewErrorDiagnostic("configuring context failed", err.Error()))
+		return
+	}
+
+	maxConcurrentActivationsPerNetwork, err := getFrameworkConfigInt(data.MaxConcurrentActivationsPerNetwork, maxConcurrentActivationsPerNetworkEnv)
+	if err != nil {
+		resp.Diagnostics.Append(diag.NewErrorDiagnostic("configuring context failed", err.Error()))
+		return
+	}
+
+	cacheTTL, err := getFrameworkConfigInt(data.CacheTTL, "AKAMAI_CACHE_TTL")
+	if err != nil {
+		resp.Diagnostics.Append(diag.NewErrorDiagnostic("configuring context failed", err.Error()))
+		return
+	}
+
 	meta, err := configureContext(contextConfig{
 		edgegridConfig: edgegridConfig,
 		userAgent:      userAgent(req.TerraformVersion),
 		ctx:            ctx,
 		requestLimit:   requestLimit,
 		enableCache:    data.CacheEnabled.ValueBool(),
+		cacheDir:       getFrameworkConfigString(data.CacheDir, "AKAMAI_CACHE_DIR"),
+		cacheTTL:       time.Duration(cacheTTL) * time.Second,
 		retryMax:       retryMax,
 		retryWaitMin:   time.Duration(retryWaitMin) * time.Second,
 		retryWaitMax:   time.Duration(retryWaitMax) * time.Second,
 		retryDisabled:  retryDisabled,
+		retryRules:     retryRules,
+		readOnly:       readOnly,
+		auditLogPath:   getFrameworkConfigString(data.AuditLogPath, auditLogPathEnv),
+		tracingConfig: tracing.Config{
+			Endpoint: getFrameworkConfigString(data.TracingEndpoint, tracingEndpointEnv),
+			File:     getFrameworkConfigString(data.TracingFile, tracingFileEnv),
+		},
+		defaults:     defaults,
+		changeFreeze: changeFreeze,
+		versionNotes: versionnotes.Config{
+			Template: getFrameworkConfigString(data.VersionNotesTemplate, versionNotesTemplateEnv),
+			ChangeID: getFrameworkConfigString(data.ChangeID, changeIDEnv),
+		},
+		activationLimits: activation.Limits{
+			Total:      maxConcurrentActivations,
+			PerNetwork: maxConcurrentActivationsPerNetwork,
+		},
 	})
 	if err != nil {
 		resp.Diagnostics.Append(diag.NewErrorDiagnostic("configuring context failed", err.Error()))
@@ -245,7 +455,9 @@ func (p *Provider) Resources(_ context.Context) []func() resource.Resource {
 	resources := make([]func() resource.Resource, 0)
 
 	for _, subprovider := range p.subproviders {
-		resources = append(resources, subprovider.FrameworkResources()...)
+		for _, newResource := range subprovider.FrameworkResources() {
+			resources = append(resources, withFrameworkAccountSwitchKey(newResource))
+		}
 	}
 
 	return resources
@@ -256,12 +468,27 @@ func (p *Provider) DataSources(_ context.Context) []func() datasource.DataSource
 	dataSources := make([]func() datasource.DataSource, 0)
 
 	for _, subprovider := range p.subproviders {
-		dataSources = append(dataSources, subprovider.FrameworkDataSources()...)
+		for _, newDataSource := range subprovider.FrameworkDataSources() {
+			dataSources = append(dataSources, withFrameworkDataSourceAccountSwitchKey(newDataSource))
+		}
 	}
 
 	return dataSources
 }
 
+// Functions returns slice of functions used to instantiate provider function implementations
+func (p *Provider) Functions(_ context.Context) []func() function.Function {
+	functions := make([]func() function.Function, 0)
+
+	for _, sub := range p.subproviders {
+		if withFunctions, ok := sub.(subprovider.WithFunctions); ok {
+			functions = append(functions, withFunctions.FrameworkFunctions()...)
+		}
+	}
+
+	return functions
+}
+
 func getFrameworkConfigInt(tfValue types.Int64, envKey string) (int, error) {
 	ret := int(tfValue.ValueInt64())
 	if tfValue.IsNull() {
@@ -289,3 +516,100 @@ func getFrameworkConfigBool(tfValue types.Bool, envKey string) (bool, error) {
 	}
 	return ret, nil
 }
+
+func getFrameworkConfigString(tfValue types.String, envKey string) string {
+	if tfValue.IsNull() {
+		return os.Getenv(envKey)
+	}
+	return tfValue.ValueString()
+}
+
+func getFrameworkDefaults(ctx context.Context, data ProviderModel) (meta.Defaults, diag.Diagnostics) {
+	notificationEmails := notificationEmailsFromEnv()
+	if !data.DefaultNotificationEmails.IsNull() && !data.DefaultNotificationEmails.IsUnknown() {
+		notificationEmails = nil
+		if diags := data.DefaultNotificationEmails.ElementsAs(ctx, &notificationEmails, false); diags.HasError() {
+			return meta.Defaults{}, diags
+		}
+	}
+	deletionProtection, err := getFrameworkConfigBool(data.DeletionProtection, deletionProtectionEnv)
+	if err != nil {
+		return meta.Defaults{}, diag.Diagnostics{diag.NewErrorDiagnostic("configuring context failed", err.Error())}
+	}
+	defaults, err := newDefaults(
+		getFrameworkConfigString(data.DefaultContractID, defaultContractIDEnv),
+		getFrameworkConfigString(data.DefaultGroupID, defaultGroupIDEnv),
+		notificationEmails,
+	)
+	if err != nil {
+		return meta.Defaults{}, diag.Diagnostics{diag.NewErrorDiagnostic("configuring context failed", err.Error())}
+	}
+	defaults.DeletionProtection = deletionProtection
+	return defaults, nil
+}
+
+func getFrameworkRetryRules(ctx context.Context, policy types.List) ([]retryRule, diag.Diagnostics) {
+	if policy.IsNull() || policy.IsUnknown() {
+		rules, err := retryRulesFromEnv()
+		if err != nil {
+			return nil, diag.Diagnostics{diag.NewErrorDiagnostic("configuring context failed", err.Error())}
+		}
+		return rules, nil
+	}
+
+	var models []RetryRuleModel
+	diags := policy.ElementsAs(ctx, &models, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	rules := make([]retryRule, 0, len(models))
+	for _, model := range models {
+		rule := retryRule{PathPrefix: model.PathPrefix.ValueString()}
+		diags.Append(model.Methods.ElementsAs(ctx, &rule.Methods, false)...)
+		var statusCodes []int64
+		diags.Append(model.StatusCodes.ElementsAs(ctx, &statusCodes, false)...)
+		if diags.HasError() {
+			return nil, diags
+		}
+		for _, code := range statusCodes {
+			rule.StatusCodes = append(rule.StatusCodes, int(code))
+		}
+		rules = append(rules, rule)
+	}
+	return rules, diags
+}
+
+func getFrameworkChangeFreeze(ctx context.Context, freeze types.List) ([]meta.FreezeWindow, diag.Diagnostics) {
+	var windows []changeFreezeWindow
+	if freeze.IsNull() || freeze.IsUnknown() {
+		var err error
+		if windows, err = changeFreezeFromEnv(); err != nil {
+			return nil, diag.Diagnostics{diag.NewErrorDiagnostic("configuring context failed", err.Error())}
+		}
+	} else {
+		var models []ChangeFreezeWindowModel
+		diags := freeze.ElementsAs(ctx, &models, false)
+		if diags.HasError() {
+			return nil, diags
+		}
+		for _, model := range models {
+			window := changeFreezeWindow{
+				Start:    model.Start.ValueString(),
+				End:      model.End.ValueString(),
+				Timezone: model.Timezone.ValueString(),
+				Reason:   model.Reason.ValueString(),
+			}
+			if diags.Append(model.Networks.ElementsAs(ctx, &window.Networks, false)...); diags.HasError() {
+				return nil, diags
+			}
+			windows = append(windows, window)
+		}
+	}
+
+	changeFreeze, err := newChangeFreeze(windows)
+	if err != nil {
+		return nil, diag.Diagnostics{diag.NewErrorDiagnostic("configuring context failed", err.Error())}
+	}
+	return changeFreeze, nil
+}
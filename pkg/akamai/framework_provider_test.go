@@ -3,6 +3,7 @@ package akamai_test
 import (
 	"context"
 	"fmt"
+	"os"
 	"regexp"
 	"testing"
 
@@ -10,9 +11,13 @@ import (
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/cache"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/testutils"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/providers/registry"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestFrameworkProvider(t *testing.T) {
@@ -23,6 +28,31 @@ func TestFrameworkProvider(t *testing.T) {
 	prov.Schema(context.Background(), provider.SchemaRequest{}, &resp)
 
 	assert.False(t, resp.Diagnostics.HasError())
+
+	for _, newResource := range prov.Resources(context.Background()) {
+		res := newResource()
+		var metadataResp fwresource.MetadataResponse
+		res.Metadata(context.Background(), fwresource.MetadataRequest{ProviderTypeName: "akamai"}, &metadataResp)
+		var schemaResp fwresource.SchemaResponse
+		res.Schema(context.Background(), fwresource.SchemaRequest{}, &schemaResp)
+		assert.Contains(t, schemaResp.Schema.Attributes, "account_switch_key", metadataResp.TypeName)
+	}
+	for _, newDataSource := range prov.DataSources(context.Background()) {
+		ds := newDataSource()
+		var metadataResp datasource.MetadataResponse
+		ds.Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "akamai"}, &metadataResp)
+		var schemaResp datasource.SchemaResponse
+		ds.Schema(context.Background(), datasource.SchemaRequest{}, &schemaResp)
+		assert.Contains(t, schemaResp.Schema.Attributes, "account_switch_key", metadataResp.TypeName)
+	}
+
+	var functions []string
+	for _, newFunction := range prov.(provider.ProviderWithFunctions).Functions(context.Background()) {
+		var metadataResp function.MetadataResponse
+		newFunction().Metadata(context.Background(), function.MetadataRequest{}, &metadataResp)
+		functions = append(functions, metadataResp.Name)
+	}
+	assert.Subset(t, functions, []string{"rules_normalize", "rules_merge", "rules_find_behavior"})
 }
 
 func TestFramework_ConfigureCache_EnabledInContext(t *testing.T) {
@@ -64,6 +94,34 @@ func TestFramework_ConfigureCache_EnabledInContext(t *testing.T) {
 	}
 }
 
+func TestFramework_ConfigureCache_PersistentDir(t *testing.T) {
+	cacheDir := t.TempDir()
+	defer func() {
+		require.NoError(t, cache.EnablePersistent("", ""))
+		require.NoError(t, cache.SetDefaultTTL(0))
+	}()
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(dummy{}),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					provider "akamai" {
+						cache_dir = %q
+						cache_ttl = 60
+					}
+					data "akamai_dummy" "test" {}
+				`, cacheDir),
+			},
+		},
+	})
+
+	entries, err := os.ReadDir(cacheDir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "expected a single namespace directory for the configured credentials")
+}
+
 func TestFramework_ConfigureEdgercInContext(t *testing.T) {
 	tests := map[string]struct {
 		edgerc        string
@@ -0,0 +1,318 @@
+package akamai
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/edgegrid"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/logger"
+)
+
+const (
+	// httpRecordEnv is the environment variable pointing to a cassette file to which
+	// all HTTP interactions are written
+	httpRecordEnv = "AKAMAI_HTTP_RECORD"
+	// httpReplayEnv is the environment variable pointing to a cassette file from which
+	// HTTP responses are served instead of the network
+	httpReplayEnv = "AKAMAI_HTTP_REPLAY"
+)
+
+var (
+	// ErrHTTPCassette is returned when HTTP record or replay cannot be set up
+	ErrHTTPCassette = errors.New("http record/replay")
+	// ErrNoRecordedInteraction is returned in replay mode when the cassette does not contain a matching request
+	ErrNoRecordedInteraction = errors.New("no recorded interaction")
+
+	// cassettes holds cassettes opened by this process per path, so that all sessions
+	// share the same file when recording and the same sequence of responses when replaying
+	cassettes      = make(map[string]any)
+	cassettesMutex sync.Mutex
+)
+
+type (
+	cassetteInteraction struct {
+		Request  cassetteRequest  `json:"request"`
+		Response cassetteResponse `json:"response"`
+	}
+
+	cassetteRequest struct {
+		Method string      `json:"method"`
+		Path   string      `json:"path"`
+		Query  string      `json:"query"`
+		Header http.Header `json:"header"`
+		Body   string      `json:"body"`
+	}
+
+	cassetteResponse struct {
+		StatusCode int         `json:"statusCode"`
+		Header     http.Header `json:"header"`
+		Body       string      `json:"body"`
+	}
+
+	// recordingTransport writes every request/response pair passing through it to a cassette file,
+	// one JSON object per line
+	recordingTransport struct {
+		base     http.RoundTripper
+		cassette *cassetteWriter
+	}
+
+	cassetteWriter struct {
+		mu   sync.Mutex
+		file *os.File
+	}
+
+	// replayingTransport serves responses from a cassette file instead of the network.
+	// Interactions matching the same request are served in the recorded order,
+	// the last one is repeated once all of them were used.
+	replayingTransport struct {
+		mu           sync.Mutex
+		interactions map[string][]cassetteResponse
+		served       map[string]int
+	}
+)
+
+// cassetteTransport wraps base with record or replay behavior if requested by the environment
+func cassetteTransport(base http.RoundTripper) (http.RoundTripper, error) {
+	recordPath, replayPath := os.Getenv(httpRecordEnv), os.Getenv(httpReplayEnv)
+	switch {
+	case recordPath != "" && replayPath != "":
+		return nil, fmt.Errorf("%w: %s and %s cannot be used together", ErrHTTPCassette, httpRecordEnv, httpReplayEnv)
+	case recordPath != "":
+		return newRecordingTransport(base, recordPath)
+	case replayPath != "":
+		return newReplayingTransport(replayPath)
+	}
+	return base, nil
+}
+
+// replayEdgegridConfig returns placeholder credentials allowing to replay a cassette without a real
+// EdgeGrid configuration, as requests are matched regardless of the host and signature
+func replayEdgegridConfig() (*edgegrid.Config, bool) {
+	if os.Getenv(httpReplayEnv) == "" {
+		return nil, false
+	}
+	return &edgegrid.Config{
+		Host:         "replay.akamaiapis.net",
+		ClientToken:  logger.RedactedValue,
+		ClientSecret: logger.RedactedValue,
+		AccessToken:  logger.RedactedValue,
+		MaxBody:      edgegrid.MaxBodySize,
+	}, true
+}
+
+func newRecordingTransport(base http.RoundTripper, path string) (*recordingTransport, error) {
+	cassettesMutex.Lock()
+	defer cassettesMutex.Unlock()
+
+	if w, ok := cassettes[path].(*cassetteWriter); ok {
+		return &recordingTransport{base: base, cassette: w}, nil
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrHTTPCassette, err)
+	}
+	w := &cassetteWriter{file: file}
+	cassettes[path] = w
+
+	return &recordingTransport{base: base, cassette: w}, nil
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *recordingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(&r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.base.RoundTrip(r)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	interaction := cassetteInteraction{
+		Request: cassetteRequest{
+			Method: r.Method,
+			Path:   r.URL.Path,
+			Query:  r.URL.Query().Encode(),
+			Header: redactHeader(r.Header),
+			Body:   normalizeBody(reqBody),
+		},
+		Response: cassetteResponse{
+			StatusCode: resp.StatusCode,
+			Header:     redactHeader(resp.Header),
+			Body:       normalizeBody(respBody),
+		},
+	}
+
+	line, err := json.Marshal(interaction)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.cassette.write(line); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (w *cassetteWriter) write(line []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, err := w.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("%w: %s", ErrHTTPCassette, err)
+	}
+	return nil
+}
+
+func newReplayingTransport(path string) (*replayingTransport, error) {
+	cassettesMutex.Lock()
+	defer cassettesMutex.Unlock()
+
+	if t, ok := cassettes[path].(*replayingTransport); ok {
+		return t, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrHTTPCassette, err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	t := &replayingTransport{
+		interactions: make(map[string][]cassetteResponse),
+		served:       make(map[string]int),
+	}
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, edgegrid.MaxBodySize*16)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var interaction cassetteInteraction
+		if err := json.Unmarshal(scanner.Bytes(), &interaction); err != nil {
+			return nil, fmt.Errorf("%w: invalid interaction in line %d of %s: %s", ErrHTTPCassette, line, path, err)
+		}
+		key := interaction.Request.key()
+		t.interactions[key] = append(t.interactions[key], interaction.Response)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrHTTPCassette, err)
+	}
+	cassettes[path] = t
+
+	return t, nil
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *replayingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	body, err := readBody(&r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	req := cassetteRequest{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query().Encode(),
+		Body:   normalizeBody(body),
+	}
+	key := req.key()
+
+	t.mu.Lock()
+	responses := t.interactions[key]
+	if len(responses) == 0 {
+		t.mu.Unlock()
+		return nil, fmt.Errorf("%w: %s %s?%s", ErrNoRecordedInteraction, req.Method, req.Path, req.Query)
+	}
+	i := t.served[key]
+	if i < len(responses)-1 {
+		t.served[key]++
+	}
+	recorded := responses[i]
+	t.mu.Unlock()
+
+	// recorded bodies are normalized, so their length may differ from the original one
+	header := recorded.Header.Clone()
+	header.Del("Content-Length")
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       r,
+	}, nil
+}
+
+func (r cassetteRequest) key() string {
+	return strings.Join([]string{r.Method, r.Path, r.Query, r.Body}, "\n")
+}
+
+// readBody reads the whole body and replaces it with a fresh reader, so that it can still be consumed
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	data, err := io.ReadAll(*body)
+	if err != nil {
+		return nil, err
+	}
+	if err := (*body).Close(); err != nil {
+		return nil, err
+	}
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+func redactHeader(header http.Header) http.Header {
+	out := header.Clone()
+	for _, h := range logger.RedactedHeaders {
+		out.Del(h)
+	}
+	return out
+}
+
+// normalizeBody masks secrets in JSON bodies and serializes them with sorted keys and no insignificant
+// whitespace, so that recorded and replayed requests can be matched. Numbers are kept as they were sent,
+// so that large integer IDs do not lose precision. Other bodies are left intact.
+func normalizeBody(body []byte) string {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return ""
+	}
+	var v any
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&v); err != nil {
+		return string(body)
+	}
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return string(body)
+	}
+	normalized, err := json.Marshal(logger.RedactJSON(v))
+	if err != nil {
+		return string(body)
+	}
+	return string(normalized)
+}
@@ -0,0 +1,108 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/cache"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/logger"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/tracing"
+	"github.com/apex/log"
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// tracingEndpointEnv is the environment variable holding the OTLP/HTTP endpoint spans are exported to,
+	// used when the tracing_endpoint argument is not configured
+	tracingEndpointEnv = "AKAMAI_TRACING_ENDPOINT"
+
+	// tracingFileEnv is the environment variable holding the path of the file spans are written to,
+	// used when the tracing_file argument is not configured
+	tracingFileEnv = "AKAMAI_TRACING_FILE"
+
+	tracingEndpointDescription = "The URL of an OpenTelemetry collector accepting OTLP over HTTP, e.g. http://localhost:4318, " +
+		"to which spans of resource operations, API requests with their retries and activation polling are exported"
+
+	tracingFileDescription = "The path of a file to which spans of resource operations, API requests with their retries " +
+		"and activation polling are appended as JSON objects"
+)
+
+// operationLogKey is the context key of the logger of an operation, tagged with the operation id
+type operationLogKey struct{}
+
+// startOperation tags the context with the resource type and starts the span of the operation performed for it
+func startOperation(ctx context.Context, resourceType, operation string, m any) (context.Context, trace.Span) {
+	ctx = tf.WithResourceType(ctx, resourceType)
+	attrs := []attribute.KeyValue{tracing.ResourceTypeKey.String(resourceType)}
+	if operationMeta, ok := m.(meta.Meta); ok {
+		attrs = append(attrs, tracing.OperationIDKey.String(operationMeta.OperationID()))
+		ctx = context.WithValue(ctx, operationLogKey{}, operationMeta.Log("cache", "Summary"))
+	}
+	return tracing.Start(ctx, fmt.Sprintf("%s %s", resourceType, operation), attrs...)
+}
+
+// endOperation ends the span of the operation and exports it, as the plugin may be killed before it shuts tracing down.
+// The export is bounded by a short timeout, so that a slow collector does not delay the operation.
+// It also logs the summary of the cache usage since the plugin started. The counts are not reset,
+// as operations run in parallel and share the cache.
+func endOperation(ctx context.Context, span trace.Span, err error) {
+	tracing.End(span, err)
+	tracing.Flush(ctx)
+
+	operationLog, ok := ctx.Value(operationLogKey{}).(log.Interface)
+	if !ok {
+		operationLog = logger.Get("cache", "Summary")
+	}
+	cache.LogStats(operationLog)
+}
+
+// requestTracingTransport traces each request as a span, like the retryable client does for requests sent with retries
+type requestTracingTransport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t requestTracingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	ctx, span := otel.Tracer(tracing.TracerName).Start(r.Context(), "HTTP "+r.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodOriginal(r.Method),
+			semconv.URLPath(r.URL.Path),
+		))
+
+	resp, err := t.base.RoundTrip(r.WithContext(ctx))
+	if resp != nil {
+		span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	}
+	tracing.End(span, err)
+	return resp, err
+}
+
+// sdkDiagsError returns the errors among SDK diagnostics
+func sdkDiagsError(diags diag.Diagnostics) error {
+	var errs []error
+	for _, d := range diags {
+		if d.Severity == diag.Error {
+			errs = append(errs, errors.New(d.Summary))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// frameworkDiagsError returns the errors among framework diagnostics
+func frameworkDiagsError(diags fwdiag.Diagnostics) error {
+	var errs []error
+	for _, d := range diags.Errors() {
+		errs = append(errs, errors.New(d.Summary()))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,104 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/tracing"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	sdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestSDKOperationSpan(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+
+	var operationSpan trace.SpanContext
+	res := &sdkschema.Resource{
+		Schema: map[string]*sdkschema.Schema{
+			"name": {Type: sdkschema.TypeString, Required: true, ForceNew: true},
+		},
+		CreateContext: func(ctx context.Context, _ *sdkschema.ResourceData, _ any) diag.Diagnostics {
+			operationSpan = trace.SpanContextFromContext(ctx)
+			return diag.Errorf("creating failed")
+		},
+		ReadContext: func(context.Context, *sdkschema.ResourceData, any) diag.Diagnostics {
+			return nil
+		},
+		DeleteContext: func(context.Context, *sdkschema.ResourceData, any) diag.Diagnostics {
+			return nil
+		},
+	}
+	addSDKAccountSwitchKey(map[string]*sdkschema.Resource{"akamai_test": res}, true)
+
+	d := sdkschema.TestResourceDataRaw(t, res.Schema, map[string]any{"name": "test"})
+	assert.True(t, res.CreateContext(context.Background(), d, testAccountMeta{}).HasError())
+	assert.False(t, res.ReadContext(context.Background(), d, testAccountMeta{}).HasError())
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	create, read := spans[0], spans[1]
+
+	assert.Equal(t, "akamai_test create", create.Name)
+	assert.Equal(t, operationSpan, create.SpanContext, "operation is performed in the context of its span")
+	assert.Contains(t, create.Attributes, tracing.ResourceTypeKey.String("akamai_test"))
+	assert.Contains(t, create.Attributes, tracing.OperationIDKey.String(""))
+	assert.Equal(t, codes.Error, create.Status.Code)
+	assert.Equal(t, "creating failed", create.Status.Description)
+
+	assert.Equal(t, "akamai_test read", read.Name)
+	assert.Equal(t, codes.Unset, read.Status.Code)
+}
+
+func TestRequestTracingTransport(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+
+	var requestSpan trace.SpanContext
+	transport := requestTracingTransport{base: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		requestSpan = trace.SpanContextFromContext(r.Context())
+		if r.Method == http.MethodPost {
+			return nil, errors.New("connection refused")
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	})}
+
+	ctx, operation := tracing.Start(context.Background(), "akamai_test read")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://test.akamaiapis.net/papi/v1/groups", nil)
+	require.NoError(t, err)
+	_, err = transport.RoundTrip(req)
+	require.NoError(t, err)
+	operation.End()
+
+	req, err = http.NewRequest(http.MethodPost, "https://test.akamaiapis.net/papi/v1/properties", nil)
+	require.NoError(t, err)
+	_, err = transport.RoundTrip(req)
+	assert.Error(t, err)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 3)
+	get, post := spans[0], spans[2]
+
+	assert.Equal(t, "HTTP GET", get.Name)
+	assert.Equal(t, trace.SpanKindClient, get.SpanKind)
+	assert.Equal(t, spans[1].SpanContext.SpanID(), get.Parent.SpanID(), "request span is a child of the operation span")
+	assert.Contains(t, get.Attributes, semconv.URLPath("/papi/v1/groups"))
+	assert.Contains(t, get.Attributes, semconv.HTTPResponseStatusCode(http.StatusOK))
+
+	assert.Equal(t, "HTTP POST", post.Name)
+	assert.Equal(t, post.SpanContext, requestSpan, "request is sent in the context of its span")
+	assert.Equal(t, codes.Error, post.Status.Code)
+}
@@ -0,0 +1,303 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/str"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	sdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// defaultContractIDEnv is the environment variable holding the default contract ID,
+	// used when the default_contract_id argument is not configured
+	defaultContractIDEnv = "AKAMAI_DEFAULT_CONTRACT_ID"
+
+	// defaultGroupIDEnv is the environment variable holding the default group ID,
+	// used when the default_group_id argument is not configured
+	defaultGroupIDEnv = "AKAMAI_DEFAULT_GROUP_ID"
+
+	// defaultNotificationEmailsEnv is the environment variable holding comma separated default notification emails,
+	// used when the default_notification_emails argument is not configured
+	defaultNotificationEmailsEnv = "AKAMAI_DEFAULT_NOTIFICATION_EMAILS"
+
+	defaultContractIDDescription = "The contract ID, with or without the `ctr_` prefix, used by resources whose `contract_id` is not set"
+
+	defaultGroupIDDescription = "The group ID, with or without the `grp_` prefix, used by resources whose `group_id` is not set"
+
+	defaultNotificationEmailsDescription = "The emails notified about activations whose resources do not set their own, " +
+		"e.g. `contact` of `akamai_property_activation` or `notification_emails` of `akamai_appsec_activations`"
+)
+
+// ErrProviderDefaults is returned when the provider defaults are invalid
+var ErrProviderDefaults = errors.New("wrong provider defaults")
+
+// defaultedAttributes holds, per resource type, the attributes which fall back to the provider defaults when not set
+var defaultedAttributes = map[string][]defaultedAttribute{
+	"akamai_property":                    {contractIDAttribute("ctr_"), groupIDAttribute("grp_")},
+	"akamai_property_bootstrap":          {contractIDAttribute("ctr_"), groupIDAttribute("grp_")},
+	"akamai_property_include":            {contractIDAttribute("ctr_"), groupIDAttribute("grp_")},
+	"akamai_cp_code":                     {contractIDAttribute("ctr_"), groupIDAttribute("grp_")},
+	"akamai_edge_hostname":               {contractIDAttribute("ctr_"), groupIDAttribute("grp_")},
+	"akamai_appsec_configuration":        {contractIDAttribute(""), groupIDNumberAttribute()},
+	"akamai_networklist_network_list":    {contractIDAttribute("").optional(), groupIDNumberAttribute().optional()},
+	"akamai_clientlist_list":             {contractIDAttribute(""), groupIDNumberAttribute()},
+	"akamai_cloudaccess_key":             {contractIDAttribute(""), groupIDNumberAttribute()},
+	"akamai_edgekv":                      {groupIDNumberAttribute()},
+	"akamai_edgeworker":                  {groupIDAttribute("")},
+	"akamai_property_activation":         {notificationEmailsAttribute("contact")},
+	"akamai_property_include_activation": {notificationEmailsAttribute("notify_emails")},
+	"akamai_appsec_activations":          {notificationEmailsAttribute("notification_emails")},
+	"akamai_networklist_activations":     {notificationEmailsAttribute("notification_emails")},
+	"akamai_clientlist_activation":       {notificationEmailsAttribute("notification_recipients").optional()},
+}
+
+// defaultedAttribute is an attribute of a resource which falls back to a provider default when not set
+type defaultedAttribute struct {
+	name string
+	// argument is the provider argument holding the default
+	argument string
+	// value returns the default in the form expected by the resource, false when there is no default
+	value func(meta.Defaults) (any, bool)
+	// required tells if either the attribute or the default has to be set
+	required bool
+}
+
+func contractIDAttribute(prefix string) defaultedAttribute {
+	return defaultedAttribute{
+		name:     "contract_id",
+		argument: "default_contract_id",
+		required: true,
+		value: func(d meta.Defaults) (any, bool) {
+			return str.AddPrefix(d.ContractID, prefix), d.ContractID != ""
+		},
+	}
+}
+
+func groupIDAttribute(prefix string) defaultedAttribute {
+	return defaultedAttribute{
+		name:     "group_id",
+		argument: "default_group_id",
+		required: true,
+		value: func(d meta.Defaults) (any, bool) {
+			return str.AddPrefix(d.GroupID, prefix), d.GroupID != ""
+		},
+	}
+}
+
+func groupIDNumberAttribute() defaultedAttribute {
+	return defaultedAttribute{
+		name:     "group_id",
+		argument: "default_group_id",
+		required: true,
+		value: func(d meta.Defaults) (any, bool) {
+			if d.GroupID == "" {
+				return nil, false
+			}
+			// validated by newDefaults
+			groupID, _ := str.GetIntID(d.GroupID, "grp_")
+			return groupID, true
+		},
+	}
+}
+
+func notificationEmailsAttribute(name string) defaultedAttribute {
+	return defaultedAttribute{
+		name:     name,
+		argument: "default_notification_emails",
+		required: true,
+		value: func(d meta.Defaults) (any, bool) {
+			emails := make([]any, 0, len(d.NotificationEmails))
+			for _, email := range d.NotificationEmails {
+				emails = append(emails, email)
+			}
+			return emails, len(emails) > 0
+		},
+	}
+}
+
+// optional returns the attribute which may be left unset when there is no default
+func (a defaultedAttribute) optional() defaultedAttribute {
+	a.required = false
+	return a
+}
+
+// description returns the description of the attribute mentioning the provider default
+func (a defaultedAttribute) description(description string) string {
+	fallback := fmt.Sprintf("Defaults to the `%s` provider argument.", a.argument)
+	if description == "" {
+		return fallback
+	}
+	return strings.TrimSuffix(description, ".") + ". " + fallback
+}
+
+// missing returns the error reported when neither the attribute nor its default is set
+func (a defaultedAttribute) missing() error {
+	return fmt.Errorf("%q is required: set it or the %q provider argument", a.name, a.argument)
+}
+
+// newDefaults returns the provider defaults with contract and group IDs without prefixes
+func newDefaults(contractID, groupID string, notificationEmails []string) (meta.Defaults, error) {
+	groupID = strings.TrimPrefix(groupID, "grp_")
+	if groupID != "" {
+		if _, err := str.GetIntID(groupID, "grp_"); err != nil {
+			return meta.Defaults{}, fmt.Errorf("%w: default_group_id %q is not a number", ErrProviderDefaults, groupID)
+		}
+	}
+	return meta.Defaults{
+		ContractID:         strings.TrimPrefix(contractID, "ctr_"),
+		GroupID:            groupID,
+		NotificationEmails: notificationEmails,
+	}, nil
+}
+
+// notificationEmailsFromEnv returns the default notification emails from the environment variable
+func notificationEmailsFromEnv() []string {
+	var emails []string
+	for _, email := range strings.Split(os.Getenv(defaultNotificationEmailsEnv), ",") {
+		if email = strings.TrimSpace(email); email != "" {
+			emails = append(emails, email)
+		}
+	}
+	return emails
+}
+
+// addSDKProviderDefaults makes the defaulted attributes of the resources optional, and fills them
+// with the provider defaults when planning creation of a resource which does not set them
+func addSDKProviderDefaults(resources map[string]*sdkschema.Resource) {
+	for name, attrs := range defaultedAttributes {
+		r, ok := resources[name]
+		if !ok {
+			continue
+		}
+		for _, attr := range attrs {
+			s := r.Schema[attr.name]
+			s.Required, s.Optional, s.Computed = false, true, true
+			s.Description = attr.description(s.Description)
+		}
+		r.CustomizeDiff = withSDKProviderDefaults(attrs, r.CustomizeDiff)
+	}
+}
+
+func withSDKProviderDefaults(attrs []defaultedAttribute, f sdkschema.CustomizeDiffFunc) sdkschema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *sdkschema.ResourceDiff, m any) error {
+		if d.Id() == "" {
+			if err := setSDKDefaults(d, attrs, providerDefaults(m)); err != nil {
+				return err
+			}
+		}
+		if f == nil {
+			return nil
+		}
+		return f(ctx, d, m)
+	}
+}
+
+func setSDKDefaults(d *sdkschema.ResourceDiff, attrs []defaultedAttribute, defaults meta.Defaults) error {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+	for _, attr := range attrs {
+		if !config.GetAttr(attr.name).IsNull() {
+			continue
+		}
+		value, ok := attr.value(defaults)
+		if !ok {
+			if attr.required {
+				return attr.missing()
+			}
+			continue
+		}
+		if err := d.SetNew(attr.name, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addFrameworkProviderDefaults makes the defaulted attributes among the attributes of the framework resource optional
+func addFrameworkProviderDefaults(resourceType string, attributes map[string]schema.Attribute) {
+	for _, attr := range defaultedAttributes[resourceType] {
+		switch a := attributes[attr.name].(type) {
+		case schema.StringAttribute:
+			a.Required, a.Optional, a.Computed = false, true, true
+			a.Description = attr.description(a.Description)
+			attributes[attr.name] = a
+		case schema.Int64Attribute:
+			a.Required, a.Optional, a.Computed = false, true, true
+			a.Description = attr.description(a.Description)
+			attributes[attr.name] = a
+		case schema.SetAttribute:
+			a.Required, a.Optional, a.Computed = false, true, true
+			a.Description = attr.description(a.Description)
+			attributes[attr.name] = a
+		default:
+			panic(fmt.Sprintf("provider default is not supported for %s.%s of %T", resourceType, attr.name, a))
+		}
+	}
+}
+
+// setFrameworkDefaults returns the plan with the defaulted attributes which are not configured set to their prior state,
+// or to the provider defaults when planning creation
+func setFrameworkDefaults(resourceType string, config, prior, plan tftypes.Value, defaults meta.Defaults) (tftypes.Value, error) {
+	attrs := defaultedAttributes[resourceType]
+	if len(attrs) == 0 || plan.IsNull() || !plan.IsKnown() || !config.IsKnown() {
+		return plan, nil
+	}
+
+	var configAttributes, priorAttributes, planAttributes map[string]tftypes.Value
+	if err := config.As(&configAttributes); err != nil {
+		return plan, err
+	}
+	if err := prior.As(&priorAttributes); err != nil {
+		return plan, err
+	}
+	if err := plan.As(&planAttributes); err != nil {
+		return plan, err
+	}
+
+	for _, attr := range attrs {
+		if !configAttributes[attr.name].IsNull() {
+			continue
+		}
+		if !prior.IsNull() {
+			planAttributes[attr.name] = priorAttributes[attr.name]
+			continue
+		}
+		value, ok := attr.value(defaults)
+		if !ok {
+			if attr.required {
+				return plan, attr.missing()
+			}
+			continue
+		}
+		planAttributes[attr.name] = frameworkValue(planAttributes[attr.name].Type(), value)
+	}
+	return tftypes.NewValue(plan.Type(), planAttributes), nil
+}
+
+func frameworkValue(typ tftypes.Type, value any) tftypes.Value {
+	if set, ok := typ.(tftypes.Set); ok {
+		var elements []tftypes.Value
+		for _, element := range value.([]any) {
+			elements = append(elements, tftypes.NewValue(set.ElementType, element))
+		}
+		return tftypes.NewValue(typ, elements)
+	}
+	return tftypes.NewValue(typ, value)
+}
+
+// providerDefaults returns the defaults of the meta, none before the provider is configured
+func providerDefaults(m any) meta.Defaults {
+	if providerMeta, ok := m.(meta.Meta); ok {
+		return providerMeta.Defaults()
+	}
+	return meta.Defaults{}
+}
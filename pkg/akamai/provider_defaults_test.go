@@ -0,0 +1,196 @@
+package akamai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/providers/registry"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	sdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	// Load the providers
+	_ "github.com/akamai/terraform-provider-akamai/v6/pkg/providers"
+)
+
+func TestNewDefaults(t *testing.T) {
+	tests := map[string]struct {
+		contractID, groupID string
+		expected            meta.Defaults
+		withError           string
+	}{
+		"no defaults": {},
+		"prefixes are trimmed": {
+			contractID: "ctr_1-AB123",
+			groupID:    "grp_12345",
+			expected:   meta.Defaults{ContractID: "1-AB123", GroupID: "12345"},
+		},
+		"without prefixes": {
+			contractID: "1-AB123",
+			groupID:    "12345",
+			expected:   meta.Defaults{ContractID: "1-AB123", GroupID: "12345"},
+		},
+		"group ID is not a number": {
+			groupID:   "grp_abc",
+			withError: `default_group_id "abc" is not a number`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			defaults, err := newDefaults(test.contractID, test.groupID, nil)
+			if test.withError != "" {
+				assert.ErrorIs(t, err, ErrProviderDefaults)
+				assert.ErrorContains(t, err, test.withError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, defaults)
+		})
+	}
+}
+
+func TestNotificationEmailsFromEnv(t *testing.T) {
+	t.Setenv(defaultNotificationEmailsEnv, "jsmith@example.com, jdoe@example.com,")
+	assert.Equal(t, []string{"jsmith@example.com", "jdoe@example.com"}, notificationEmailsFromEnv())
+}
+
+func TestDefaultedAttributes(t *testing.T) {
+	sdkProvider := NewSDKProvider(registry.Subproviders()...)()
+	frameworkResources := make(map[string]resource.Resource)
+	for _, newResource := range NewFrameworkProvider(registry.Subproviders()...)().Resources(context.Background()) {
+		r := newResource()
+		var resp resource.MetadataResponse
+		r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: providerTypeName}, &resp)
+		frameworkResources[resp.TypeName] = r
+	}
+
+	for resourceType, attrs := range defaultedAttributes {
+		for _, attr := range attrs {
+			if r, ok := sdkProvider.ResourcesMap[resourceType]; ok {
+				require.Contains(t, r.Schema, attr.name, resourceType)
+				s := r.Schema[attr.name]
+				assert.True(t, s.Optional && s.Computed && !s.Required, "%s.%s", resourceType, attr.name)
+				continue
+			}
+			r, ok := frameworkResources[resourceType]
+			require.True(t, ok, "unknown resource %s", resourceType)
+			var resp resource.SchemaResponse
+			r.Schema(context.Background(), resource.SchemaRequest{}, &resp)
+			require.Contains(t, resp.Schema.Attributes, attr.name, resourceType)
+			a := resp.Schema.Attributes[attr.name]
+			assert.True(t, a.IsOptional() && a.IsComputed() && !a.IsRequired(), "%s.%s", resourceType, attr.name)
+		}
+	}
+}
+
+func TestSDKProviderDefaults(t *testing.T) {
+	noop := func(context.Context, *sdkschema.ResourceData, any) diag.Diagnostics { return nil }
+	res := &sdkschema.Resource{
+		Schema: map[string]*sdkschema.Schema{
+			"name":        {Type: sdkschema.TypeString, Required: true},
+			"contract_id": {Type: sdkschema.TypeString, Required: true, ForceNew: true},
+			"group_id":    {Type: sdkschema.TypeInt, Required: true, ForceNew: true},
+		},
+		CreateContext: noop,
+		ReadContext:   noop,
+		UpdateContext: noop,
+		DeleteContext: noop,
+	}
+	addSDKProviderDefaults(map[string]*sdkschema.Resource{"akamai_appsec_configuration": res})
+	require.NoError(t, res.InternalValidate(nil, true))
+
+	// diff plans the resource like the SDK does, with the raw config passed along the prior state
+	diff := func(state *terraform.InstanceState, contractID cty.Value, m any) (*terraform.InstanceDiff, error) {
+		config := cty.ObjectVal(map[string]cty.Value{
+			"id":          cty.NullVal(cty.String),
+			"name":        cty.StringVal("test"),
+			"contract_id": contractID,
+			"group_id":    cty.NullVal(cty.Number),
+		})
+		state.RawConfig = config
+		return res.Diff(context.Background(), state, terraform.NewResourceConfigShimmed(config, res.CoreConfigSchema()), m)
+	}
+	defaults := testAccountMeta{defaults: meta.Defaults{ContractID: "1-AB123", GroupID: "12345"}}
+
+	t.Run("defaults of a new resource", func(t *testing.T) {
+		planned, err := diff(&terraform.InstanceState{}, cty.NullVal(cty.String), defaults)
+		require.NoError(t, err)
+		assert.Equal(t, "1-AB123", planned.Attributes["contract_id"].New)
+		assert.Equal(t, "12345", planned.Attributes["group_id"].New)
+	})
+
+	t.Run("configured value takes precedence", func(t *testing.T) {
+		planned, err := diff(&terraform.InstanceState{}, cty.StringVal("1-XYZ"), defaults)
+		require.NoError(t, err)
+		assert.Equal(t, "1-XYZ", planned.Attributes["contract_id"].New)
+		assert.Equal(t, "12345", planned.Attributes["group_id"].New)
+	})
+
+	t.Run("existing resource keeps its values", func(t *testing.T) {
+		state := &terraform.InstanceState{
+			ID:         "1",
+			Attributes: map[string]string{"id": "1", "name": "test", "contract_id": "1-OLD", "group_id": "1"},
+		}
+		planned, err := diff(state, cty.NullVal(cty.String), defaults)
+		require.NoError(t, err)
+		assert.Nil(t, planned)
+	})
+
+	t.Run("no default", func(t *testing.T) {
+		_, err := diff(&terraform.InstanceState{}, cty.NullVal(cty.String), testAccountMeta{})
+		assert.ErrorContains(t, err, `"contract_id" is required: set it or the "default_contract_id" provider argument`)
+	})
+}
+
+func TestSetFrameworkDefaults(t *testing.T) {
+	attributes := map[string]schema.Attribute{
+		"name":        schema.StringAttribute{Required: true},
+		"contract_id": schema.StringAttribute{Required: true},
+		"group_id":    schema.Int64Attribute{Required: true},
+	}
+	addFrameworkProviderDefaults("akamai_cloudaccess_key", attributes)
+	assert.True(t, attributes["contract_id"].IsOptional() && attributes["contract_id"].IsComputed())
+	assert.Equal(t, "Defaults to the `default_group_id` provider argument.", attributes["group_id"].GetDescription())
+
+	typ := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"name":        tftypes.String,
+		"contract_id": tftypes.String,
+		"group_id":    tftypes.Number,
+	}}
+	value := func(contractID, groupID any) tftypes.Value {
+		return tftypes.NewValue(typ, map[string]tftypes.Value{
+			"name":        tftypes.NewValue(tftypes.String, "test"),
+			"contract_id": tftypes.NewValue(tftypes.String, contractID),
+			"group_id":    tftypes.NewValue(tftypes.Number, groupID),
+		})
+	}
+	config := value(nil, nil)
+	defaults := meta.Defaults{ContractID: "1-AB123", GroupID: "12345"}
+
+	t.Run("defaults of a new resource", func(t *testing.T) {
+		plan, err := setFrameworkDefaults("akamai_cloudaccess_key", config, tftypes.NewValue(typ, nil),
+			value(tftypes.UnknownValue, tftypes.UnknownValue), defaults)
+		require.NoError(t, err)
+		assert.True(t, value("1-AB123", 12345).Equal(plan), plan.String())
+	})
+
+	t.Run("existing resource keeps its values", func(t *testing.T) {
+		plan, err := setFrameworkDefaults("akamai_cloudaccess_key", config, value("1-OLD", 1),
+			value(tftypes.UnknownValue, tftypes.UnknownValue), defaults)
+		require.NoError(t, err)
+		assert.True(t, value("1-OLD", 1).Equal(plan), plan.String())
+	})
+
+	t.Run("no default", func(t *testing.T) {
+		_, err := setFrameworkDefaults("akamai_cloudaccess_key", config, tftypes.NewValue(typ, nil),
+			value(tftypes.UnknownValue, tftypes.UnknownValue), meta.Defaults{})
+		assert.ErrorContains(t, err, `"contract_id" is required`)
+	})
+}
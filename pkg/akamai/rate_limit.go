@@ -0,0 +1,169 @@
+package akamai
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apex/log"
+)
+
+const (
+	// rateLimitLowWatermark is the fraction of X-RateLimit-Limit below which requests
+	// of an API family are proactively slowed down
+	rateLimitLowWatermark = 0.1
+	// rateLimitMaxSlowdown is the delay applied when no requests remain
+	rateLimitMaxSlowdown = time.Second
+)
+
+type (
+	// rateLimitTransport throttles requests per API family, i.e. per the first segment of the request path.
+	// Each family has its own token bucket refilled at requestLimit requests per second (if configured),
+	// and is additionally slowed down based on the X-RateLimit-* headers returned by the API.
+	rateLimitTransport struct {
+		base         http.RoundTripper
+		requestLimit int
+		logger       log.Interface
+
+		mu       sync.Mutex
+		families map[string]*familyLimiter
+	}
+
+	familyLimiter struct {
+		mu sync.Mutex
+		// tokens and refilled track the token bucket enforcing requestLimit
+		tokens   float64
+		refilled time.Time
+		// notBefore holds the earliest time of the next request, as derived from rate limit headers
+		notBefore time.Time
+	}
+)
+
+func newRateLimitTransport(base http.RoundTripper, requestLimit int, logger log.Interface) *rateLimitTransport {
+	return &rateLimitTransport{
+		base:         base,
+		requestLimit: requestLimit,
+		logger:       logger,
+		families:     make(map[string]*familyLimiter),
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *rateLimitTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	family := apiFamily(r.URL.Path)
+	limiter := t.limiter(family)
+
+	if wait := limiter.reserve(time.Now(), t.requestLimit); wait > 0 {
+		if t.logger != nil {
+			t.logger.Debugf("Rate limiting %s API: waiting %v before %s %s", family, wait, r.Method, r.URL.Path)
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-r.Context().Done():
+			timer.Stop()
+			return nil, r.Context().Err()
+		case <-timer.C:
+		}
+	}
+
+	resp, err := t.base.RoundTrip(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if delay, ok := rateLimitDelay(resp, t.logger); ok {
+		limiter.delay(time.Now().Add(delay))
+	}
+
+	return resp, nil
+}
+
+func (t *rateLimitTransport) limiter(family string) *familyLimiter {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	limiter, ok := t.families[family]
+	if !ok {
+		limiter = &familyLimiter{tokens: float64(t.requestLimit), refilled: time.Now()}
+		t.families[family] = limiter
+	}
+	return limiter
+}
+
+// reserve takes a token for a request made at now and returns how long the request has to wait
+func (l *familyLimiter) reserve(now time.Time, requestLimit int) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var wait time.Duration
+	if l.notBefore.After(now) {
+		wait = l.notBefore.Sub(now)
+	}
+
+	if requestLimit <= 0 {
+		return wait
+	}
+
+	rate := float64(requestLimit)
+	l.tokens += now.Sub(l.refilled).Seconds() * rate
+	if l.tokens > rate {
+		l.tokens = rate
+	}
+	l.refilled = now
+
+	// a negative balance means the request has to wait for the bucket to refill
+	l.tokens--
+	if l.tokens < 0 {
+		if tokenWait := time.Duration(-l.tokens / rate * float64(time.Second)); tokenWait > wait {
+			wait = tokenWait
+		}
+	}
+	return wait
+}
+
+func (l *familyLimiter) delay(until time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if until.After(l.notBefore) {
+		l.notBefore = until
+	}
+}
+
+// rateLimitDelay returns how long subsequent requests of the same API family should be delayed,
+// based on the X-RateLimit-* headers of the response
+func rateLimitDelay(resp *http.Response, logger log.Interface) (time.Duration, bool) {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return getXRateLimitBackoff(resp, logger)
+	}
+
+	limit, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Limit"))
+	if err != nil || limit <= 0 {
+		return 0, false
+	}
+	remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	if err != nil || remaining < 0 {
+		return 0, false
+	}
+
+	lowWatermark := float64(limit) * rateLimitLowWatermark
+	if float64(remaining) >= lowWatermark {
+		return 0, false
+	}
+
+	// the fewer requests remain, the longer the delay
+	scarcity := 1 - float64(remaining)/lowWatermark
+	delay := time.Duration(scarcity * float64(rateLimitMaxSlowdown))
+	if logger != nil {
+		logger.Debugf("Only %d of %d requests remain for %s, slowing down by %v", remaining, limit, resp.Request.URL.Path, delay)
+	}
+	return delay, true
+}
+
+// apiFamily returns the first segment of the path, e.g. "papi" for "/papi/v1/properties"
+func apiFamily(path string) string {
+	family, _, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	return family
+}
@@ -0,0 +1,157 @@
+package akamai
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestAPIFamily(t *testing.T) {
+	tests := map[string]string{
+		"/papi/v1/properties":         "papi",
+		"/appsec/v1/configs":          "appsec",
+		"/config-gtm/v1/domains/test": "config-gtm",
+		"/identity-management/v3":     "identity-management",
+		"":                            "",
+	}
+	for path, expected := range tests {
+		t.Run(path, func(t *testing.T) {
+			assert.Equal(t, expected, apiFamily(path))
+		})
+	}
+}
+
+func TestRateLimitDelay(t *testing.T) {
+	withLimits := func(status int, limit, remaining string) *http.Response {
+		resp := &http.Response{
+			StatusCode: status,
+			Header:     http.Header{},
+			Request:    newRequest(t, http.MethodGet, "/appsec/v1/configs"),
+		}
+		if limit != "" {
+			resp.Header.Set("X-RateLimit-Limit", limit)
+		}
+		if remaining != "" {
+			resp.Header.Set("X-RateLimit-Remaining", remaining)
+		}
+		return resp
+	}
+
+	tests := map[string]struct {
+		resp          *http.Response
+		expectedDelay time.Duration
+		expectedOK    bool
+	}{
+		"no rate limit headers": {
+			resp: withLimits(http.StatusOK, "", ""),
+		},
+		"plenty of requests remaining": {
+			resp: withLimits(http.StatusOK, "100", "10"),
+		},
+		"few requests remaining": {
+			resp:          withLimits(http.StatusOK, "100", "5"),
+			expectedDelay: rateLimitMaxSlowdown / 2,
+			expectedOK:    true,
+		},
+		"no requests remaining": {
+			resp:          withLimits(http.StatusOK, "100", "0"),
+			expectedDelay: rateLimitMaxSlowdown,
+			expectedOK:    true,
+		},
+		"invalid limit": {
+			resp: withLimits(http.StatusOK, "many", "0"),
+		},
+		"status 429 with X-RateLimit-Next": {
+			resp:          stat429ResponseWaiting(1500 * time.Millisecond),
+			expectedDelay: 1500 * time.Millisecond,
+			expectedOK:    true,
+		},
+		"status 429 without X-RateLimit-Next": {
+			resp: withLimits(http.StatusTooManyRequests, "100", "0"),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			delay, ok := rateLimitDelay(test.resp, nil)
+			assert.Equal(t, test.expectedOK, ok)
+			assert.Equal(t, test.expectedDelay, delay)
+		})
+	}
+}
+
+func TestFamilyLimiterReserve(t *testing.T) {
+	now := time.Now()
+
+	t.Run("token bucket", func(t *testing.T) {
+		limiter := &familyLimiter{tokens: 2, refilled: now}
+		assert.Zero(t, limiter.reserve(now, 2))
+		assert.Zero(t, limiter.reserve(now, 2))
+		assert.Equal(t, 500*time.Millisecond, limiter.reserve(now, 2))
+		assert.Equal(t, time.Second, limiter.reserve(now, 2))
+		// refilled over time, but never above the limit
+		assert.Zero(t, limiter.reserve(now.Add(time.Hour), 2))
+		assert.Zero(t, limiter.reserve(now.Add(time.Hour), 2))
+		assert.Equal(t, 500*time.Millisecond, limiter.reserve(now.Add(time.Hour), 2))
+	})
+
+	t.Run("no request limit", func(t *testing.T) {
+		limiter := &familyLimiter{refilled: now}
+		for i := 0; i < 10; i++ {
+			assert.Zero(t, limiter.reserve(now, 0))
+		}
+	})
+
+	t.Run("delayed by rate limit headers", func(t *testing.T) {
+		limiter := &familyLimiter{refilled: now}
+		limiter.delay(now.Add(2 * time.Second))
+		limiter.delay(now.Add(time.Second))
+		assert.Equal(t, 2*time.Second, limiter.reserve(now, 0))
+		assert.Zero(t, limiter.reserve(now.Add(3*time.Second), 0))
+	})
+}
+
+func TestRateLimitTransport(t *testing.T) {
+	var calls []string
+	base := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		calls = append(calls, r.URL.Path)
+		resp := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Request: r}
+		if r.URL.Path == "/papi/v1/exhausted" {
+			resp.Header.Set("X-RateLimit-Limit", "100")
+			resp.Header.Set("X-RateLimit-Remaining", "0")
+		}
+		return resp, nil
+	})
+	transport := newRateLimitTransport(base, 0, nil)
+
+	do := func(ctx context.Context, path string) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://test.akamaiapis.net"+path, nil)
+		require.NoError(t, err)
+		_, err = transport.RoundTrip(req)
+		return err
+	}
+
+	require.NoError(t, do(context.Background(), "/papi/v1/exhausted"))
+
+	// other API families are not slowed down
+	start := time.Now()
+	require.NoError(t, do(context.Background(), "/appsec/v1/configs"))
+	assert.Less(t, time.Since(start), rateLimitMaxSlowdown/2)
+
+	// requests of the exhausted family wait, unless they are cancelled
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, do(ctx, "/papi/v1/properties"), context.DeadlineExceeded)
+
+	assert.Equal(t, []string{"/papi/v1/exhausted", "/appsec/v1/configs"}, calls)
+	assert.Len(t, transport.families, 2)
+}
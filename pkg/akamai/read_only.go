@@ -0,0 +1,59 @@
+package akamai
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+)
+
+const (
+	// readOnlyEnv is the environment variable enabling the read-only mode,
+	// used when the read_only argument is not configured
+	readOnlyEnv = "AKAMAI_READ_ONLY"
+
+	readOnlyDescription = "Should API requests which may modify objects be rejected, e.g. to safely run plans with credentials allowing writes, default false. " +
+		"Only GET and HEAD requests, and POST requests known to be read-only such as PAPI property search, are sent in this mode"
+)
+
+// ErrReadOnly is returned for requests rejected in the read-only mode
+var ErrReadOnly = errors.New("read-only mode")
+
+// readOnlyPOSTPaths holds paths of POST requests which do not modify any object
+var readOnlyPOSTPaths = map[string]bool{
+	"/papi/v1/search/find-by-value": true,
+	"/edgeworkers/v1/validations":   true,
+}
+
+// readOnlyTransport rejects requests which may modify objects, before they are sent
+type readOnlyTransport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t readOnlyTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if isReadOnlyRequest(r) {
+		return t.base.RoundTrip(r)
+	}
+	if r.Body != nil {
+		_ = r.Body.Close()
+	}
+
+	endpoint := fmt.Sprintf("%s %s", r.Method, r.URL.Path)
+	if resourceType := tf.ResourceTypeFromContext(r.Context()); resourceType != "" {
+		return nil, fmt.Errorf("%w: %s requested by %s was rejected, unset read_only or %s to allow changes",
+			ErrReadOnly, endpoint, resourceType, readOnlyEnv)
+	}
+	return nil, fmt.Errorf("%w: %s was rejected, unset read_only or %s to allow changes", ErrReadOnly, endpoint, readOnlyEnv)
+}
+
+func isReadOnlyRequest(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		return true
+	case http.MethodPost:
+		return readOnlyPOSTPaths[r.URL.Path]
+	}
+	return false
+}
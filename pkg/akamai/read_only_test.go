@@ -0,0 +1,97 @@
+package akamai
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/edgegrid"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/retryablehttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadOnlyTransport(t *testing.T) {
+	tests := map[string]struct {
+		method       string
+		path         string
+		resourceType string
+		errMsg       string
+	}{
+		"GET is sent": {
+			method: http.MethodGet,
+			path:   "/papi/v1/properties",
+		},
+		"HEAD is sent": {
+			method: http.MethodHead,
+			path:   "/papi/v1/properties",
+		},
+		"PAPI search is sent": {
+			method:       http.MethodPost,
+			path:         "/papi/v1/search/find-by-value",
+			resourceType: "akamai_property",
+		},
+		"PUT is rejected": {
+			method:       http.MethodPut,
+			path:         "/papi/v1/properties/prp_1/versions/1/rules",
+			resourceType: "akamai_property",
+			errMsg:       "read-only mode: PUT /papi/v1/properties/prp_1/versions/1/rules requested by akamai_property was rejected",
+		},
+		"appsec configuration version clone is rejected": {
+			method:       http.MethodPost,
+			path:         "/appsec/v1/configs/1234/versions",
+			resourceType: "akamai_appsec_match_target",
+			errMsg:       "read-only mode: POST /appsec/v1/configs/1234/versions requested by akamai_appsec_match_target was rejected",
+		},
+		"DELETE outside of resource operations is rejected": {
+			method: http.MethodDelete,
+			path:   "/dns/v2/zones/example.com",
+			errMsg: "read-only mode: DELETE /dns/v2/zones/example.com was rejected",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var sent bool
+			transport := readOnlyTransport{base: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				sent = true
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(""))}, nil
+			})}
+
+			ctx := context.Background()
+			if test.resourceType != "" {
+				ctx = tf.WithResourceType(ctx, test.resourceType)
+			}
+			req := httptest.NewRequest(test.method, "https://akaa-xxx.luna.akamaiapis.net"+test.path, strings.NewReader("{}")).WithContext(ctx)
+
+			_, err := transport.RoundTrip(req)
+			if test.errMsg != "" {
+				assert.ErrorIs(t, err, ErrReadOnly)
+				assert.ErrorContains(t, err, test.errMsg)
+				assert.False(t, sent)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, sent)
+		})
+	}
+}
+
+func TestReadOnlySession(t *testing.T) {
+	meta, err := configureContext(contextConfig{
+		edgegridConfig: &edgegrid.Config{Host: "akaa-xxx.luna.akamaiapis.net"},
+		ctx:            context.Background(),
+		readOnly:       true,
+	})
+	require.NoError(t, err)
+	rt := meta.Session().Client().Transport.(*retryablehttp.RoundTripper)
+	assert.IsType(t, readOnlyTransport{}, rt.Client.HTTPClient.Transport)
+
+	accountMeta, err := meta.WithAccountSwitchKey("1-ABCD")
+	require.NoError(t, err)
+	rt = accountMeta.Session().Client().Transport.(*retryablehttp.RoundTripper)
+	assert.IsType(t, readOnlyTransport{}, rt.Client.HTTPClient.Transport)
+}
@@ -0,0 +1,91 @@
+package akamai
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+)
+
+const (
+	// retryPolicyEnv is the environment variable holding retry rules as a JSON array,
+	// used when the retry_policy block is not configured
+	retryPolicyEnv = "AKAMAI_RETRY_POLICY"
+
+	retryPolicyDescription            = "Additional rules marking API requests as retryable, e.g. idempotent writes resulting in 5xx status codes"
+	retryPolicyPathPrefixDescription  = "The prefix of the request path, e.g. /appsec/v1/configs"
+	retryPolicyMethodsDescription     = "The HTTP methods of requests to retry"
+	retryPolicyStatusCodesDescription = "The response status codes to retry, between 400 and 599"
+)
+
+var (
+	// ErrRetryPolicy is returned when retry rules are invalid
+	ErrRetryPolicy = errors.New("wrong retry policy")
+
+	retryableMethods = []string{
+		http.MethodGet,
+		http.MethodHead,
+		http.MethodPost,
+		http.MethodPut,
+		http.MethodPatch,
+		http.MethodDelete,
+	}
+)
+
+// retryRule marks responses to requests with any of the methods, to paths starting with the prefix,
+// and with any of the status codes as retryable, in addition to the default retry policy
+type retryRule struct {
+	PathPrefix  string   `json:"path_prefix"`
+	Methods     []string `json:"methods"`
+	StatusCodes []int    `json:"status_codes"`
+}
+
+// retryRulesFromEnv reads retry rules from the AKAMAI_RETRY_POLICY environment variable, if set
+func retryRulesFromEnv() ([]retryRule, error) {
+	v := os.Getenv(retryPolicyEnv)
+	if v == "" {
+		return nil, nil
+	}
+	var rules []retryRule
+	if err := json.Unmarshal([]byte(v), &rules); err != nil {
+		return nil, fmt.Errorf("%w: cannot parse %s: %s", ErrRetryPolicy, retryPolicyEnv, err)
+	}
+	return rules, nil
+}
+
+func validateRetryRules(rules []retryRule) error {
+	for i, rule := range rules {
+		if !strings.HasPrefix(rule.PathPrefix, "/") {
+			return fmt.Errorf("%w: path prefix %q of rule %d has to start with '/'", ErrRetryPolicy, rule.PathPrefix, i)
+		}
+		if len(rule.Methods) == 0 {
+			return fmt.Errorf("%w: rule %d has to list at least one method", ErrRetryPolicy, i)
+		}
+		for _, method := range rule.Methods {
+			if !slices.Contains(retryableMethods, strings.ToUpper(method)) {
+				return fmt.Errorf("%w: method %q of rule %d has to be one of %s", ErrRetryPolicy, method, i, strings.Join(retryableMethods, ", "))
+			}
+		}
+		if len(rule.StatusCodes) == 0 {
+			return fmt.Errorf("%w: rule %d has to list at least one status code", ErrRetryPolicy, i)
+		}
+		for _, code := range rule.StatusCodes {
+			if code < 400 || code > 599 {
+				return fmt.Errorf("%w: status code %d of rule %d has to be between 400 and 599", ErrRetryPolicy, code, i)
+			}
+		}
+	}
+	return nil
+}
+
+func (r retryRule) matches(resp *http.Response) bool {
+	if resp.Request == nil || resp.Request.URL == nil {
+		return false
+	}
+	return strings.HasPrefix(resp.Request.URL.Path, r.PathPrefix) &&
+		slices.ContainsFunc(r.Methods, func(m string) bool { return strings.EqualFold(m, resp.Request.Method) }) &&
+		slices.Contains(r.StatusCodes, resp.StatusCode)
+}
@@ -0,0 +1,93 @@
+package akamai
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateRetryRules(t *testing.T) {
+	tests := map[string]struct {
+		rules  []retryRule
+		errMsg string
+	}{
+		"no rules": {},
+		"valid rules": {
+			rules: []retryRule{
+				{PathPrefix: "/appsec/", Methods: []string{"PUT"}, StatusCodes: []int{502, 503}},
+				{PathPrefix: "/config-dns/v2/zones", Methods: []string{"put", "DELETE"}, StatusCodes: []int{409}},
+			},
+		},
+		"path prefix not starting with slash": {
+			rules:  []retryRule{{PathPrefix: "appsec/", Methods: []string{"PUT"}, StatusCodes: []int{502}}},
+			errMsg: `wrong retry policy: path prefix "appsec/" of rule 0 has to start with '/'`,
+		},
+		"no methods": {
+			rules:  []retryRule{{PathPrefix: "/appsec/", StatusCodes: []int{502}}},
+			errMsg: "wrong retry policy: rule 0 has to list at least one method",
+		},
+		"invalid method": {
+			rules: []retryRule{
+				{PathPrefix: "/appsec/", Methods: []string{"PUT"}, StatusCodes: []int{502}},
+				{PathPrefix: "/appsec/", Methods: []string{"FETCH"}, StatusCodes: []int{502}},
+			},
+			errMsg: `wrong retry policy: method "FETCH" of rule 1 has to be one of GET, HEAD, POST, PUT, PATCH, DELETE`,
+		},
+		"no status codes": {
+			rules:  []retryRule{{PathPrefix: "/appsec/", Methods: []string{"PUT"}}},
+			errMsg: "wrong retry policy: rule 0 has to list at least one status code",
+		},
+		"invalid status code": {
+			rules:  []retryRule{{PathPrefix: "/appsec/", Methods: []string{"PUT"}, StatusCodes: []int{200}}},
+			errMsg: "wrong retry policy: status code 200 of rule 0 has to be between 400 and 599",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := validateRetryRules(test.rules)
+			if test.errMsg != "" {
+				assert.ErrorIs(t, err, ErrRetryPolicy)
+				assert.EqualError(t, err, test.errMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRetryRulesFromEnv(t *testing.T) {
+	t.Run("not set", func(t *testing.T) {
+		rules, err := retryRulesFromEnv()
+		require.NoError(t, err)
+		assert.Nil(t, rules)
+	})
+
+	t.Run("valid JSON", func(t *testing.T) {
+		t.Setenv(retryPolicyEnv, `[{"path_prefix": "/appsec/", "methods": ["PUT"], "status_codes": [502, 503]}]`)
+		rules, err := retryRulesFromEnv()
+		require.NoError(t, err)
+		assert.Equal(t, []retryRule{{PathPrefix: "/appsec/", Methods: []string{"PUT"}, StatusCodes: []int{502, 503}}}, rules)
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		t.Setenv(retryPolicyEnv, `{"path_prefix": "/appsec/"}`)
+		_, err := retryRulesFromEnv()
+		assert.ErrorIs(t, err, ErrRetryPolicy)
+	})
+}
+
+func TestRetryRuleMatches(t *testing.T) {
+	rule := retryRule{PathPrefix: "/config-dns/", Methods: []string{"put"}, StatusCodes: []int{http.StatusBadGateway}}
+
+	assert.True(t, rule.matches(&http.Response{
+		Request:    newRequest(t, http.MethodPut, "/config-dns/v2/zones/example.com/names/www/types/A"),
+		StatusCode: http.StatusBadGateway,
+	}))
+	assert.False(t, rule.matches(&http.Response{
+		Request:    newRequest(t, http.MethodPut, "/papi/v1/properties/prp_1/versions/1/rules"),
+		StatusCode: http.StatusBadGateway,
+	}))
+	assert.False(t, rule.matches(&http.Response{StatusCode: http.StatusBadGateway}))
+}
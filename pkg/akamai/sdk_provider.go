@@ -9,9 +9,13 @@ import (
 	"time"
 
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/cache"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/activation"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/collections"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/versionnotes"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/subprovider"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/tracing"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -66,14 +70,90 @@ func NewSDKProvider(subprovs ...subprovider.Subprovider) plugin.ProviderFunc {
 					},
 				},
 			},
+			"credential_process": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Description: credentialProcessDescription,
+			},
+			"read_only": {
+				Optional:    true,
+				Type:        schema.TypeBool,
+				Description: readOnlyDescription,
+			},
+			"audit_log_path": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Description: auditLogPathDescription,
+			},
+			"tracing_endpoint": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Description: tracingEndpointDescription,
+			},
+			"tracing_file": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Description: tracingFileDescription,
+			},
+			"default_contract_id": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Description: defaultContractIDDescription,
+			},
+			"default_group_id": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Description: defaultGroupIDDescription,
+			},
+			"default_notification_emails": {
+				Optional:    true,
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: defaultNotificationEmailsDescription,
+			},
+			"deletion_protection": {
+				Optional:    true,
+				Type:        schema.TypeBool,
+				Description: defaultDeletionProtectionDescription,
+			},
+			"version_notes_template": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Description: versionNotesTemplateDescription,
+			},
+			"change_id": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Description: changeIDDescription,
+			},
+			"max_concurrent_activations": {
+				Optional:    true,
+				Type:        schema.TypeInt,
+				Description: maxConcurrentActivationsDescription,
+			},
+			"max_concurrent_activations_per_network": {
+				Optional:    true,
+				Type:        schema.TypeInt,
+				Description: maxConcurrentActivationsPerNetworkDescription,
+			},
 			"cache_enabled": {
 				Optional: true,
 				Type:     schema.TypeBool,
 			},
+			"cache_dir": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Description: "The directory of the persistent cache shared across Terraform runs (in-memory cache only when not set)",
+			},
+			"cache_ttl": {
+				Optional:    true,
+				Type:        schema.TypeInt,
+				Description: "The time in seconds after which cache entries expire, unless their bucket declares its own, default is 600 sec",
+			},
 			"request_limit": {
 				Optional:    true,
 				Type:        schema.TypeInt,
-				Description: "The maximum number of API requests to be made per second (0 for no limit)",
+				Description: "The maximum number of API requests to be made per second to each API, e.g. PAPI or Application Security (0 for no limit)",
 			},
 			"retry_max": {
 				Optional:    true,
@@ -95,11 +175,73 @@ func NewSDKProvider(subprovs ...subprovider.Subprovider) plugin.ProviderFunc {
 				Type:        schema.TypeBool,
 				Description: "Should the retries of API requests be disabled, default false",
 			},
+			"change_freeze": {
+				Optional:    true,
+				Type:        schema.TypeList,
+				Description: changeFreezeDescription,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"start": {
+							Required:    true,
+							Type:        schema.TypeString,
+							Description: changeFreezeStartDescription,
+						},
+						"end": {
+							Required:    true,
+							Type:        schema.TypeString,
+							Description: changeFreezeEndDescription,
+						},
+						"timezone": {
+							Optional:    true,
+							Type:        schema.TypeString,
+							Description: changeFreezeTimezoneDescription,
+						},
+						"networks": {
+							Optional:    true,
+							Type:        schema.TypeSet,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: changeFreezeNetworksDescription,
+						},
+						"reason": {
+							Optional:    true,
+							Type:        schema.TypeString,
+							Description: changeFreezeReasonDescription,
+						},
+					},
+				},
+			},
+			"retry_policy": {
+				Optional:    true,
+				Type:        schema.TypeList,
+				Description: retryPolicyDescription,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"path_prefix": {
+							Required:    true,
+							Type:        schema.TypeString,
+							Description: retryPolicyPathPrefixDescription,
+						},
+						"methods": {
+							Required:    true,
+							Type:        schema.TypeSet,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: retryPolicyMethodsDescription,
+						},
+						"status_codes": {
+							Required:    true,
+							Type:        schema.TypeSet,
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+							Description: retryPolicyStatusCodesDescription,
+						},
+					},
+				},
+			},
 		},
 		ResourcesMap:   make(map[string]*schema.Resource),
 		DataSourcesMap: make(map[string]*schema.Resource),
 	}
 
+	activeVersions := make(map[string]subprovider.ActiveVersionsFunc)
 	for _, subprov := range subprovs {
 		if err := collections.AddMap(prov.ResourcesMap, subprov.SDKResources()); err != nil {
 			panic(err)
@@ -108,8 +250,20 @@ func NewSDKProvider(subprovs ...subprovider.Subprovider) plugin.ProviderFunc {
 		if err := collections.AddMap(prov.DataSourcesMap, subprov.SDKDataSources()); err != nil {
 			panic(err)
 		}
+
+		if protected, ok := subprov.(subprovider.WithDeletionProtection); ok {
+			if err := collections.AddMap(activeVersions, protected.SDKActiveVersions()); err != nil {
+				panic(err)
+			}
+		}
 	}
 
+	addSDKProviderDefaults(prov.ResourcesMap)
+	addSDKChangeFreeze(prov.ResourcesMap)
+	addSDKDeletionProtection(prov.ResourcesMap, activeVersions)
+	addSDKAccountSwitchKey(prov.ResourcesMap, true)
+	addSDKAccountSwitchKey(prov.DataSourcesMap, false)
+
 	prov.ConfigureContextFunc = configureProviderContext(prov)
 
 	return func() *schema.Provider {
@@ -164,7 +318,12 @@ func configureProviderContext(p *schema.Provider) schema.ConfigureContextFunc {
 			}
 		}
 
-		edgegridConfig, err := newEdgegridConfig(edgercPath, edgercSection, edgegridConfigBearer)
+		credentialProcess, err := getPluginConfigString(d, "credential_process", credentialProcessEnv)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		edgegridConfig, err := newEdgegridConfig(ctx, edgercPath, edgercSection, edgegridConfigBearer, credentialProcess)
 		if err != nil {
 			return nil, diag.FromErr(err)
 		}
@@ -194,16 +353,94 @@ func configureProviderContext(p *schema.Provider) schema.ConfigureContextFunc {
 			return nil, diag.FromErr(err)
 		}
 
+		retryRules, err := getPluginRetryRules(d)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		readOnly, err := getPluginConfigBool(d, "read_only", readOnlyEnv)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		auditLogPath, err := getPluginConfigString(d, "audit_log_path", auditLogPathEnv)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		tracingEndpoint, err := getPluginConfigString(d, "tracing_endpoint", tracingEndpointEnv)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		tracingFile, err := getPluginConfigString(d, "tracing_file", tracingFileEnv)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		defaults, err := getPluginDefaults(d)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		changeFreeze, err := getPluginChangeFreeze(d)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		versionNotesTemplate, err := getPluginConfigString(d, "version_notes_template", versionNotesTemplateEnv)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		changeID, err := getPluginConfigString(d, "change_id", changeIDEnv)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		maxConcurrentActivations, err := getPluginConfigInt(d, "max_concurrent_activations", maxConcurrentActivationsEnv)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		maxConcurrentActivationsPerNetwork, err := getPluginConfigInt(d, "max_concurrent_activations_per_network", maxConcurrentActivationsPerNetworkEnv)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		cacheDir, err := getPluginConfigString(d, "cache_dir", "AKAMAI_CACHE_DIR")
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		cacheTTL, err := getPluginConfigInt(d, "cache_ttl", "AKAMAI_CACHE_TTL")
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
 		meta, err := configureContext(contextConfig{
 			edgegridConfig: edgegridConfig,
 			userAgent:      userAgent(p.TerraformVersion),
 			ctx:            ctx,
 			requestLimit:   requestLimit,
 			enableCache:    cacheEnabled,
+			cacheDir:       cacheDir,
+			cacheTTL:       time.Duration(cacheTTL) * time.Second,
 			retryMax:       retryMax,
 			retryWaitMin:   time.Duration(retryWaitMin) * time.Second,
 			retryWaitMax:   time.Duration(retryWaitMax) * time.Second,
 			retryDisabled:  retryDisabled,
+			retryRules:     retryRules,
+			readOnly:       readOnly,
+			auditLogPath:   auditLogPath,
+			tracingConfig:  tracing.Config{Endpoint: tracingEndpoint, File: tracingFile},
+			defaults:       defaults,
+			changeFreeze:   changeFreeze,
+			versionNotes:   versionnotes.Config{Template: versionNotesTemplate, ChangeID: changeID},
+			activationLimits: activation.Limits{
+				Total:      maxConcurrentActivations,
+				PerNetwork: maxConcurrentActivationsPerNetwork,
+			},
 		})
 		if err != nil {
 			return nil, diag.FromErr(err)
@@ -255,3 +492,108 @@ func getPluginConfigBool(d *schema.ResourceData, key string, envKey string) (boo
 	}
 	return value, nil
 }
+
+func getPluginConfigString(d *schema.ResourceData, key string, envKey string) (string, error) {
+	value, err := tf.GetStringValue(key, d)
+	if err != nil {
+		if !errors.Is(err, tf.ErrNotFound) {
+			return "", err
+		}
+		value = os.Getenv(envKey)
+	}
+	return value, nil
+}
+
+func getPluginRetryRules(d *schema.ResourceData) ([]retryRule, error) {
+	policy, err := tf.GetListValue("retry_policy", d)
+	if err != nil {
+		if !errors.Is(err, tf.ErrNotFound) {
+			return nil, err
+		}
+		return retryRulesFromEnv()
+	}
+
+	rules := make([]retryRule, 0, len(policy))
+	for _, r := range policy {
+		ruleMap, ok := r.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s, %q", tf.ErrInvalidType, "retry_policy", "map[string]any")
+		}
+		rules = append(rules, retryRule{
+			PathPrefix:  ruleMap["path_prefix"].(string),
+			Methods:     tf.SetToStringSlice(ruleMap["methods"].(*schema.Set)),
+			StatusCodes: setToIntSlice(ruleMap["status_codes"].(*schema.Set)),
+		})
+	}
+	return rules, nil
+}
+
+func getPluginDefaults(d *schema.ResourceData) (meta.Defaults, error) {
+	contractID, err := getPluginConfigString(d, "default_contract_id", defaultContractIDEnv)
+	if err != nil {
+		return meta.Defaults{}, err
+	}
+	groupID, err := getPluginConfigString(d, "default_group_id", defaultGroupIDEnv)
+	if err != nil {
+		return meta.Defaults{}, err
+	}
+
+	deletionProtection, err := getPluginConfigBool(d, "deletion_protection", deletionProtectionEnv)
+	if err != nil {
+		return meta.Defaults{}, err
+	}
+
+	notificationEmails := notificationEmailsFromEnv()
+	emails, err := tf.GetSetValue("default_notification_emails", d)
+	if err != nil {
+		if !errors.Is(err, tf.ErrNotFound) {
+			return meta.Defaults{}, err
+		}
+	} else {
+		notificationEmails = tf.SetToStringSlice(emails)
+	}
+	defaults, err := newDefaults(contractID, groupID, notificationEmails)
+	if err != nil {
+		return meta.Defaults{}, err
+	}
+	defaults.DeletionProtection = deletionProtection
+	return defaults, nil
+}
+
+func getPluginChangeFreeze(d *schema.ResourceData) ([]meta.FreezeWindow, error) {
+	freeze, err := tf.GetListValue("change_freeze", d)
+	if err != nil {
+		if !errors.Is(err, tf.ErrNotFound) {
+			return nil, err
+		}
+		windows, err := changeFreezeFromEnv()
+		if err != nil {
+			return nil, err
+		}
+		return newChangeFreeze(windows)
+	}
+
+	windows := make([]changeFreezeWindow, 0, len(freeze))
+	for _, w := range freeze {
+		windowMap, ok := w.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s, %q", tf.ErrInvalidType, "change_freeze", "map[string]any")
+		}
+		windows = append(windows, changeFreezeWindow{
+			Start:    windowMap["start"].(string),
+			End:      windowMap["end"].(string),
+			Timezone: windowMap["timezone"].(string),
+			Networks: tf.SetToStringSlice(windowMap["networks"].(*schema.Set)),
+			Reason:   windowMap["reason"].(string),
+		})
+	}
+	return newChangeFreeze(windows)
+}
+
+func setToIntSlice(s *schema.Set) []int {
+	ints := make([]int, 0, s.Len())
+	for _, v := range s.List() {
+		ints = append(ints, v.(int))
+	}
+	return ints
+}
@@ -24,6 +24,13 @@ func TestSDKProvider(t *testing.T) {
 	provider := akamai.NewSDKProvider(registry.Subproviders()...)()
 	err := provider.InternalValidate()
 	assert.NoError(t, err)
+
+	for name, res := range provider.ResourcesMap {
+		assert.Contains(t, res.Schema, "account_switch_key", name)
+	}
+	for name, res := range provider.DataSourcesMap {
+		assert.Contains(t, res.Schema, "account_switch_key", name)
+	}
 }
 
 func TestConfigureCache_EnabledInContext(t *testing.T) {
@@ -0,0 +1,17 @@
+package akamai
+
+const (
+	// versionNotesTemplateEnv is the environment variable holding the template of version and activation notes,
+	// used when the version_notes_template argument is not configured
+	versionNotesTemplateEnv = "AKAMAI_VERSION_NOTES_TEMPLATE"
+
+	// changeIDEnv is the environment variable holding the change ID of version and activation notes,
+	// used when the change_id argument is not configured
+	changeIDEnv = "AKAMAI_CHANGE_ID"
+
+	versionNotesTemplateDescription = "A Go template of the notes of property, include, security configuration and cloudlets versions, " +
+		"and of activations, created by the provider. It has access to .Resource, .Name, .Notes (the notes configured for the resource), " +
+		".Workspace, .OperationID, .ChangeID and .Timestamp"
+
+	changeIDDescription = "The ID of the change applied by the Terraform run, e.g. a ticket number, available as .ChangeID in version_notes_template"
+)
@@ -2,9 +2,12 @@
 package cache
 
 import (
+	"encoding/binary"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/logger"
@@ -18,11 +21,33 @@ var (
 	ErrEntryNotFound = errors.New("cache entry not found")
 )
 
-var defaultCache = newCache(10 * time.Minute)
+const (
+	// DefaultTTL is the time to live of entries in buckets that do not declare their own
+	DefaultTTL = 10 * time.Minute
+
+	// maxTTL bounds the in-memory lifetime of any entry, bucket TTLs above it are capped
+	maxTTL = 24 * time.Hour
+
+	expiresSize = 8
+)
+
+var defaultCache = newCache()
 
 type cache struct {
 	cache   *bigcache.BigCache
 	enabled bool
+
+	// configMutex guards store and ttl which are set when the provider is configured
+	configMutex sync.RWMutex
+	// store is an optional persistent backend shared across provider runs
+	store *fileStore
+	// ttl is used for buckets which do not declare their own
+	ttl time.Duration
+
+	// keys indexes keys set in memory, as bigcache does not reliably iterate over keys
+	keys sync.Map
+
+	stats stats
 }
 
 // BucketName can be used as a bucket argument to Set and Get functions
@@ -33,18 +58,128 @@ func (b BucketName) Name() string {
 	return string(b)
 }
 
+// MemoryBucketName can be used as a bucket argument to Set and Get functions
+// for data which can change during a single run, e.g. the modifiable version of a configuration.
+// Entries stored under such a bucket never reach the persistent store.
+type MemoryBucketName string
+
+// Name returns MemoryBucketName as a string
+func (b MemoryBucketName) Name() string {
+	return string(b)
+}
+
+// MemoryOnly marks the bucket as excluded from the persistent store
+func (b MemoryBucketName) MemoryOnly() bool {
+	return true
+}
+
 // Bucket defines a contract for a bucket used to form a key
 type Bucket interface {
 	Name() string
 }
 
-func newCache(eviction time.Duration) *cache {
-	c, err := bigcache.NewBigCache(bigcache.DefaultConfig(eviction))
+type memoryOnlyBucket interface {
+	MemoryOnly() bool
+}
+
+type ttlBucket interface {
+	TTL() time.Duration
+}
+
+// ConfiguredBucket is a bucket declaring its own time to live of entries
+type ConfiguredBucket struct {
+	name       string
+	ttl        time.Duration
+	memoryOnly bool
+}
+
+// NewBucket returns a bucket whose entries expire after ttl
+func NewBucket(name string, ttl time.Duration) ConfiguredBucket {
+	return ConfiguredBucket{name: name, ttl: ttl}
+}
+
+// NewMemoryBucket returns a bucket whose entries expire after ttl and never reach the persistent store
+func NewMemoryBucket(name string, ttl time.Duration) ConfiguredBucket {
+	return ConfiguredBucket{name: name, ttl: ttl, memoryOnly: true}
+}
+
+// Name returns name of the bucket
+func (b ConfiguredBucket) Name() string {
+	return b.name
+}
+
+// TTL returns the time to live of entries in the bucket
+func (b ConfiguredBucket) TTL() time.Duration {
+	return b.ttl
+}
+
+// MemoryOnly returns whether the bucket is excluded from the persistent store
+func (b ConfiguredBucket) MemoryOnly() bool {
+	return b.memoryOnly
+}
+
+// accountBucket keeps entries of an account separately from entries of other accounts in the same bucket
+type accountBucket struct {
+	bucket           Bucket
+	accountSwitchKey string
+}
+
+// ForAccount returns the bucket for entries read with the given account switch key, so that data of
+// different accounts never mixes. The empty key stands for the account of the provider credentials.
+func ForAccount(bucket Bucket, accountSwitchKey string) Bucket {
+	if accountSwitchKey == "" {
+		return bucket
+	}
+	return accountBucket{bucket: bucket, accountSwitchKey: accountSwitchKey}
+}
+
+// Name returns name of the bucket
+func (b accountBucket) Name() string {
+	return fmt.Sprintf("%s@%s", b.bucket.Name(), b.accountSwitchKey)
+}
+
+// TTL returns the time to live of entries in the underlying bucket
+func (b accountBucket) TTL() time.Duration {
+	if t, ok := b.bucket.(ttlBucket); ok {
+		return t.TTL()
+	}
+	return 0
+}
+
+// MemoryOnly returns whether the underlying bucket is excluded from the persistent store
+func (b accountBucket) MemoryOnly() bool {
+	return !isPersistent(b.bucket)
+}
+
+func isPersistent(bucket Bucket) bool {
+	b, ok := bucket.(memoryOnlyBucket)
+	return !ok || !b.MemoryOnly()
+}
+
+func bucketTTL(bucket Bucket) time.Duration {
+	ttl := defaultCache.defaultTTL()
+	if b, ok := bucket.(ttlBucket); ok && b.TTL() > 0 {
+		ttl = b.TTL()
+	}
+	if ttl > maxTTL {
+		return maxTTL
+	}
+	return ttl
+}
+
+func newCache() *cache {
+	c, err := bigcache.NewBigCache(bigcache.DefaultConfig(maxTTL))
 	if err != nil {
 		panic(err)
 	}
 
-	return &cache{cache: c}
+	return &cache{cache: c, ttl: DefaultTTL}
+}
+
+func (c *cache) defaultTTL() time.Duration {
+	c.configMutex.RLock()
+	defer c.configMutex.RUnlock()
+	return c.ttl
 }
 
 // Enable is used to enable or disable cache
@@ -57,6 +192,53 @@ func IsEnabled() bool {
 	return defaultCache.enabled
 }
 
+// SetDefaultTTL sets the time to live of entries in buckets that do not declare their own.
+// Passing zero restores DefaultTTL.
+func SetDefaultTTL(ttl time.Duration) error {
+	if ttl < 0 {
+		return fmt.Errorf("cache ttl cannot be negative, got %v", ttl)
+	}
+	if ttl == 0 {
+		ttl = DefaultTTL
+	}
+
+	defaultCache.configMutex.Lock()
+	defer defaultCache.configMutex.Unlock()
+	defaultCache.ttl = ttl
+
+	return nil
+}
+
+// EnablePersistent configures a persistent on-disk backend under dir, shared across provider runs
+// and parallel plugin processes. Entries are kept separately for each namespace (e.g. EdgeGrid host
+// and account key). Passing an empty dir disables the persistent backend.
+func EnablePersistent(dir, namespace string) error {
+	var store *fileStore
+	if dir != "" {
+		var err error
+		store, err = newFileStore(dir, namespace)
+		if err != nil {
+			return err
+		}
+	}
+
+	defaultCache.configMutex.Lock()
+	defer defaultCache.configMutex.Unlock()
+	defaultCache.store = store
+
+	return nil
+}
+
+func persistentStore(bucket Bucket) *fileStore {
+	if !isPersistent(bucket) {
+		return nil
+	}
+
+	defaultCache.configMutex.RLock()
+	defer defaultCache.configMutex.RUnlock()
+	return defaultCache.store
+}
+
 // Set sets the given value under the key in cache
 func Set(bucket Bucket, key string, val any) error {
 	log := logger.Get("cache", "CacheSet")
@@ -66,7 +248,7 @@ func Set(bucket Bucket, key string, val any) error {
 		return ErrDisabled
 	}
 
-	key = fmt.Sprintf("%s:%s", key, bucket.Name())
+	key = cacheKey(bucket, key)
 
 	data, err := json.Marshal(val)
 	if err != nil {
@@ -75,7 +257,20 @@ func Set(bucket Bucket, key string, val any) error {
 
 	log.Debugf("cache set for for key %s [%d bytes]", key, len(data))
 
-	return defaultCache.cache.Set(key, data)
+	expires := time.Now().Add(bucketTTL(bucket))
+	if err := setMemory(key, expires, data); err != nil {
+		return err
+	}
+	defaultCache.stats.set(bucket, len(data))
+
+	// the persistent store is an optimization only, failing to write to it must not fail the operation
+	if store := persistentStore(bucket); store != nil {
+		if err := store.set(key, data, expires); err != nil {
+			log.Warnf("failed to store key %s in persistent cache: %s", key, err)
+		}
+	}
+
+	return nil
 }
 
 // Get returns value stored under the key from cache and writes it into out
@@ -87,18 +282,131 @@ func Get(bucket Bucket, key string, out any) error {
 		return ErrDisabled
 	}
 
-	key = fmt.Sprintf("%s:%s", key, bucket.Name())
+	key = cacheKey(bucket, key)
 
-	data, err := defaultCache.cache.Get(key)
+	data, err := getMemory(bucket, key)
+	if errors.Is(err, ErrEntryNotFound) {
+		data, err = getPersistent(bucket, key, log)
+	}
 	if err != nil {
-		if errors.Is(err, bigcache.ErrEntryNotFound) {
+		if errors.Is(err, ErrEntryNotFound) {
 			log.Debugf("cache miss for key %s", key)
-			return ErrEntryNotFound
+			defaultCache.stats.miss(bucket)
 		}
 		return err
 	}
 
 	log.Debugf("cache get for for key %s: [%d bytes]", key, len(data))
+	defaultCache.stats.hit(bucket)
 
 	return json.Unmarshal(data, out)
 }
+
+// Invalidate removes all entries of the bucket whose keys start with keyPrefix, both from memory
+// and from the persistent store. Resources should call it after writes affecting cached data.
+func Invalidate(bucket Bucket, keyPrefix string) {
+	log := logger.Get("cache", "CacheInvalidate")
+
+	matches := func(key string) bool {
+		return strings.HasPrefix(key, keyPrefix) && strings.HasSuffix(key, ":"+bucket.Name())
+	}
+
+	var keys []string
+	defaultCache.keys.Range(func(k, _ any) bool {
+		if key := k.(string); matches(key) {
+			keys = append(keys, key)
+		}
+		return true
+	})
+
+	removed := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		defaultCache.keys.Delete(key)
+		if err := defaultCache.cache.Delete(key); err == nil {
+			removed[key] = struct{}{}
+		}
+	}
+
+	if store := persistentStore(bucket); store != nil {
+		storeKeys, err := store.invalidate(matches)
+		if err != nil {
+			log.Warnf("failed to invalidate persistent cache for prefix %s: %s", keyPrefix, err)
+		}
+		for _, key := range storeKeys {
+			removed[key] = struct{}{}
+		}
+	}
+
+	log.Debugf("cache invalidated %d entries for prefix %s in bucket %s", len(removed), keyPrefix, bucket.Name())
+	defaultCache.stats.invalidate(bucket, len(removed))
+}
+
+func getMemory(bucket Bucket, key string) ([]byte, error) {
+	raw, err := defaultCache.cache.Get(key)
+	if err != nil {
+		if errors.Is(err, bigcache.ErrEntryNotFound) {
+			return nil, ErrEntryNotFound
+		}
+		return nil, err
+	}
+
+	expires, data, ok := decodeEntry(raw)
+	if !ok || time.Now().After(expires) {
+		defaultCache.keys.Delete(key)
+		_ = defaultCache.cache.Delete(key)
+		defaultCache.stats.evict(bucket)
+		return nil, ErrEntryNotFound
+	}
+
+	return data, nil
+}
+
+func setMemory(key string, expires time.Time, data []byte) error {
+	if err := defaultCache.cache.Set(key, encodeEntry(expires, data)); err != nil {
+		return err
+	}
+	defaultCache.keys.Store(key, struct{}{})
+	return nil
+}
+
+func getPersistent(bucket Bucket, key string, log *logger.Logger) ([]byte, error) {
+	store := persistentStore(bucket)
+	if store == nil {
+		return nil, ErrEntryNotFound
+	}
+
+	data, expires, err := store.get(key)
+	if err != nil {
+		if !errors.Is(err, ErrEntryNotFound) {
+			log.Warnf("failed to read key %s from persistent cache: %s", key, err)
+		}
+		return nil, ErrEntryNotFound
+	}
+
+	// keep the entry in memory for the remaining part of its lifetime
+	if err := setMemory(key, expires, data); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func cacheKey(bucket Bucket, key string) string {
+	return fmt.Sprintf("%s:%s", key, bucket.Name())
+}
+
+// encodeEntry prefixes data with its expiration time, as bigcache only supports a single eviction window
+func encodeEntry(expires time.Time, data []byte) []byte {
+	entry := make([]byte, expiresSize+len(data))
+	binary.BigEndian.PutUint64(entry, uint64(expires.UnixNano()))
+	copy(entry[expiresSize:], data)
+	return entry
+}
+
+func decodeEntry(entry []byte) (time.Time, []byte, bool) {
+	if len(entry) < expiresSize {
+		return time.Time{}, nil, false
+	}
+	expires := time.Unix(0, int64(binary.BigEndian.Uint64(entry)))
+	return expires, entry[expiresSize:], true
+}
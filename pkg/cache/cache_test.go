@@ -2,6 +2,7 @@ package cache
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -43,3 +44,108 @@ func TestCache(t *testing.T) {
 	err = Get(bucket, key, nil)
 	assert.ErrorIs(t, err, ErrDisabled)
 }
+
+func TestCachePersistent(t *testing.T) {
+	bucket := BucketName("testBucket")
+	memoryBucket := MemoryBucketName("testMemoryBucket")
+	key := "testKey"
+	object := TestObject{"1234"}
+
+	Enable(true)
+	require.NoError(t, EnablePersistent(t.TempDir(), "host:accountKey"))
+	defer func() {
+		require.NoError(t, EnablePersistent("", ""))
+		Enable(false)
+	}()
+
+	require.NoError(t, Set(bucket, key, object))
+	require.NoError(t, Set(memoryBucket, key, object))
+
+	// simulate a new provider process
+	require.NoError(t, defaultCache.cache.Reset())
+
+	var out TestObject
+	err := Get(bucket, key, &out)
+	require.NoError(t, err)
+	assert.Equal(t, object, out)
+
+	err = Get(memoryBucket, key, &out)
+	assert.ErrorIs(t, err, ErrEntryNotFound)
+}
+
+func TestCacheBucketTTL(t *testing.T) {
+	bucket := NewBucket("testTTLBucket", time.Millisecond)
+	key := "testKey"
+	object := TestObject{"1234"}
+
+	Enable(true)
+	ResetStats()
+	defer Enable(false)
+
+	require.NoError(t, Set(bucket, key, object))
+	time.Sleep(5 * time.Millisecond)
+
+	var out TestObject
+	err := Get(bucket, key, &out)
+	assert.ErrorIs(t, err, ErrEntryNotFound)
+
+	assert.Equal(t, BucketStats{Misses: 1, Bytes: 13, Evictions: 1}, Stats()["testTTLBucket"])
+}
+
+func TestCacheInvalidate(t *testing.T) {
+	bucket := BucketName("testInvalidateBucket")
+	otherBucket := BucketName("testOtherBucket")
+	object := TestObject{"1234"}
+
+	Enable(true)
+	ResetStats()
+	require.NoError(t, EnablePersistent(t.TempDir(), "host:accountKey"))
+	defer func() {
+		require.NoError(t, EnablePersistent("", ""))
+		Enable(false)
+	}()
+
+	require.NoError(t, Set(bucket, "getList:1:", object))
+	require.NoError(t, Set(bucket, "getList:12:", object))
+	require.NoError(t, Set(otherBucket, "getList:1:", object))
+
+	Invalidate(bucket, "getList:1:")
+
+	var out TestObject
+	assert.ErrorIs(t, Get(bucket, "getList:1:", &out), ErrEntryNotFound)
+	assert.NoError(t, Get(bucket, "getList:12:", &out))
+	assert.NoError(t, Get(otherBucket, "getList:1:", &out))
+
+	assert.Equal(t, BucketStats{Hits: 1, Misses: 1, Bytes: 26, Invalidations: 1}, Stats()["testInvalidateBucket"])
+	assert.Equal(t, BucketStats{Hits: 1, Bytes: 13}, Stats()["testOtherBucket"])
+
+	ResetStats()
+	assert.Empty(t, Stats())
+}
+
+func TestCacheForAccount(t *testing.T) {
+	bucket := NewMemoryBucket("testAccountBucket", time.Hour)
+	object := TestObject{"1234"}
+
+	Enable(true)
+	defer Enable(false)
+
+	assert.Equal(t, bucket, ForAccount(bucket, ""))
+	accountBucket := ForAccount(bucket, "1-ABCD")
+	assert.Equal(t, "testAccountBucket@1-ABCD", accountBucket.Name())
+	assert.False(t, isPersistent(accountBucket))
+	assert.Equal(t, time.Hour, bucketTTL(accountBucket))
+
+	require.NoError(t, Set(accountBucket, "getList:1", object))
+
+	var out TestObject
+	assert.ErrorIs(t, Get(bucket, "getList:1", &out), ErrEntryNotFound)
+	assert.ErrorIs(t, Get(ForAccount(bucket, "1-EFGH"), "getList:1", &out), ErrEntryNotFound)
+	require.NoError(t, Get(ForAccount(bucket, "1-ABCD"), "getList:1", &out))
+	assert.Equal(t, object, out)
+
+	Invalidate(bucket, "getList:")
+	assert.NoError(t, Get(accountBucket, "getList:1", &out))
+	Invalidate(accountBucket, "getList:")
+	assert.ErrorIs(t, Get(accountBucket, "getList:1", &out), ErrEntryNotFound)
+}
@@ -0,0 +1,149 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// fileStore is a persistent cache backend keeping one file per entry under a directory.
+// Entries are namespaced, so that data fetched with different credentials never mixes.
+type fileStore struct {
+	dir string
+}
+
+type fileEntry struct {
+	Key     string          `json:"key"`
+	Expires time.Time       `json:"expires"`
+	Data    json.RawMessage `json:"data"`
+}
+
+const (
+	fileStoreDirPerm  = 0700
+	fileStoreFilePerm = 0600
+)
+
+func newFileStore(dir, namespace string) (*fileStore, error) {
+	dir = filepath.Join(dir, hashKey(namespace))
+	if err := os.MkdirAll(dir, fileStoreDirPerm); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	return &fileStore{dir: dir}, nil
+}
+
+func (s *fileStore) get(key string) ([]byte, time.Time, error) {
+	path := s.path(key)
+
+	entry, err := readFileEntry(path)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	if entry.Key != key {
+		// colliding entry, treat it as a miss so it gets overwritten
+		return nil, time.Time{}, ErrEntryNotFound
+	}
+
+	if time.Now().After(entry.Expires) {
+		// best effort, another process may have removed or replaced it already
+		_ = os.Remove(path)
+		return nil, time.Time{}, ErrEntryNotFound
+	}
+
+	return entry.Data, entry.Expires, nil
+}
+
+// set writes the entry to a temporary file first and then renames it, so that
+// parallel plugin processes never observe a partially written entry
+func (s *fileStore) set(key string, data []byte, expires time.Time) error {
+	raw, err := json.Marshal(fileEntry{
+		Key:     key,
+		Expires: expires,
+		Data:    data,
+	})
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(s.dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create cache entry: %w", err)
+	}
+	defer func() {
+		// no-op once the file is renamed
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if _, err := tmp.Write(raw); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := tmp.Chmod(fileStoreFilePerm); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), s.path(key)); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+
+	return nil
+}
+
+// invalidate removes all entries with keys accepted by matches and returns their keys
+func (s *fileStore) invalidate(matches func(key string) bool) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(s.dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	for _, path := range paths {
+		entry, err := readFileEntry(path)
+		if err != nil || !matches(entry.Key) {
+			continue
+		}
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return keys, err
+		}
+		keys = append(keys, entry.Key)
+	}
+
+	return keys, nil
+}
+
+func readFileEntry(path string) (*fileEntry, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrEntryNotFound
+		}
+		return nil, err
+	}
+
+	var entry fileEntry
+	if err := json.Unmarshal(raw, &entry); err != nil {
+		// corrupted entry, treat it as a miss so it gets overwritten
+		return nil, ErrEntryNotFound
+	}
+
+	return &entry, nil
+}
+
+func (s *fileStore) path(key string) string {
+	return filepath.Join(s.dir, hashKey(key)+".json")
+}
+
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
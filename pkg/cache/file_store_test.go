@@ -0,0 +1,86 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileStore(t *testing.T) {
+	dir := t.TempDir()
+
+	store, err := newFileStore(dir, "host:accountKey")
+	require.NoError(t, err)
+
+	_, _, err = store.get("key")
+	assert.ErrorIs(t, err, ErrEntryNotFound)
+
+	expires := time.Now().Add(time.Minute)
+	require.NoError(t, store.set("key", []byte(`{"ID":"1234"}`), expires))
+
+	data, gotExpires, err := store.get("key")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"ID":"1234"}`, string(data))
+	assert.True(t, expires.Equal(gotExpires))
+
+	// other namespaces do not see the entry
+	otherStore, err := newFileStore(dir, "host:otherAccountKey")
+	require.NoError(t, err)
+	_, _, err = otherStore.get("key")
+	assert.ErrorIs(t, err, ErrEntryNotFound)
+
+	// no temporary files are left behind
+	tmpFiles, err := filepath.Glob(filepath.Join(store.dir, ".tmp-*"))
+	require.NoError(t, err)
+	assert.Empty(t, tmpFiles)
+
+	info, err := os.Stat(store.path("key"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(fileStoreFilePerm), info.Mode().Perm())
+}
+
+func TestFileStoreExpiration(t *testing.T) {
+	store, err := newFileStore(t.TempDir(), "host:accountKey")
+	require.NoError(t, err)
+
+	require.NoError(t, store.set("key", []byte(`{}`), time.Now().Add(-time.Second)))
+
+	_, _, err = store.get("key")
+	assert.ErrorIs(t, err, ErrEntryNotFound)
+
+	_, err = os.Stat(store.path("key"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestFileStoreCorruptedEntry(t *testing.T) {
+	store, err := newFileStore(t.TempDir(), "host:accountKey")
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(store.path("key"), []byte("not json"), fileStoreFilePerm))
+
+	_, _, err = store.get("key")
+	assert.ErrorIs(t, err, ErrEntryNotFound)
+}
+
+func TestFileStoreInvalidate(t *testing.T) {
+	store, err := newFileStore(t.TempDir(), "host:accountKey")
+	require.NoError(t, err)
+
+	expires := time.Now().Add(time.Minute)
+	require.NoError(t, store.set("a:1", []byte(`{}`), expires))
+	require.NoError(t, store.set("a:2", []byte(`{}`), expires))
+	require.NoError(t, store.set("b:1", []byte(`{}`), expires))
+
+	keys, err := store.invalidate(func(key string) bool { return key[0] == 'a' })
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"a:1", "a:2"}, keys)
+
+	_, _, err = store.get("a:1")
+	assert.ErrorIs(t, err, ErrEntryNotFound)
+	_, _, err = store.get("b:1")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,108 @@
+package cache
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/apex/log"
+)
+
+// BucketStats holds usage statistics of a single bucket
+type BucketStats struct {
+	// Hits is the number of Get calls which found an entry
+	Hits int
+	// Misses is the number of Get calls which did not find an entry
+	Misses int
+	// Bytes is the total size of values written with Set
+	Bytes int
+	// Evictions is the number of entries removed because they expired
+	Evictions int
+	// Invalidations is the number of entries removed with Invalidate
+	Invalidations int
+}
+
+type stats struct {
+	sync.Mutex
+	buckets map[string]*BucketStats
+}
+
+func (s *stats) update(bucket Bucket, f func(*BucketStats)) {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.buckets == nil {
+		s.buckets = make(map[string]*BucketStats)
+	}
+	b, ok := s.buckets[bucket.Name()]
+	if !ok {
+		b = &BucketStats{}
+		s.buckets[bucket.Name()] = b
+	}
+	f(b)
+}
+
+func (s *stats) hit(bucket Bucket) {
+	s.update(bucket, func(b *BucketStats) { b.Hits++ })
+}
+
+func (s *stats) miss(bucket Bucket) {
+	s.update(bucket, func(b *BucketStats) { b.Misses++ })
+}
+
+func (s *stats) set(bucket Bucket, size int) {
+	s.update(bucket, func(b *BucketStats) { b.Bytes += size })
+}
+
+func (s *stats) evict(bucket Bucket) {
+	s.update(bucket, func(b *BucketStats) { b.Evictions++ })
+}
+
+func (s *stats) invalidate(bucket Bucket, n int) {
+	s.update(bucket, func(b *BucketStats) { b.Invalidations += n })
+}
+
+// Stats returns usage statistics per bucket name gathered since the last ResetStats
+func Stats() map[string]BucketStats {
+	defaultCache.stats.Lock()
+	defer defaultCache.stats.Unlock()
+
+	out := make(map[string]BucketStats, len(defaultCache.stats.buckets))
+	for name, b := range defaultCache.stats.buckets {
+		out[name] = *b
+	}
+	return out
+}
+
+// ResetStats clears usage statistics of all buckets, e.g. between tests
+func ResetStats() {
+	defaultCache.stats.Lock()
+	defer defaultCache.stats.Unlock()
+
+	defaultCache.stats.buckets = nil
+}
+
+// LogStats writes a summary of usage statistics per bucket to the logger
+func LogStats(logger log.Interface) {
+	buckets := Stats()
+	if len(buckets) == 0 {
+		return
+	}
+
+	names := make([]string, 0, len(buckets))
+	for name := range buckets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		b := buckets[name]
+		logger.WithFields(log.Fields{
+			"bucket":        name,
+			"hits":          b.Hits,
+			"misses":        b.Misses,
+			"bytes":         b.Bytes,
+			"evictions":     b.Evictions,
+			"invalidations": b.Invalidations,
+		}).Info("cache summary")
+	}
+}
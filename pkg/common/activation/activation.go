@@ -0,0 +1,236 @@
+// Package activation provides waiting for activations of all subproviders: polling their status
+// with an exponential backoff, with consistent timeouts and diagnostics on failures
+package activation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/timeouts"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/logger"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/tracing"
+)
+
+const (
+	// DefaultMaxInterval caps the wait between polls of a poller without MaxInterval
+	DefaultMaxInterval = 5 * time.Minute
+
+	// Multiplier is the factor the wait grows by after each poll
+	Multiplier = 1.5
+
+	// Jitter is the fraction of the wait randomly added to it, so that activations started together do not poll together
+	Jitter = 0.1
+)
+
+// State is the state of an activation
+type State int
+
+const (
+	// StatePending is the state of an activation in progress
+	StatePending State = iota
+	// StateSucceeded is the state of a completed activation
+	StateSucceeded
+	// StateFailed is the state of an activation rejected or failed in the downstream systems
+	StateFailed
+	// StateAborted is the state of an activation aborted or canceled
+	StateAborted
+)
+
+// Status is the status of an activation
+type Status struct {
+	State State
+	// Value is the status reported by the API, e.g. PENDING
+	Value string
+	// Detail describes a failure, e.g. with the status message reported by the API
+	Detail string
+}
+
+// Pending returns the status of an activation in progress
+func Pending(value string) Status {
+	return Status{State: StatePending, Value: value}
+}
+
+// Succeeded returns the status of a completed activation
+func Succeeded(value string) Status {
+	return Status{State: StateSucceeded, Value: value}
+}
+
+// Failed returns the status of a failed activation
+func Failed(value, detail string) Status {
+	return Status{State: StateFailed, Value: value, Detail: detail}
+}
+
+// Aborted returns the status of an aborted activation
+func Aborted(value, detail string) Status {
+	return Status{State: StateAborted, Value: value, Detail: detail}
+}
+
+// PollFunc returns the current status of an activation
+type PollFunc func(ctx context.Context) (Status, error)
+
+// RetryFunc performs a request, e.g. creating an activation, and tells whether its error is worth retrying
+type RetryFunc func(ctx context.Context) (retry bool, err error)
+
+// Poller waits for activations, polling their status with an exponential backoff.
+// Operations without a deadline are limited to timeouts.SDKDefaultTimeout.
+type Poller struct {
+	// Name names the activation in logs, traces and errors, e.g. "property activation"
+	Name string
+	// Interval is the wait before the first poll
+	Interval time.Duration
+	// MaxInterval caps the wait between polls, DefaultMaxInterval when zero
+	MaxInterval time.Duration
+}
+
+// Wait waits until the activation with the given status succeeds, polling its status with poll.
+// It returns an *Error when the activation fails, is aborted or the context is done,
+// and errors of poll as they are.
+func (p Poller) Wait(ctx context.Context, status Status, poll PollFunc) error {
+	ctx, cancel := timeouts.WithDefault(ctx, timeouts.SDKDefaultTimeout)
+	defer cancel()
+	log := logger.FromContext(ctx, "activation", p.Name)
+
+	for iteration := 1; ; iteration++ {
+		switch status.State {
+		case StateSucceeded:
+			log.Debugf("%s completed with status %s", p.Name, status.Value)
+			return nil
+		case StateFailed:
+			return &Error{Name: p.Name, Status: status, Err: ErrFailed}
+		case StateAborted:
+			return &Error{Name: p.Name, Status: status, Err: ErrAborted}
+		}
+
+		wait := p.Backoff(iteration)
+		log.Debugf("%s is in status %s, polling again in %s", p.Name, status.Value, wait)
+		if err := sleep(ctx, wait); err != nil {
+			return &Error{Name: p.Name, Status: status, Err: contextErr(err), Cause: err}
+		}
+
+		pollCtx, span := tracing.StartPoll(ctx, "poll "+p.Name, iteration, wait)
+		next, err := poll(pollCtx)
+		if err != nil {
+			tracing.EndPoll(span, "", err)
+			return err
+		}
+		tracing.EndPoll(span, next.Value, nil)
+		status = next
+	}
+}
+
+// Retry calls f until it succeeds or returns an error which is not worth retrying, waiting with the backoff
+// between calls. It returns an *Error when the context is done before, with the last error of f as its cause.
+func (p Poller) Retry(ctx context.Context, f RetryFunc) error {
+	ctx, cancel := timeouts.WithDefault(ctx, timeouts.SDKDefaultTimeout)
+	defer cancel()
+	log := logger.FromContext(ctx, "activation", p.Name)
+
+	for iteration := 1; ; iteration++ {
+		retry, err := f(ctx)
+		if err == nil || !retry {
+			return err
+		}
+
+		wait := p.Backoff(iteration)
+		log.Debugf("%s failed, retrying in %s: %s", p.Name, wait, err)
+		if ctxErr := sleep(ctx, wait); ctxErr != nil {
+			return &Error{Name: p.Name, Err: contextErr(ctxErr), Cause: err}
+		}
+	}
+}
+
+// Backoff returns the wait before the given iteration, starting with 1
+func (p Poller) Backoff(iteration int) time.Duration {
+	maxInterval := p.MaxInterval
+	if maxInterval == 0 {
+		maxInterval = DefaultMaxInterval
+	}
+	if maxInterval < p.Interval {
+		maxInterval = p.Interval
+	}
+
+	wait := float64(p.Interval) * math.Pow(Multiplier, float64(iteration-1))
+	if wait > float64(maxInterval) {
+		wait = float64(maxInterval)
+	}
+	// the jitter is only added, so the wait is never below the interval
+	wait += wait * Jitter * rand.Float64()
+	return time.Duration(wait)
+}
+
+func sleep(ctx context.Context, wait time.Duration) error {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func contextErr(err error) error {
+	if errors.Is(err, context.Canceled) {
+		return ErrCanceled
+	}
+	return ErrTimeout
+}
+
+var (
+	// ErrFailed is returned when an activation fails
+	ErrFailed = errors.New("activation failed")
+	// ErrAborted is returned when an activation is aborted
+	ErrAborted = errors.New("activation aborted")
+	// ErrTimeout is returned when the operation times out while waiting for an activation
+	ErrTimeout = errors.New("activation timeout")
+	// ErrCanceled is returned when the operation is canceled while waiting for an activation
+	ErrCanceled = errors.New("activation canceled")
+)
+
+// Error is returned when waiting for an activation did not end with its success
+type Error struct {
+	// Name is the name of the poller
+	Name string
+	// Status is the last known status of the activation
+	Status Status
+	// Err is one of ErrFailed, ErrAborted, ErrTimeout and ErrCanceled
+	Err error
+	// Cause is the error of the context, or the last error of a retried request
+	Cause error
+}
+
+func (e *Error) Error() string {
+	var msg string
+	switch e.Err {
+	case ErrFailed:
+		msg = fmt.Sprintf("%s failed with status %s", e.Name, e.Status.Value)
+	case ErrAborted:
+		msg = fmt.Sprintf("%s aborted with status %s", e.Name, e.Status.Value)
+	case ErrCanceled:
+		msg = fmt.Sprintf("operation canceled while waiting for %s", e.Name)
+	default:
+		msg = fmt.Sprintf("timeout waiting for %s", e.Name)
+	}
+	if (e.Err == ErrCanceled || e.Err == ErrTimeout) && e.Status.Value != "" {
+		msg += " in status " + e.Status.Value
+	}
+	if e.Status.Detail != "" {
+		msg += ": " + e.Status.Detail
+	}
+	if e.Cause != nil {
+		msg += ": " + e.Cause.Error()
+	}
+	return msg
+}
+
+// Unwrap returns the sentinel error and the cause
+func (e *Error) Unwrap() []error {
+	if e.Cause == nil {
+		return []error{e.Err}
+	}
+	return []error{e.Err, e.Cause}
+}
@@ -0,0 +1,156 @@
+package activation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackoff(t *testing.T) {
+	p := Poller{Interval: time.Second, MaxInterval: 3 * time.Second}
+	for iteration, expected := range map[int]time.Duration{
+		1:  time.Second,
+		2:  1500 * time.Millisecond,
+		3:  2250 * time.Millisecond,
+		4:  3 * time.Second,
+		10: 3 * time.Second,
+	} {
+		wait := p.Backoff(iteration)
+		assert.GreaterOrEqual(t, wait, expected, "iteration %d", iteration)
+		assert.LessOrEqual(t, wait, expected+time.Duration(float64(expected)*Jitter), "iteration %d", iteration)
+	}
+
+	assert.GreaterOrEqual(t, Poller{Interval: 10 * time.Minute}.Backoff(5), 10*time.Minute, "interval above the default max")
+}
+
+func TestWait(t *testing.T) {
+	p := Poller{Name: "test activation", Interval: time.Microsecond}
+	pollError := errors.New("oops")
+
+	tests := map[string]struct {
+		status    Status
+		polls     []Status
+		pollError error
+		withError string
+		errorIs   error
+		calls     int
+	}{
+		"already active": {
+			status: Succeeded("ACTIVE"),
+		},
+		"active after polls": {
+			status: Pending("PENDING"),
+			polls:  []Status{Pending("PENDING"), Succeeded("ACTIVE")},
+			calls:  2,
+		},
+		"failed": {
+			status:    Pending("PENDING"),
+			polls:     []Status{Failed("FAILED", "invalid hostname")},
+			withError: "test activation failed with status FAILED: invalid hostname",
+			errorIs:   ErrFailed,
+			calls:     1,
+		},
+		"aborted": {
+			status:    Aborted("ABORTED", ""),
+			withError: "test activation aborted with status ABORTED",
+			errorIs:   ErrAborted,
+		},
+		"poll error": {
+			status:    Pending("PENDING"),
+			pollError: pollError,
+			withError: "oops",
+			errorIs:   pollError,
+			calls:     1,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			err := p.Wait(context.Background(), test.status, func(context.Context) (Status, error) {
+				calls++
+				if test.pollError != nil {
+					return Status{}, test.pollError
+				}
+				return test.polls[calls-1], nil
+			})
+			assert.Equal(t, test.calls, calls)
+			if test.withError == "" {
+				require.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, test.errorIs)
+			assert.EqualError(t, err, test.withError)
+		})
+	}
+}
+
+func TestWaitTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := Poller{Name: "test activation", Interval: time.Millisecond}.Wait(ctx, Pending("PENDING"),
+		func(context.Context) (Status, error) {
+			return Pending("PENDING"), nil
+		})
+	assert.ErrorIs(t, err, ErrTimeout)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.EqualError(t, err, "timeout waiting for test activation in status PENDING: context deadline exceeded")
+
+	diags := Diagnostics(err)
+	require.Len(t, diags, 1)
+	assert.Equal(t, "Timeout waiting for activation status", diags[0].Summary)
+	assert.Contains(t, diags[0].Detail, "The test activation request has been started successfully")
+}
+
+func TestWaitCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := Poller{Name: "test activation", Interval: time.Millisecond}.Wait(ctx, Pending("PENDING"),
+		func(context.Context) (Status, error) {
+			return Pending("PENDING"), nil
+		})
+	assert.ErrorIs(t, err, ErrCanceled)
+	summary, _ := Describe(err)
+	assert.Equal(t, "Operation canceled while waiting for activation status", summary)
+}
+
+func TestRetry(t *testing.T) {
+	p := Poller{Name: "test activation creation", Interval: time.Microsecond}
+	errRetryable, errFatal := errors.New("conflict"), errors.New("bad request")
+
+	t.Run("succeeds after retries", func(t *testing.T) {
+		calls := 0
+		err := p.Retry(context.Background(), func(context.Context) (bool, error) {
+			if calls++; calls < 3 {
+				return true, errRetryable
+			}
+			return false, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("not retryable error", func(t *testing.T) {
+		err := p.Retry(context.Background(), func(context.Context) (bool, error) {
+			return false, errFatal
+		})
+		assert.Equal(t, errFatal, err)
+	})
+
+	t.Run("timeout with the last error", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		err := Poller{Name: "test activation creation", Interval: time.Millisecond}.Retry(ctx,
+			func(context.Context) (bool, error) {
+				return true, errRetryable
+			})
+		assert.ErrorIs(t, err, ErrTimeout)
+		assert.ErrorIs(t, err, errRetryable)
+		assert.EqualError(t, err, "timeout waiting for test activation creation: conflict")
+	})
+}
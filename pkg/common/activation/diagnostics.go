@@ -0,0 +1,55 @@
+package activation
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+const (
+	timeoutSummary = "Timeout waiting for activation status"
+
+	canceledSummary = "Operation canceled while waiting for activation status"
+
+	timeoutDetail = `
+The %s request has been started successfully, however the operation timeout was
+exceeded while waiting for the remote resource to update. You may retry the operation to continue
+to wait for the final status.
+
+It is recommended that the timeout for activation resources be set to greater than 90 minutes.
+See: https://www.terraform.io/docs/configuration/resources.html#operation-timeouts
+`
+
+	canceledDetail = `
+The %s request has been started successfully, however a cancellation was received
+while waiting for the remote resource to update. You may retry the operation to continue to wait for
+the final status.
+`
+)
+
+// Describe returns the summary and the detail of the diagnostic reporting the error of waiting for an activation,
+// the same for activations of all subproviders
+func Describe(err error) (string, string) {
+	var activationErr *Error
+	if !errors.As(err, &activationErr) {
+		return err.Error(), ""
+	}
+	switch activationErr.Err {
+	case ErrTimeout:
+		return timeoutSummary, fmt.Sprintf(timeoutDetail, activationErr.Name)
+	case ErrCanceled:
+		return canceledSummary, fmt.Sprintf(canceledDetail, activationErr.Name)
+	}
+	return err.Error(), ""
+}
+
+// Diagnostics returns the SDK diagnostics reporting the error of waiting for an activation
+func Diagnostics(err error) diag.Diagnostics {
+	summary, detail := Describe(err)
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  summary,
+		Detail:   detail,
+	}}
+}
@@ -0,0 +1,127 @@
+package activation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/logger"
+)
+
+// Limits limits the number of activations submitted and awaited at the same time by this process,
+// regardless of the subprovider they belong to
+type Limits struct {
+	// Total is the maximum number of concurrent activations on all networks, 0 for no limit
+	Total int
+	// PerNetwork is the maximum number of concurrent activations on each network, 0 for no limit
+	PerNetwork int
+}
+
+var (
+	// ErrLimits is returned when the limits of concurrent activations are not valid
+	ErrLimits = errors.New("concurrent activations limits")
+	// ErrQueued is returned when the operation ends while waiting for a free slot to submit an activation
+	ErrQueued = errors.New("activation queued until the end of the operation")
+)
+
+var global = struct {
+	mu       sync.Mutex
+	limits   Limits
+	total    chan struct{}
+	networks map[string]chan struct{}
+}{}
+
+// Configure sets the limits of concurrent activations of the process. Configuring the same limits again
+// is a no-op, so that both providers served by the plugin can configure them. Activations holding
+// a slot when the limits change release it to the limits they acquired it from.
+func Configure(limits Limits) error {
+	if limits.Total < 0 || limits.PerNetwork < 0 {
+		return fmt.Errorf("%w: maximum numbers of concurrent activations (%d) and concurrent activations per network (%d) cannot be negative",
+			ErrLimits, limits.Total, limits.PerNetwork)
+	}
+
+	global.mu.Lock()
+	defer global.mu.Unlock()
+
+	if limits == global.limits {
+		return nil
+	}
+	global.limits = limits
+	global.total = newSemaphore(limits.Total)
+	global.networks = make(map[string]chan struct{})
+	return nil
+}
+
+// Acquire waits for a free slot to submit an activation on the given network, e.g. STAGING, and await it.
+// Activations of resources without networks pass an empty network and are limited by the total only.
+// The returned function releases the slot and has to be called once the activation is done.
+// It returns ErrQueued when the context is done while waiting, so the time waiting counts toward the timeout of the operation.
+func Acquire(ctx context.Context, name, network string) (func(), error) {
+	network = normalizeNetwork(network)
+	total, perNetwork := semaphores(network)
+	if total == nil && perNetwork == nil {
+		return func() {}, nil
+	}
+
+	log := logger.FromContext(ctx, "activation", name, "network", network)
+	start := time.Now()
+	var acquired []chan struct{}
+	release := func() {
+		for _, sem := range acquired {
+			<-sem
+		}
+	}
+	// the per network slot is acquired first, so that activations waiting for a busy network do not hold total slots
+	for _, sem := range []chan struct{}{perNetwork, total} {
+		if sem == nil {
+			continue
+		}
+		select {
+		case sem <- struct{}{}:
+			acquired = append(acquired, sem)
+			continue
+		default:
+		}
+
+		log.Infof("%s is queued, %d of %d concurrent activations are in progress", name, len(sem), cap(sem))
+		select {
+		case sem <- struct{}{}:
+			acquired = append(acquired, sem)
+		case <-ctx.Done():
+			release()
+			return nil, fmt.Errorf("%w: %s was not submitted: %w", ErrQueued, name, ctx.Err())
+		}
+	}
+	log.Infof("%s waited %s for a free slot", name, time.Since(start).Round(time.Millisecond))
+	return release, nil
+}
+
+func semaphores(network string) (total, perNetwork chan struct{}) {
+	global.mu.Lock()
+	defer global.mu.Unlock()
+
+	if network != "" && global.limits.PerNetwork > 0 {
+		perNetwork = global.networks[network]
+		if perNetwork == nil {
+			perNetwork = newSemaphore(global.limits.PerNetwork)
+			global.networks[network] = perNetwork
+		}
+	}
+	return global.total, perNetwork
+}
+
+func newSemaphore(size int) chan struct{} {
+	if size == 0 {
+		return nil
+	}
+	return make(chan struct{}, size)
+}
+
+// normalizeNetwork returns the network as named by most APIs, so that e.g. prod and PRODUCTION share slots
+func normalizeNetwork(network string) string {
+	return strings.ToUpper(tf.StateNetwork(network))
+}
@@ -0,0 +1,75 @@
+package activation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigure(t *testing.T) {
+	defer func() { require.NoError(t, Configure(Limits{})) }()
+
+	assert.ErrorIs(t, Configure(Limits{Total: -1}), ErrLimits)
+	require.NoError(t, Configure(Limits{Total: 2, PerNetwork: 1}))
+	total := global.total
+	require.NoError(t, Configure(Limits{Total: 2, PerNetwork: 1}))
+	assert.True(t, total == global.total, "the same limits keep the semaphores")
+}
+
+func TestAcquire(t *testing.T) {
+	defer func() { require.NoError(t, Configure(Limits{})) }()
+
+	t.Run("no limits", func(t *testing.T) {
+		require.NoError(t, Configure(Limits{}))
+		release, err := Acquire(context.Background(), "test activation", "STAGING")
+		require.NoError(t, err)
+		release()
+	})
+
+	t.Run("per network", func(t *testing.T) {
+		require.NoError(t, Configure(Limits{PerNetwork: 1}))
+		release, err := Acquire(context.Background(), "test activation", "staging")
+		require.NoError(t, err)
+
+		releaseProduction, err := Acquire(context.Background(), "test activation", "PROD")
+		require.NoError(t, err, "other network is not limited")
+		releaseProduction()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err = Acquire(ctx, "test activation", "STAGING")
+		assert.ErrorIs(t, err, ErrQueued)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+		release()
+		release, err = Acquire(context.Background(), "test activation", "STAGING")
+		require.NoError(t, err)
+		release()
+	})
+
+	t.Run("total", func(t *testing.T) {
+		require.NoError(t, Configure(Limits{Total: 1, PerNetwork: 1}))
+		release, err := Acquire(context.Background(), "test activation", "")
+		require.NoError(t, err)
+
+		acquired := make(chan func())
+		go func() {
+			r, err := Acquire(context.Background(), "test activation", "PRODUCTION")
+			assert.NoError(t, err)
+			acquired <- r
+		}()
+		select {
+		case <-acquired:
+			t.Fatal("acquired over the total limit")
+		case <-time.After(10 * time.Millisecond):
+		}
+
+		release()
+		(<-acquired)()
+		assert.Empty(t, global.total)
+		assert.Empty(t, global.networks["PRODUCTION"])
+	})
+}
@@ -0,0 +1,186 @@
+package schemasnapshot
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Change is a difference between two snapshots
+type Change struct {
+	// Path is the address of the changed resource, data source, attribute or block, e.g. data.akamai_property.rules
+	Path string
+	// Breaking is set when configurations or modules valid for the old schema may fail or plan differently with the new one
+	Breaking bool
+	// Message describes the change
+	Message string
+}
+
+// String returns the change in a form suitable for test failures
+func (c Change) String() string {
+	kind := "non-breaking"
+	if c.Breaking {
+		kind = "breaking"
+	}
+	return fmt.Sprintf("%s: %s (%s)", c.Path, c.Message, kind)
+}
+
+// Compare returns the changes of the schemas from old to new, sorted by path.
+//
+// Breaking changes are removals of resources, data sources, attributes and blocks, new required attributes and blocks,
+// optional attributes becoming required or not configurable, attributes no longer computed, changed types and nesting modes,
+// narrowed numbers of blocks, and attributes or blocks which become sensitive or force replacement.
+func Compare(old, new *Snapshot) []Change {
+	var c changes
+	c.schemas("", old.Resources, new.Resources)
+	c.schemas("data.", old.DataSources, new.DataSources)
+	sort.SliceStable(c, func(i, j int) bool {
+		return c[i].Path < c[j].Path
+	})
+	return c
+}
+
+type changes []Change
+
+func (c *changes) add(path string, breaking bool, format string, args ...any) {
+	*c = append(*c, Change{Path: path, Breaking: breaking, Message: fmt.Sprintf(format, args...)})
+}
+
+func (c *changes) schemas(prefix string, old, new map[string]*Block) {
+	for name, oldBlock := range old {
+		newBlock, ok := new[name]
+		if !ok {
+			c.add(prefix+name, true, "removed")
+			continue
+		}
+		c.block(prefix+name, oldBlock, newBlock)
+	}
+	for name := range new {
+		if _, ok := old[name]; !ok {
+			c.add(prefix+name, false, "added")
+		}
+	}
+}
+
+func (c *changes) block(path string, old, new *Block) {
+	for name := range old.Attributes {
+		if _, ok := new.Blocks[name]; ok {
+			c.add(path+"."+name, true, "changed from an attribute to a block")
+		}
+	}
+	for name, oldBlock := range old.Blocks {
+		if _, ok := new.Attributes[name]; ok {
+			c.add(path+"."+name, true, "changed from a block to an attribute")
+			continue
+		}
+		newBlock, ok := new.Blocks[name]
+		if !ok {
+			c.add(path+"."+name, true, "block removed")
+			continue
+		}
+		c.nestedBlock(path+"."+name, oldBlock, newBlock)
+	}
+	for name, newBlock := range new.Blocks {
+		if _, ok := old.Blocks[name]; ok {
+			continue
+		}
+		if _, ok := old.Attributes[name]; ok {
+			continue
+		}
+		if newBlock.MinItems > 0 {
+			c.add(path+"."+name, true, "required block added")
+		} else {
+			c.add(path+"."+name, false, "block added")
+		}
+	}
+	c.attributes(path, old.Attributes, new.Attributes, new.Blocks)
+}
+
+func (c *changes) attributes(path string, old, new map[string]*Attribute, newBlocks map[string]*NestedBlock) {
+	for name, oldAttr := range old {
+		newAttr, ok := new[name]
+		if !ok {
+			if _, ok := newBlocks[name]; !ok {
+				c.add(path+"."+name, true, "attribute removed")
+			}
+			continue
+		}
+		c.attribute(path+"."+name, oldAttr, newAttr)
+	}
+	for name, newAttr := range new {
+		if _, ok := old[name]; ok {
+			continue
+		}
+		if newAttr.Required {
+			c.add(path+"."+name, true, "required attribute added")
+		} else {
+			c.add(path+"."+name, false, "attribute added")
+		}
+	}
+}
+
+func (c *changes) nestedBlock(path string, old, new *NestedBlock) {
+	if old.Nesting != new.Nesting {
+		c.add(path, true, "nesting changed from %s to %s", old.Nesting, new.Nesting)
+	}
+	if new.MinItems > old.MinItems {
+		c.add(path, true, "minimum number of blocks increased from %d to %d", old.MinItems, new.MinItems)
+	} else if new.MinItems < old.MinItems {
+		c.add(path, false, "minimum number of blocks decreased from %d to %d", old.MinItems, new.MinItems)
+	}
+	if new.MaxItems > 0 && (old.MaxItems == 0 || new.MaxItems < old.MaxItems) {
+		c.add(path, true, "maximum number of blocks decreased to %d", new.MaxItems)
+	} else if old.MaxItems > 0 && (new.MaxItems == 0 || new.MaxItems > old.MaxItems) {
+		c.add(path, false, "maximum number of blocks increased from %d", old.MaxItems)
+	}
+	c.forceNew(path, old.ForceNew, new.ForceNew)
+	c.block(path, &old.Block, &new.Block)
+}
+
+func (c *changes) attribute(path string, old, new *Attribute) {
+	switch {
+	case old.NestedType != nil && new.NestedType != nil:
+		if old.NestedType.Nesting != new.NestedType.Nesting {
+			c.add(path, true, "nesting changed from %s to %s", old.NestedType.Nesting, new.NestedType.Nesting)
+		}
+		c.attributes(path, old.NestedType.Attributes, new.NestedType.Attributes, nil)
+	case old.NestedType != nil || new.NestedType != nil:
+		c.add(path, true, "changed between a type and nested attributes")
+	case old.Type != new.Type:
+		c.add(path, true, "type changed from %s to %s", old.Type, new.Type)
+	}
+
+	wasConfigurable, isConfigurable := old.Required || old.Optional, new.Required || new.Optional
+	switch {
+	case wasConfigurable && !isConfigurable:
+		c.add(path, true, "can no longer be configured")
+	case !old.Required && new.Required:
+		c.add(path, true, "became required")
+	case old.Required && !new.Required:
+		c.add(path, false, "became optional")
+	case !wasConfigurable && isConfigurable:
+		c.add(path, false, "can be configured")
+	}
+	if old.Computed && !new.Computed && isConfigurable {
+		c.add(path, true, "is no longer computed")
+	} else if !old.Computed && new.Computed {
+		c.add(path, false, "became computed")
+	}
+
+	if !old.Sensitive && new.Sensitive {
+		c.add(path, true, "became sensitive")
+	} else if old.Sensitive && !new.Sensitive {
+		c.add(path, false, "is no longer sensitive")
+	}
+	if !old.Deprecated && new.Deprecated {
+		c.add(path, false, "deprecated")
+	}
+	c.forceNew(path, old.ForceNew, new.ForceNew)
+}
+
+func (c *changes) forceNew(path string, old, new bool) {
+	if !old && new {
+		c.add(path, true, "forces replacement")
+	} else if old && !new {
+		c.add(path, false, "no longer forces replacement")
+	}
+}
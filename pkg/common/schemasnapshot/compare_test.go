@@ -0,0 +1,170 @@
+package schemasnapshot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompare(t *testing.T) {
+	resource := func(attributes map[string]*Attribute, blocks map[string]*NestedBlock) *Snapshot {
+		return &Snapshot{Resources: map[string]*Block{"akamai_test": {Attributes: attributes, Blocks: blocks}}}
+	}
+	attribute := func(attr Attribute) *Snapshot {
+		return resource(map[string]*Attribute{"name": &attr}, nil)
+	}
+	block := func(b NestedBlock) *Snapshot {
+		return resource(nil, map[string]*NestedBlock{"rule": &b})
+	}
+
+	tests := map[string]struct {
+		old, new *Snapshot
+		expected []Change
+	}{
+		"no changes": {
+			old: attribute(Attribute{Type: "string", Optional: true}),
+			new: attribute(Attribute{Type: "string", Optional: true}),
+		},
+		"resource and data source removed and added": {
+			old: &Snapshot{
+				Resources:   map[string]*Block{"akamai_old": {}},
+				DataSources: map[string]*Block{"akamai_old": {}},
+			},
+			new: &Snapshot{
+				Resources:   map[string]*Block{"akamai_new": {}},
+				DataSources: map[string]*Block{"akamai_new": {}},
+			},
+			expected: []Change{
+				{Path: "akamai_new", Message: "added"},
+				{Path: "akamai_old", Breaking: true, Message: "removed"},
+				{Path: "data.akamai_new", Message: "added"},
+				{Path: "data.akamai_old", Breaking: true, Message: "removed"},
+			},
+		},
+		"attributes removed and added": {
+			old: resource(map[string]*Attribute{"old": {Type: "string", Optional: true}}, nil),
+			new: resource(map[string]*Attribute{
+				"computed": {Type: "string", Computed: true},
+				"required": {Type: "string", Required: true},
+			}, nil),
+			expected: []Change{
+				{Path: "akamai_test.computed", Message: "attribute added"},
+				{Path: "akamai_test.old", Breaking: true, Message: "attribute removed"},
+				{Path: "akamai_test.required", Breaking: true, Message: "required attribute added"},
+			},
+		},
+		"optional to required": {
+			old:      attribute(Attribute{Type: "string", Optional: true}),
+			new:      attribute(Attribute{Type: "string", Required: true}),
+			expected: []Change{{Path: "akamai_test.name", Breaking: true, Message: "became required"}},
+		},
+		"required to optional": {
+			old:      attribute(Attribute{Type: "string", Required: true}),
+			new:      attribute(Attribute{Type: "string", Optional: true}),
+			expected: []Change{{Path: "akamai_test.name", Message: "became optional"}},
+		},
+		"optional to computed": {
+			old:      attribute(Attribute{Type: "string", Optional: true, Computed: true}),
+			new:      attribute(Attribute{Type: "string", Computed: true}),
+			expected: []Change{{Path: "akamai_test.name", Breaking: true, Message: "can no longer be configured"}},
+		},
+		"computed to optional": {
+			old:      attribute(Attribute{Type: "string", Computed: true}),
+			new:      attribute(Attribute{Type: "string", Optional: true, Computed: true}),
+			expected: []Change{{Path: "akamai_test.name", Message: "can be configured"}},
+		},
+		"no longer computed": {
+			old:      attribute(Attribute{Type: "string", Optional: true, Computed: true}),
+			new:      attribute(Attribute{Type: "string", Optional: true}),
+			expected: []Change{{Path: "akamai_test.name", Breaking: true, Message: "is no longer computed"}},
+		},
+		"type change": {
+			old:      attribute(Attribute{Type: "number", Optional: true}),
+			new:      attribute(Attribute{Type: "string", Optional: true}),
+			expected: []Change{{Path: "akamai_test.name", Breaking: true, Message: "type changed from number to string"}},
+		},
+		"force new added": {
+			old:      attribute(Attribute{Type: "string", Required: true}),
+			new:      attribute(Attribute{Type: "string", Required: true, ForceNew: true}),
+			expected: []Change{{Path: "akamai_test.name", Breaking: true, Message: "forces replacement"}},
+		},
+		"force new removed": {
+			old:      attribute(Attribute{Type: "string", Required: true, ForceNew: true}),
+			new:      attribute(Attribute{Type: "string", Required: true}),
+			expected: []Change{{Path: "akamai_test.name", Message: "no longer forces replacement"}},
+		},
+		"sensitive and deprecated": {
+			old: attribute(Attribute{Type: "string", Optional: true}),
+			new: attribute(Attribute{Type: "string", Optional: true, Sensitive: true, Deprecated: true}),
+			expected: []Change{
+				{Path: "akamai_test.name", Breaking: true, Message: "became sensitive"},
+				{Path: "akamai_test.name", Message: "deprecated"},
+			},
+		},
+		"nested attributes": {
+			old: attribute(Attribute{Optional: true, NestedType: &NestedAttributes{Nesting: "list", Attributes: map[string]*Attribute{
+				"hostname": {Type: "string", Optional: true},
+			}}}),
+			new: attribute(Attribute{Optional: true, NestedType: &NestedAttributes{Nesting: "set", Attributes: map[string]*Attribute{
+				"hostname": {Type: "string", Required: true},
+			}}}),
+			expected: []Change{
+				{Path: "akamai_test.name", Breaking: true, Message: "nesting changed from list to set"},
+				{Path: "akamai_test.name.hostname", Breaking: true, Message: "became required"},
+			},
+		},
+		"nested attributes instead of a type": {
+			old:      attribute(Attribute{Type: "list(object({hostname=string}))", Optional: true}),
+			new:      attribute(Attribute{Optional: true, NestedType: &NestedAttributes{Nesting: "list", Attributes: map[string]*Attribute{}}}),
+			expected: []Change{{Path: "akamai_test.name", Breaking: true, Message: "changed between a type and nested attributes"}},
+		},
+		"block instead of an attribute": {
+			old:      resource(map[string]*Attribute{"rule": {Type: "list(string)", Optional: true}}, nil),
+			new:      block(NestedBlock{Nesting: "list"}),
+			expected: []Change{{Path: "akamai_test.rule", Breaking: true, Message: "changed from an attribute to a block"}},
+		},
+		"blocks removed and added": {
+			old: resource(nil, map[string]*NestedBlock{"old": {Nesting: "list"}}),
+			new: resource(nil, map[string]*NestedBlock{
+				"optional": {Nesting: "list"},
+				"required": {Nesting: "list", MinItems: 1},
+			}),
+			expected: []Change{
+				{Path: "akamai_test.old", Breaking: true, Message: "block removed"},
+				{Path: "akamai_test.optional", Message: "block added"},
+				{Path: "akamai_test.required", Breaking: true, Message: "required block added"},
+			},
+		},
+		"block limits narrowed": {
+			old: block(NestedBlock{Nesting: "list"}),
+			new: block(NestedBlock{Nesting: "set", MinItems: 1, MaxItems: 1, ForceNew: true}),
+			expected: []Change{
+				{Path: "akamai_test.rule", Breaking: true, Message: "nesting changed from list to set"},
+				{Path: "akamai_test.rule", Breaking: true, Message: "minimum number of blocks increased from 0 to 1"},
+				{Path: "akamai_test.rule", Breaking: true, Message: "maximum number of blocks decreased to 1"},
+				{Path: "akamai_test.rule", Breaking: true, Message: "forces replacement"},
+			},
+		},
+		"block limits widened": {
+			old: block(NestedBlock{Nesting: "list", MinItems: 1, MaxItems: 1}),
+			new: block(NestedBlock{Nesting: "list"}),
+			expected: []Change{
+				{Path: "akamai_test.rule", Message: "minimum number of blocks decreased from 1 to 0"},
+				{Path: "akamai_test.rule", Message: "maximum number of blocks increased from 1"},
+			},
+		},
+		"attribute of a block": {
+			old: block(NestedBlock{Nesting: "list", Block: Block{Attributes: map[string]*Attribute{"name": {Type: "string", Optional: true}}}}),
+			new: block(NestedBlock{Nesting: "list"}),
+			expected: []Change{
+				{Path: "akamai_test.rule.name", Breaking: true, Message: "attribute removed"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, Compare(test.old, test.new))
+		})
+	}
+}
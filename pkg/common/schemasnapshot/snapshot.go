@@ -0,0 +1,370 @@
+// Package schemasnapshot serializes the schemas of the resources and data sources of the provider into a canonical
+// JSON snapshot, and classifies differences between snapshots as breaking or non-breaking for configurations using them.
+package schemasnapshot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
+	sdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type (
+	// Snapshot holds the schemas of the resources and data sources of the provider by their type names
+	Snapshot struct {
+		Resources   map[string]*Block `json:"resources"`
+		DataSources map[string]*Block `json:"data_sources"`
+	}
+
+	// Block is a schema or a nested block of it
+	Block struct {
+		Attributes map[string]*Attribute   `json:"attributes,omitempty"`
+		Blocks     map[string]*NestedBlock `json:"blocks,omitempty"`
+	}
+
+	// NestedBlock is a block nested in another one
+	NestedBlock struct {
+		Block
+		Nesting  string `json:"nesting"`
+		MinItems int64  `json:"min_items,omitempty"`
+		MaxItems int64  `json:"max_items,omitempty"`
+		ForceNew bool   `json:"force_new,omitempty"`
+	}
+
+	// Attribute is an attribute of a block. Either its type or its nested attributes are set.
+	Attribute struct {
+		Type       string            `json:"type,omitempty"`
+		NestedType *NestedAttributes `json:"nested_type,omitempty"`
+		Required   bool              `json:"required,omitempty"`
+		Optional   bool              `json:"optional,omitempty"`
+		Computed   bool              `json:"computed,omitempty"`
+		Sensitive  bool              `json:"sensitive,omitempty"`
+		Deprecated bool              `json:"deprecated,omitempty"`
+		// ForceNew is set when changing the attribute replaces the resource, also conditionally
+		ForceNew bool `json:"force_new,omitempty"`
+	}
+
+	// NestedAttributes are the attributes of an attribute with a nested type
+	NestedAttributes struct {
+		Nesting    string                `json:"nesting"`
+		Attributes map[string]*Attribute `json:"attributes"`
+	}
+)
+
+// New returns the snapshot of the resources and data sources of the SDK and framework providers, as served to Terraform.
+// The replacement of framework resources is only known for attributes having one of the RequiresReplace plan modifiers,
+// not for the ones which are replaced by the ModifyPlan method of the resource.
+func New(ctx context.Context, sdkProvider *sdkschema.Provider, frameworkProvider provider.Provider) (*Snapshot, error) {
+	sdkServer, err := tf5to6server.UpgradeServer(ctx, sdkProvider.GRPCProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &Snapshot{Resources: make(map[string]*Block), DataSources: make(map[string]*Block)}
+	for _, server := range []tfprotov6.ProviderServer{sdkServer, providerserver.NewProtocol6(frameworkProvider)()} {
+		resp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+		if err != nil {
+			return nil, err
+		}
+		if err = diagnosticsError(resp.Diagnostics); err != nil {
+			return nil, err
+		}
+		for name, s := range resp.ResourceSchemas {
+			snapshot.Resources[name] = newBlock(s.Block)
+		}
+		for name, s := range resp.DataSourceSchemas {
+			snapshot.DataSources[name] = newBlock(s.Block)
+		}
+	}
+
+	for name, r := range sdkProvider.ResourcesMap {
+		if block, ok := snapshot.Resources[name]; ok {
+			markSDKForceNew(block, r.SchemaMap())
+		}
+	}
+
+	var metadata provider.MetadataResponse
+	frameworkProvider.Metadata(ctx, provider.MetadataRequest{}, &metadata)
+	for _, newResource := range frameworkProvider.Resources(ctx) {
+		r := newResource()
+		var resourceMetadata resource.MetadataResponse
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: metadata.TypeName}, &resourceMetadata)
+		var resp resource.SchemaResponse
+		r.Schema(ctx, resource.SchemaRequest{}, &resp)
+		if block, ok := snapshot.Resources[resourceMetadata.TypeName]; ok {
+			markFrameworkForceNew(block, resp.Schema.Attributes, resp.Schema.Blocks)
+		}
+	}
+
+	return snapshot, nil
+}
+
+// ReadFile reads the snapshot written to path by WriteFile
+func ReadFile(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var snapshot Snapshot
+	if err = json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("reading schema snapshot %s: %w", path, err)
+	}
+	return &snapshot, nil
+}
+
+// WriteFile writes the snapshot to path as indented JSON with sorted keys, so that it can be committed and diffed.
+// Objects holding only values other than objects and arrays, like most attributes, are written on a single line.
+func (s *Snapshot) WriteFile(path string) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	var v any
+	if err = json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err = writeValue(&buf, v, ""); err != nil {
+		return err
+	}
+	buf.WriteByte('\n')
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
+
+func writeValue(buf *bytes.Buffer, v any, indent string) error {
+	object, ok := v.(map[string]any)
+	if !ok {
+		data, err := json.Marshal(v)
+		buf.Write(data)
+		return err
+	}
+
+	names := make([]string, 0, len(object))
+	flat := true
+	for name, value := range object {
+		names = append(names, name)
+		switch value.(type) {
+		case map[string]any, []any:
+			flat = false
+		}
+	}
+	sort.Strings(names)
+	if len(names) == 0 {
+		buf.WriteString("{}")
+		return nil
+	}
+
+	separator, nested, closing := " ", indent, " "
+	if !flat {
+		separator, nested, closing = "\n"+indent+"  ", indent+"  ", "\n"+indent
+	}
+	buf.WriteByte('{')
+	for i, name := range names {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString(separator)
+		key, err := json.Marshal(name)
+		if err != nil {
+			return err
+		}
+		buf.Write(key)
+		buf.WriteString(": ")
+		if err = writeValue(buf, object[name], nested); err != nil {
+			return err
+		}
+	}
+	buf.WriteString(closing)
+	buf.WriteByte('}')
+	return nil
+}
+
+func newBlock(b *tfprotov6.SchemaBlock) *Block {
+	block := &Block{}
+	if len(b.Attributes) > 0 {
+		block.Attributes = make(map[string]*Attribute, len(b.Attributes))
+		for _, attr := range b.Attributes {
+			block.Attributes[attr.Name] = newAttribute(attr)
+		}
+	}
+	if len(b.BlockTypes) > 0 {
+		block.Blocks = make(map[string]*NestedBlock, len(b.BlockTypes))
+		for _, nested := range b.BlockTypes {
+			block.Blocks[nested.TypeName] = &NestedBlock{
+				Block:    *newBlock(nested.Block),
+				Nesting:  strings.ToLower(nested.Nesting.String()),
+				MinItems: nested.MinItems,
+				MaxItems: nested.MaxItems,
+			}
+		}
+	}
+	return block
+}
+
+func newAttribute(a *tfprotov6.SchemaAttribute) *Attribute {
+	attr := &Attribute{
+		Required:   a.Required,
+		Optional:   a.Optional,
+		Computed:   a.Computed,
+		Sensitive:  a.Sensitive,
+		Deprecated: a.Deprecated,
+	}
+	if a.NestedType != nil {
+		attr.NestedType = &NestedAttributes{
+			Nesting:    strings.ToLower(a.NestedType.Nesting.String()),
+			Attributes: make(map[string]*Attribute, len(a.NestedType.Attributes)),
+		}
+		for _, nested := range a.NestedType.Attributes {
+			attr.NestedType.Attributes[nested.Name] = newAttribute(nested)
+		}
+	} else {
+		attr.Type = typeString(a.Type)
+	}
+	return attr
+}
+
+// typeString returns the type in the syntax of type constraints of Terraform, e.g. list(object({name=string}))
+func typeString(t tftypes.Type) string {
+	switch t := t.(type) {
+	case tftypes.List:
+		return "list(" + typeString(t.ElementType) + ")"
+	case tftypes.Set:
+		return "set(" + typeString(t.ElementType) + ")"
+	case tftypes.Map:
+		return "map(" + typeString(t.ElementType) + ")"
+	case tftypes.Tuple:
+		elements := make([]string, 0, len(t.ElementTypes))
+		for _, element := range t.ElementTypes {
+			elements = append(elements, typeString(element))
+		}
+		return "tuple([" + strings.Join(elements, ", ") + "])"
+	case tftypes.Object:
+		names := make([]string, 0, len(t.AttributeTypes))
+		for name := range t.AttributeTypes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		attributes := make([]string, 0, len(names))
+		for _, name := range names {
+			attrType := typeString(t.AttributeTypes[name])
+			if _, ok := t.OptionalAttributes[name]; ok {
+				attrType = "optional(" + attrType + ")"
+			}
+			attributes = append(attributes, name+"="+attrType)
+		}
+		return "object({" + strings.Join(attributes, ", ") + "})"
+	}
+
+	switch {
+	case t.Is(tftypes.String):
+		return "string"
+	case t.Is(tftypes.Number):
+		return "number"
+	case t.Is(tftypes.Bool):
+		return "bool"
+	case t.Is(tftypes.DynamicPseudoType):
+		return "any"
+	}
+	return t.String()
+}
+
+func markSDKForceNew(block *Block, schemas map[string]*sdkschema.Schema) {
+	for name, s := range schemas {
+		if attr, ok := block.Attributes[name]; ok {
+			attr.ForceNew = s.ForceNew
+			continue
+		}
+		if nested, ok := block.Blocks[name]; ok {
+			nested.ForceNew = s.ForceNew
+			if elem, ok := s.Elem.(*sdkschema.Resource); ok {
+				markSDKForceNew(&nested.Block, elem.SchemaMap())
+			}
+		}
+	}
+}
+
+func markFrameworkForceNew(block *Block, attributes map[string]schema.Attribute, blocks map[string]schema.Block) {
+	for name, a := range attributes {
+		attr, ok := block.Attributes[name]
+		if !ok {
+			continue
+		}
+		attr.ForceNew = requiresReplace(a)
+		if attr.NestedType == nil {
+			continue
+		}
+		switch a := a.(type) {
+		case schema.SingleNestedAttribute:
+			markFrameworkNestedForceNew(attr.NestedType, a.Attributes)
+		case schema.ListNestedAttribute:
+			markFrameworkNestedForceNew(attr.NestedType, a.NestedObject.Attributes)
+		case schema.SetNestedAttribute:
+			markFrameworkNestedForceNew(attr.NestedType, a.NestedObject.Attributes)
+		case schema.MapNestedAttribute:
+			markFrameworkNestedForceNew(attr.NestedType, a.NestedObject.Attributes)
+		}
+	}
+
+	for name, b := range blocks {
+		nested, ok := block.Blocks[name]
+		if !ok {
+			continue
+		}
+		nested.ForceNew = requiresReplace(b)
+		switch b := b.(type) {
+		case schema.SingleNestedBlock:
+			markFrameworkForceNew(&nested.Block, b.Attributes, b.Blocks)
+		case schema.ListNestedBlock:
+			markFrameworkForceNew(&nested.Block, b.NestedObject.Attributes, b.NestedObject.Blocks)
+		case schema.SetNestedBlock:
+			markFrameworkForceNew(&nested.Block, b.NestedObject.Attributes, b.NestedObject.Blocks)
+		}
+	}
+}
+
+func markFrameworkNestedForceNew(nested *NestedAttributes, attributes map[string]schema.Attribute) {
+	markFrameworkForceNew(&Block{Attributes: nested.Attributes}, attributes, nil)
+}
+
+// requiresReplace tells whether the PlanModifiers of the framework attribute or block contain one of RequiresReplace,
+// RequiresReplaceIf or RequiresReplaceIfConfigured of the type specific plan modifier packages, e.g. stringplanmodifier
+func requiresReplace(attributeOrBlock any) bool {
+	v := reflect.Indirect(reflect.ValueOf(attributeOrBlock))
+	if v.Kind() != reflect.Struct {
+		return false
+	}
+	modifiers := v.FieldByName("PlanModifiers")
+	if !modifiers.IsValid() || modifiers.Kind() != reflect.Slice {
+		return false
+	}
+	for i := 0; i < modifiers.Len(); i++ {
+		if strings.HasSuffix(fmt.Sprintf("%T", modifiers.Index(i).Interface()), ".requiresReplaceIfModifier") {
+			return true
+		}
+	}
+	return false
+}
+
+func diagnosticsError(diags []*tfprotov6.Diagnostic) error {
+	var errs []error
+	for _, d := range diags {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			errs = append(errs, fmt.Errorf("%s: %s", d.Summary, d.Detail))
+		}
+	}
+	return errors.Join(errs...)
+}
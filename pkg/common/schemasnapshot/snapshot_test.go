@@ -0,0 +1,179 @@
+package schemasnapshot
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	sdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	testProvider struct{}
+
+	testResource struct{}
+)
+
+func (testProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "akamai"
+}
+
+func (testProvider) Schema(context.Context, provider.SchemaRequest, *provider.SchemaResponse) {}
+
+func (testProvider) Configure(context.Context, provider.ConfigureRequest, *provider.ConfigureResponse) {
+}
+
+func (testProvider) Resources(context.Context) []func() resource.Resource {
+	return []func() resource.Resource{func() resource.Resource { return testResource{} }}
+}
+
+func (testProvider) DataSources(context.Context) []func() datasource.DataSource { return nil }
+
+func (testResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_framework"
+}
+
+func (testResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"secret": schema.StringAttribute{Optional: true, Computed: true, Sensitive: true},
+			"origins": schema.ListNestedAttribute{
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"hostname": schema.StringAttribute{
+							Required:      true,
+							PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplaceIfConfigured()},
+						},
+						"weights": schema.MapAttribute{ElementType: types.Int64Type, Optional: true},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": schema.SingleNestedBlock{
+				Attributes: map[string]schema.Attribute{
+					"create": schema.StringAttribute{Optional: true, DeprecationMessage: "not used"},
+				},
+			},
+		},
+	}
+}
+
+func (testResource) Create(context.Context, resource.CreateRequest, *resource.CreateResponse) {}
+
+func (testResource) Read(context.Context, resource.ReadRequest, *resource.ReadResponse) {}
+
+func (testResource) Update(context.Context, resource.UpdateRequest, *resource.UpdateResponse) {}
+
+func (testResource) Delete(context.Context, resource.DeleteRequest, *resource.DeleteResponse) {}
+
+func newTestSnapshot(t *testing.T) *Snapshot {
+	sdkProvider := &sdkschema.Provider{
+		ResourcesMap: map[string]*sdkschema.Resource{
+			"akamai_sdk": {
+				Schema: map[string]*sdkschema.Schema{
+					"contract_id": {Type: sdkschema.TypeString, Required: true, ForceNew: true},
+					"tags":        {Type: sdkschema.TypeSet, Optional: true, Elem: &sdkschema.Schema{Type: sdkschema.TypeString}},
+					"rule": {
+						Type:     sdkschema.TypeList,
+						Optional: true,
+						MaxItems: 1,
+						Elem: &sdkschema.Resource{Schema: map[string]*sdkschema.Schema{
+							"name": {Type: sdkschema.TypeString, Optional: true, ForceNew: true},
+						}},
+					},
+				},
+			},
+		},
+		DataSourcesMap: map[string]*sdkschema.Resource{
+			"akamai_sdk": {
+				Schema: map[string]*sdkschema.Schema{
+					"contract_id": {Type: sdkschema.TypeString, Required: true},
+					"version":     {Type: sdkschema.TypeInt, Computed: true},
+				},
+			},
+		},
+	}
+	snapshot, err := New(context.Background(), sdkProvider, testProvider{})
+	require.NoError(t, err)
+	return snapshot
+}
+
+func TestNew(t *testing.T) {
+	snapshot := newTestSnapshot(t)
+
+	assert.Equal(t, &Snapshot{
+		Resources: map[string]*Block{
+			"akamai_sdk": {
+				Attributes: map[string]*Attribute{
+					"contract_id": {Type: "string", Required: true, ForceNew: true},
+					"id":          {Type: "string", Optional: true, Computed: true},
+					"tags":        {Type: "set(string)", Optional: true},
+				},
+				Blocks: map[string]*NestedBlock{
+					"rule": {
+						Block:    Block{Attributes: map[string]*Attribute{"name": {Type: "string", Optional: true, ForceNew: true}}},
+						Nesting:  "list",
+						MaxItems: 1,
+					},
+				},
+			},
+			"akamai_framework": {
+				Attributes: map[string]*Attribute{
+					"name":   {Type: "string", Required: true, ForceNew: true},
+					"secret": {Type: "string", Optional: true, Computed: true, Sensitive: true},
+					"origins": {
+						Optional: true,
+						NestedType: &NestedAttributes{
+							Nesting: "list",
+							Attributes: map[string]*Attribute{
+								"hostname": {Type: "string", Required: true, ForceNew: true},
+								"weights":  {Type: "map(number)", Optional: true},
+							},
+						},
+					},
+				},
+				Blocks: map[string]*NestedBlock{
+					"timeouts": {
+						Block:   Block{Attributes: map[string]*Attribute{"create": {Type: "string", Optional: true, Deprecated: true}}},
+						Nesting: "single",
+					},
+				},
+			},
+		},
+		DataSources: map[string]*Block{
+			"akamai_sdk": {
+				Attributes: map[string]*Attribute{
+					"contract_id": {Type: "string", Required: true},
+					"id":          {Type: "string", Optional: true, Computed: true},
+					"version":     {Type: "number", Computed: true},
+				},
+			},
+		},
+	}, snapshot)
+}
+
+func TestWriteFile(t *testing.T) {
+	snapshot := newTestSnapshot(t)
+	path := filepath.Join(t.TempDir(), "schema.json")
+
+	require.NoError(t, snapshot.WriteFile(path))
+	read, err := ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, snapshot, read)
+	assert.Empty(t, Compare(snapshot, read))
+}
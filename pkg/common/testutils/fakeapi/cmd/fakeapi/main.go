@@ -0,0 +1,36 @@
+// Package main runs the fake Akamai API until interrupted, e.g. for `terraform test` runs of modules.
+// It prints the environment variables pointing the provider at the fake as shell exports.
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/testutils/fakeapi"
+)
+
+func main() {
+	server, err := fakeapi.New()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer server.Close()
+
+	env := server.Env()
+	names := make([]string, 0, len(env))
+	for name := range env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Printf("export %s=%q\n", name, env[name])
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	<-signals
+}
@@ -0,0 +1,402 @@
+package fakeapi
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/dns"
+)
+
+type (
+	zone struct {
+		dns.ZoneResponse
+		recordSets map[recordSetKey]dns.RecordSet
+		changeList bool
+	}
+
+	recordSetKey struct {
+		name       string
+		recordType string
+	}
+)
+
+// nameServers are the authorities of the contract, serving every primary zone
+var nameServers = []string{"a1-1.akam.net.", "a2-2.akam.net.", "a3-3.akam.net."}
+
+func (s *Server) dnsRoutes() {
+	s.handle(http.MethodGet, "/config-dns/v2/data/groups", s.listDNSGroups)
+	s.handle(http.MethodGet, "/config-dns/v2/data/authorities", s.getAuthorities)
+
+	s.handle(http.MethodGet, "/config-dns/v2/zones", s.listZones)
+	s.handle(http.MethodPost, "/config-dns/v2/zones", s.createZone)
+	s.handle(http.MethodGet, "/config-dns/v2/zones/{zone}", s.getZone)
+	s.handle(http.MethodPut, "/config-dns/v2/zones/{zone}", s.updateZone)
+	s.handle(http.MethodPost, "/config-dns/v2/changelists", s.saveChangeList)
+	s.handle(http.MethodGet, "/config-dns/v2/changelists/{zone}", s.getChangeList)
+	s.handle(http.MethodPost, "/config-dns/v2/changelists/{zone}/submit", s.submitChangeList)
+
+	s.handle(http.MethodGet, "/config-dns/v2/zones/{zone}/recordsets", s.getRecordSets)
+	s.handle(http.MethodPost, "/config-dns/v2/zones/{zone}/recordsets", s.createRecordSets)
+	s.handle(http.MethodPut, "/config-dns/v2/zones/{zone}/recordsets", s.updateRecordSets)
+	s.handle(http.MethodGet, "/config-dns/v2/zones/{zone}/names", s.getZoneNames)
+	s.handle(http.MethodGet, "/config-dns/v2/zones/{zone}/names/{name}/types", s.getZoneNameTypes)
+	s.handle(http.MethodGet, "/config-dns/v2/zones/{zone}/names/{name}/types/{type}", s.getRecord)
+	s.handle(http.MethodPost, "/config-dns/v2/zones/{zone}/names/{name}/types/{type}", s.createRecord)
+	s.handle(http.MethodPut, "/config-dns/v2/zones/{zone}/names/{name}/types/{type}", s.updateRecord)
+	s.handle(http.MethodDelete, "/config-dns/v2/zones/{zone}/names/{name}/types/{type}", s.deleteRecord)
+}
+
+func (s *Server) listDNSGroups(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+	groupID, _ := strconv.Atoi(strings.TrimPrefix(GroupID, "grp_"))
+	writeJSON(w, http.StatusOK, dns.ListGroupResponse{Groups: []dns.Group{{
+		GroupID:     groupID,
+		GroupName:   GroupName,
+		ContractIDs: []string{strings.TrimPrefix(ContractID, "ctr_")},
+		Permissions: []string{"READ", "WRITE", "ADD", "DELETE"},
+	}}})
+}
+
+func (s *Server) getAuthorities(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+	writeJSON(w, http.StatusOK, dns.GetAuthoritiesResponse{Contracts: []dns.Contract{{
+		ContractID:  strings.TrimPrefix(ContractID, "ctr_"),
+		Authorities: nameServers,
+	}}})
+}
+
+func (s *Server) listZones(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+	zones := make([]dns.ZoneResponse, 0, len(s.zones))
+	for _, name := range sortedKeys(s.zones) {
+		zones = append(zones, s.zones[name].ZoneResponse)
+	}
+	writeJSON(w, http.StatusOK, dns.ZoneListResponse{
+		Metadata: &dns.ListMetadata{Page: 1, PageSize: len(zones), ShowAll: true, TotalElements: len(zones)},
+		Zones:    zones,
+	})
+}
+
+func (s *Server) createZone(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	var create dns.ZoneCreate
+	if !readJSON(w, r, &create) {
+		return
+	}
+	name := strings.ToLower(create.Zone)
+	if name == "" {
+		writeProblem(w, http.StatusBadRequest, "zone is required")
+		return
+	}
+	if _, ok := s.zones[name]; ok {
+		writeProblem(w, http.StatusConflict, fmt.Sprintf("Zone %s already exists", name))
+		return
+	}
+	contractID := r.URL.Query().Get("contractId")
+	if strings.TrimPrefix(contractID, "ctr_") != strings.TrimPrefix(ContractID, "ctr_") {
+		writeProblem(w, http.StatusForbidden, fmt.Sprintf("Contract %q is not accessible", contractID))
+		return
+	}
+
+	z := &zone{recordSets: make(map[recordSetKey]dns.RecordSet)}
+	z.apply(create)
+	z.Zone, z.ContractID, z.ActivationState = name, strings.TrimPrefix(ContractID, "ctr_"), "NEW"
+	s.touchZone(z)
+	s.zones[name] = z
+	writeJSON(w, http.StatusCreated, z.ZoneResponse)
+}
+
+func (s *Server) getZone(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	z, ok := s.zone(w, params)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, z.ZoneResponse)
+}
+
+func (s *Server) updateZone(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	z, ok := s.zone(w, params)
+	if !ok {
+		return
+	}
+	var update dns.ZoneCreate
+	if !readJSON(w, r, &update) {
+		return
+	}
+	if !strings.EqualFold(update.Type, z.Type) {
+		writeProblem(w, http.StatusBadRequest, fmt.Sprintf("Type of zone %s cannot be changed from %s to %s", z.Zone, z.Type, update.Type))
+		return
+	}
+	z.apply(update)
+	s.touchZone(z)
+	writeJSON(w, http.StatusOK, z.ZoneResponse)
+}
+
+// saveChangeList starts a change list of the zone, which adds the SOA and NS records to new primary zones
+func (s *Server) saveChangeList(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	z, ok := s.zone(w, map[string]string{"zone": r.URL.Query().Get("zone")})
+	if !ok {
+		return
+	}
+	if z.changeList {
+		writeProblem(w, http.StatusConflict, fmt.Sprintf("Change list of zone %s already exists", z.Zone))
+		return
+	}
+	z.changeList = true
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (s *Server) getChangeList(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	z, ok := s.zone(w, params)
+	if !ok {
+		return
+	}
+	if !z.changeList {
+		writeProblem(w, http.StatusNotFound, fmt.Sprintf("Change list of zone %s is not found", z.Zone))
+		return
+	}
+	writeJSON(w, http.StatusOK, dns.GetChangeListResponse{Zone: z.Zone, ZoneVersionID: z.VersionID, LastModifiedDate: z.LastModifiedDate})
+}
+
+func (s *Server) submitChangeList(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	z, ok := s.zone(w, params)
+	if !ok {
+		return
+	}
+	if !z.changeList {
+		writeProblem(w, http.StatusNotFound, fmt.Sprintf("Change list of zone %s is not found", z.Zone))
+		return
+	}
+	z.changeList = false
+	if strings.EqualFold(z.Type, "PRIMARY") {
+		soa := recordSetKey{name: z.Zone, recordType: "SOA"}
+		if _, ok := z.recordSets[soa]; !ok {
+			z.recordSets[soa] = dns.RecordSet{Name: z.Zone, Type: "SOA", TTL: 86400,
+				Rdata: []string{fmt.Sprintf("%s hostmaster.%s. 1 3600 600 604800 300", nameServers[0], z.Zone)}}
+		}
+		ns := recordSetKey{name: z.Zone, recordType: "NS"}
+		if _, ok := z.recordSets[ns]; !ok {
+			z.recordSets[ns] = dns.RecordSet{Name: z.Zone, Type: "NS", TTL: 86400, Rdata: nameServers}
+		}
+	}
+	z.ActivationState = "ACTIVE"
+	s.touchZone(z)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getRecordSets(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	z, ok := s.zone(w, params)
+	if !ok {
+		return
+	}
+	var types []string
+	if t := r.URL.Query().Get("types"); t != "" {
+		types = strings.Split(strings.ToUpper(t), ",")
+	}
+	recordSets := z.sortedRecordSets(types)
+	writeJSON(w, http.StatusOK, dns.GetRecordSetsResponse{
+		Metadata:   dns.Metadata{LastPage: 1, Page: 1, PageSize: len(recordSets), ShowAll: true, TotalElements: len(recordSets)},
+		RecordSets: recordSets,
+	})
+}
+
+func (s *Server) createRecordSets(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	z, ok := s.zone(w, params)
+	if !ok {
+		return
+	}
+	var create dns.RecordSets
+	if !readJSON(w, r, &create) {
+		return
+	}
+	for _, rs := range create.RecordSets {
+		if _, ok := z.recordSets[keyOf(rs.Name, rs.Type)]; ok {
+			writeProblem(w, http.StatusConflict, fmt.Sprintf("Record set %s %s already exists", rs.Name, rs.Type))
+			return
+		}
+	}
+	for _, rs := range create.RecordSets {
+		z.recordSets[keyOf(rs.Name, rs.Type)] = rs
+	}
+	s.touchZone(z)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// updateRecordSets replaces all record sets of the zone
+func (s *Server) updateRecordSets(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	z, ok := s.zone(w, params)
+	if !ok {
+		return
+	}
+	var update dns.RecordSets
+	if !readJSON(w, r, &update) {
+		return
+	}
+	z.recordSets = make(map[recordSetKey]dns.RecordSet)
+	for _, rs := range update.RecordSets {
+		z.recordSets[keyOf(rs.Name, rs.Type)] = rs
+	}
+	s.touchZone(z)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getZoneNames(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	z, ok := s.zone(w, params)
+	if !ok {
+		return
+	}
+	names := []string{}
+	for _, rs := range z.sortedRecordSets(nil) {
+		if len(names) == 0 || names[len(names)-1] != rs.Name {
+			names = append(names, rs.Name)
+		}
+	}
+	writeJSON(w, http.StatusOK, dns.GetZoneNamesResponse{Names: names})
+}
+
+func (s *Server) getZoneNameTypes(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	z, ok := s.zone(w, params)
+	if !ok {
+		return
+	}
+	types := []string{}
+	for _, rs := range z.sortedRecordSets(nil) {
+		if strings.EqualFold(rs.Name, params["name"]) {
+			types = append(types, rs.Type)
+		}
+	}
+	if len(types) == 0 {
+		writeProblem(w, http.StatusNotFound, fmt.Sprintf("Name %s is not found in zone %s", params["name"], z.Zone))
+		return
+	}
+	writeJSON(w, http.StatusOK, dns.GetZoneNameTypesResponse{Types: types})
+}
+
+func (s *Server) getRecord(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	z, rs, ok := s.recordSet(w, params)
+	if !ok {
+		return
+	}
+	if rs == nil {
+		writeProblem(w, http.StatusNotFound, fmt.Sprintf("Record set %s %s is not found in zone %s", params["name"], params["type"], z.Zone))
+		return
+	}
+	writeJSON(w, http.StatusOK, dns.GetRecordResponse{Name: rs.Name, RecordType: rs.Type, TTL: rs.TTL, Active: true, Target: rs.Rdata})
+}
+
+func (s *Server) createRecord(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	z, rs, ok := s.recordSet(w, params)
+	if !ok {
+		return
+	}
+	if rs != nil {
+		writeProblem(w, http.StatusConflict, fmt.Sprintf("Record set %s %s already exists in zone %s", rs.Name, rs.Type, z.Zone))
+		return
+	}
+	s.putRecord(w, r, z, params, http.StatusCreated)
+}
+
+func (s *Server) updateRecord(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	z, rs, ok := s.recordSet(w, params)
+	if !ok {
+		return
+	}
+	if rs == nil {
+		writeProblem(w, http.StatusNotFound, fmt.Sprintf("Record set %s %s is not found in zone %s", params["name"], params["type"], z.Zone))
+		return
+	}
+	s.putRecord(w, r, z, params, http.StatusOK)
+}
+
+func (s *Server) deleteRecord(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	z, rs, ok := s.recordSet(w, params)
+	if !ok {
+		return
+	}
+	if rs == nil {
+		writeProblem(w, http.StatusNotFound, fmt.Sprintf("Record set %s %s is not found in zone %s", params["name"], params["type"], z.Zone))
+		return
+	}
+	delete(z.recordSets, keyOf(rs.Name, rs.Type))
+	s.touchZone(z)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) putRecord(w http.ResponseWriter, r *http.Request, z *zone, params map[string]string, status int) {
+	var record dns.RecordBody
+	if !readJSON(w, r, &record) {
+		return
+	}
+	if len(record.Target) == 0 {
+		writeProblem(w, http.StatusBadRequest, "rdata is required")
+		return
+	}
+	rs := dns.RecordSet{Name: params["name"], Type: strings.ToUpper(params["type"]), TTL: record.TTL, Rdata: record.Target}
+	z.recordSets[keyOf(rs.Name, rs.Type)] = rs
+	s.touchZone(z)
+	writeJSON(w, status, dns.GetRecordResponse{Name: rs.Name, RecordType: rs.Type, TTL: rs.TTL, Active: true, Target: rs.Rdata})
+}
+
+func (s *Server) zone(w http.ResponseWriter, params map[string]string) (*zone, bool) {
+	z, ok := s.zones[strings.ToLower(params["zone"])]
+	if !ok {
+		writeProblem(w, http.StatusNotFound, fmt.Sprintf("Zone %q is not found", params["zone"]))
+	}
+	return z, ok
+}
+
+// recordSet returns the zone and its record set of the name and type of the request, nil when there is none
+func (s *Server) recordSet(w http.ResponseWriter, params map[string]string) (*zone, *dns.RecordSet, bool) {
+	z, ok := s.zone(w, params)
+	if !ok {
+		return nil, nil, false
+	}
+	name := strings.ToLower(params["name"])
+	if name != z.Zone && !strings.HasSuffix(name, "."+z.Zone) {
+		writeProblem(w, http.StatusBadRequest, fmt.Sprintf("Name %s is not in zone %s", params["name"], z.Zone))
+		return nil, nil, false
+	}
+	if rs, ok := z.recordSets[keyOf(name, params["type"])]; ok {
+		return z, &rs, true
+	}
+	return z, nil, true
+}
+
+func (s *Server) touchZone(z *zone) {
+	z.VersionID = strconv.Itoa(s.newID())
+	z.LastModifiedDate = timestamp()
+	z.LastModifiedBy = "fakeapi"
+}
+
+func (z *zone) apply(create dns.ZoneCreate) {
+	z.Type = strings.ToUpper(create.Type)
+	z.Masters = create.Masters
+	z.Comment = create.Comment
+	z.SignAndServe = create.SignAndServe
+	z.SignAndServeAlgorithm = create.SignAndServeAlgorithm
+	z.TSIGKey = create.TSIGKey
+	z.Target = create.Target
+	z.EndCustomerID = create.EndCustomerID
+	z.OutboundZoneTransfer = create.OutboundZoneTransfer
+}
+
+// sortedRecordSets returns the record sets of the given types, all when none are given, ordered by name and type
+func (z *zone) sortedRecordSets(types []string) []dns.RecordSet {
+	recordSets := []dns.RecordSet{}
+	for key, rs := range z.recordSets {
+		if len(types) == 0 || slices.Contains(types, key.recordType) {
+			recordSets = append(recordSets, rs)
+		}
+	}
+	sort.Slice(recordSets, func(i, j int) bool {
+		if recordSets[i].Name != recordSets[j].Name {
+			return recordSets[i].Name < recordSets[j].Name
+		}
+		return recordSets[i].Type < recordSets[j].Type
+	})
+	return recordSets
+}
+
+func keyOf(name, recordType string) recordSetKey {
+	return recordSetKey{name: strings.ToLower(name), recordType: strings.ToUpper(recordType)}
+}
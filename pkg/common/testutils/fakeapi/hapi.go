@@ -0,0 +1,226 @@
+package fakeapi
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/hapi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/papi"
+)
+
+// edgeHostname is an edge hostname served by both PAPI, with IDs like ehn_123, and HAPI, with numeric IDs
+type edgeHostname struct {
+	hapi.EdgeHostname
+	contractID string
+	groupID    string
+	secure     bool
+}
+
+func (s *Server) hapiRoutes() {
+	s.handle(http.MethodGet, "/hapi/v1/edge-hostnames/{edgeHostnameId}", s.getHAPIEdgeHostname)
+	s.handle(http.MethodPatch, "/hapi/v1/dns-zones/{dnsZone}/edge-hostnames/{recordName}", s.updateEdgeHostname)
+	s.handle(http.MethodDelete, "/hapi/v1/dns-zones/{dnsZone}/edge-hostnames/{recordName}", s.deleteEdgeHostname)
+	s.handle(http.MethodGet, "/hapi/v1/dns-zones/{dnsZone}/edge-hostnames/{recordName}/certificate", s.getCertificate)
+	s.handle(http.MethodGet, "/hapi/v1/change-requests/{changeId}", s.getChangeRequest)
+}
+
+func (s *Server) getHAPIEdgeHostname(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	id, err := strconv.Atoi(params["edgeHostnameId"])
+	ehn, ok := s.edgeHostnames[id]
+	if err != nil || !ok {
+		writeProblem(w, http.StatusNotFound, fmt.Sprintf("Edge hostname %q is not found", params["edgeHostnameId"]))
+		return
+	}
+	writeJSON(w, http.StatusOK, hapi.GetEdgeHostnameResponse{
+		EdgeHostnameID:    ehn.EdgeHostnameID,
+		RecordName:        ehn.RecordName,
+		DNSZone:           ehn.DNSZone,
+		SecurityType:      ehn.SecurityType,
+		UseDefaultTTL:     ehn.UseDefaultTTL,
+		UseDefaultMap:     ehn.UseDefaultMap,
+		IPVersionBehavior: ehn.IPVersionBehavior,
+		ProductID:         ehn.ProductId,
+		TTL:               ehn.TTL,
+		Map:               ehn.Map,
+		Comments:          ehn.Comments,
+		UseCases:          ehn.UseCases,
+	})
+}
+
+// updateEdgeHostname applies the JSON patch of the request body, supporting the replacement of /ttl and /ipVersionBehavior
+func (s *Server) updateEdgeHostname(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	ehn, ok := s.edgeHostnameOf(w, params)
+	if !ok {
+		return
+	}
+	var patch []hapi.UpdateEdgeHostnameRequestBody
+	if !readJSON(w, r, &patch) {
+		return
+	}
+	for _, op := range patch {
+		if op.Op != "replace" {
+			writeProblem(w, http.StatusBadRequest, fmt.Sprintf("Operation %q is not supported", op.Op))
+			return
+		}
+		switch op.Path {
+		case "/ttl":
+			ttl, err := strconv.Atoi(op.Value)
+			if err != nil || ttl < 60 {
+				writeProblem(w, http.StatusBadRequest, fmt.Sprintf("TTL %q has to be a number of seconds of at least 60", op.Value))
+				return
+			}
+			ehn.TTL, ehn.UseDefaultTTL = ttl, false
+		case "/ipVersionBehavior":
+			ehn.IPVersionBehavior = op.Value
+		default:
+			writeProblem(w, http.StatusBadRequest, fmt.Sprintf("Path %q cannot be patched", op.Path))
+			return
+		}
+	}
+
+	change := s.newChange("EDIT", r.URL.Query().Get("comments"), ehn)
+	writeJSON(w, http.StatusAccepted, hapi.UpdateEdgeHostnameResponse{
+		Action:        change.Action,
+		ChangeID:      int(change.ChangeID),
+		Comments:      change.Comments,
+		Status:        change.Status,
+		SubmitDate:    change.SubmitDate,
+		EdgeHostnames: change.EdgeHostnames,
+	})
+}
+
+func (s *Server) deleteEdgeHostname(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	ehn, ok := s.edgeHostnameOf(w, params)
+	if !ok {
+		return
+	}
+	for _, p := range s.properties {
+		for _, v := range p.versions {
+			for _, h := range v.hostnames {
+				if h.CnameTo == ehn.domain() && (v.StagingStatus == papi.VersionStatusActive || v.ProductionStatus == papi.VersionStatusActive) {
+					writeProblem(w, http.StatusBadRequest, fmt.Sprintf("Edge hostname %s is used by active property %q", ehn.domain(), p.PropertyID))
+					return
+				}
+			}
+		}
+	}
+	delete(s.edgeHostnames, ehn.EdgeHostnameID)
+
+	change := s.newChange("DELETE", r.URL.Query().Get("comments"), ehn)
+	writeJSON(w, http.StatusAccepted, hapi.DeleteEdgeHostnameResponse{
+		Action:        change.Action,
+		ChangeID:      int(change.ChangeID),
+		Comments:      change.Comments,
+		Status:        change.Status,
+		SubmitDate:    change.SubmitDate,
+		EdgeHostnames: change.EdgeHostnames,
+	})
+}
+
+// getCertificate reports that no edge hostname has a certificate, like for edge hostnames of default certificates
+func (s *Server) getCertificate(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	if _, ok := s.edgeHostnameOf(w, params); !ok {
+		return
+	}
+	writeProblem(w, http.StatusNotFound, fmt.Sprintf("Certificate of %s.%s is not found", params["recordName"], params["dnsZone"]))
+}
+
+func (s *Server) getChangeRequest(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	id, err := strconv.Atoi(params["changeId"])
+	change, ok := s.changes[id]
+	if err != nil || !ok {
+		writeProblem(w, http.StatusNotFound, fmt.Sprintf("Change %q is not found", params["changeId"]))
+		return
+	}
+	writeJSON(w, http.StatusOK, change)
+}
+
+// newEdgeHostname adds an edge hostname created with PAPI
+func (s *Server) newEdgeHostname(contractID, groupID string, create papi.EdgeHostnameCreate) *edgeHostname {
+	securityType := "STANDARD-TLS"
+	if strings.HasSuffix(create.DomainSuffix, "edgekey.net") {
+		securityType = "ENHANCED-TLS"
+	}
+	useCases := make([]hapi.UseCase, 0, len(create.UseCases))
+	for _, u := range create.UseCases {
+		useCases = append(useCases, hapi.UseCase{Type: u.Type, Option: u.Option, UseCase: u.UseCase})
+	}
+	ehn := &edgeHostname{
+		EdgeHostname: hapi.EdgeHostname{
+			EdgeHostnameID:    s.newID(),
+			RecordName:        create.DomainPrefix,
+			DNSZone:           create.DomainSuffix,
+			SecurityType:      securityType,
+			UseDefaultTTL:     true,
+			UseDefaultMap:     true,
+			TTL:               21600,
+			IPVersionBehavior: create.IPVersionBehavior,
+			ProductId:         create.ProductID,
+			UseCases:          useCases,
+		},
+		contractID: contractID,
+		groupID:    groupID,
+		secure:     create.Secure,
+	}
+	s.edgeHostnames[ehn.EdgeHostnameID] = ehn
+	return ehn
+}
+
+func (s *Server) edgeHostnameByDomain(domain string) *edgeHostname {
+	for _, ehn := range s.edgeHostnames {
+		if strings.EqualFold(ehn.domain(), domain) {
+			return ehn
+		}
+	}
+	return nil
+}
+
+func (s *Server) edgeHostnameOf(w http.ResponseWriter, params map[string]string) (*edgeHostname, bool) {
+	ehn := s.edgeHostnameByDomain(params["recordName"] + "." + params["dnsZone"])
+	if ehn == nil {
+		writeProblem(w, http.StatusNotFound, fmt.Sprintf("Edge hostname %s.%s is not found", params["recordName"], params["dnsZone"]))
+		return nil, false
+	}
+	return ehn, true
+}
+
+// newChange records a change of an edge hostname, which succeeds right away
+func (s *Server) newChange(action, comments string, ehn *edgeHostname) *hapi.ChangeRequest {
+	now := timestamp()
+	change := &hapi.ChangeRequest{
+		Action:           action,
+		ChangeID:         int64(s.newID()),
+		Comments:         comments,
+		EdgeHostnames:    []hapi.EdgeHostname{ehn.EdgeHostname},
+		Status:           "SUCCEEDED",
+		StatusUpdateDate: now,
+		SubmitDate:       now,
+		Submitter:        "fakeapi",
+	}
+	s.changes[int(change.ChangeID)] = change
+	return change
+}
+
+func (ehn *edgeHostname) domain() string {
+	return ehn.RecordName + "." + ehn.DNSZone
+}
+
+func (ehn *edgeHostname) papiItem() papi.EdgeHostnameGetItem {
+	useCases := make([]papi.UseCase, 0, len(ehn.UseCases))
+	for _, u := range ehn.UseCases {
+		useCases = append(useCases, papi.UseCase{Type: u.Type, Option: u.Option, UseCase: u.UseCase})
+	}
+	return papi.EdgeHostnameGetItem{
+		ID:                fmt.Sprintf("ehn_%d", ehn.EdgeHostnameID),
+		Domain:            ehn.domain(),
+		ProductID:         ehn.ProductId,
+		DomainPrefix:      ehn.RecordName,
+		DomainSuffix:      ehn.DNSZone,
+		Status:            "CREATED",
+		Secure:            ehn.secure || ehn.SecurityType == "ENHANCED-TLS",
+		IPVersionBehavior: ehn.IPVersionBehavior,
+		UseCases:          useCases,
+	}
+}
@@ -0,0 +1,242 @@
+package fakeapi
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/networklists"
+)
+
+type (
+	networkList struct {
+		networklists.GetNetworkListResponse
+		// activations holds the latest activation of the list per network
+		activations map[string]*nlActivation
+	}
+
+	nlActivation struct {
+		networklists.GetActivationResponse
+	}
+)
+
+// notUniqueIDChars matches characters of network list names left out of their unique IDs
+var notUniqueIDChars = regexp.MustCompile(`[^A-Z0-9]+`)
+
+func (s *Server) networkListsRoutes() {
+	s.handle(http.MethodGet, "/network-list/v2/network-lists", s.getNetworkLists)
+	s.handle(http.MethodPost, "/network-list/v2/network-lists", s.createNetworkList)
+	s.handle(http.MethodGet, "/network-list/v2/network-lists/{uniqueId}", s.getNetworkList)
+	s.handle(http.MethodPut, "/network-list/v2/network-lists/{uniqueId}", s.updateNetworkList)
+	s.handle(http.MethodDelete, "/network-list/v2/network-lists/{uniqueId}", s.removeNetworkList)
+	s.handle(http.MethodPut, "/network-list/v2/network-lists/{uniqueId}/details", s.updateNetworkListDescription)
+	s.handle(http.MethodGet, "/network-list/v2/network-lists/{uniqueId}/environments/{network}/status", s.getNetworkListStatus)
+	s.handle(http.MethodPost, "/network-list/v2/network-lists/{uniqueId}/environments/{network}/activate", s.activateNetworkList)
+	s.handle(http.MethodPost, "/network-list/v2/network-lists/{uniqueId}/environments/{network}/deactivate", s.deactivateNetworkList)
+	s.handle(http.MethodGet, "/network-list/v2/activations/{activationId}", s.getNetworkListActivation)
+}
+
+func (s *Server) getNetworkLists(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	listType := r.URL.Query().Get("listType")
+	lists := []networklists.GetNetworkListsResponseListElement{}
+	for _, id := range sortedKeys(s.networkLists) {
+		l := s.networkLists[id]
+		if listType != "" && !strings.EqualFold(listType, l.Type) {
+			continue
+		}
+		lists = append(lists, networklists.GetNetworkListsResponseListElement{
+			ElementCount:    l.ElementCount,
+			Name:            l.Name,
+			NetworkListType: l.NetworkListType,
+			SyncPoint:       l.SyncPoint,
+			Type:            l.Type,
+			UniqueID:        l.UniqueID,
+			Description:     l.Description,
+		})
+	}
+	writeJSON(w, http.StatusOK, networklists.GetNetworkListsResponse{NetworkLists: lists})
+}
+
+func (s *Server) createNetworkList(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	var create networklists.CreateNetworkListRequest
+	if !readJSON(w, r, &create) {
+		return
+	}
+	if create.Name == "" {
+		writeProblem(w, http.StatusBadRequest, "name is required")
+		return
+	}
+	if create.Type != "IP" && create.Type != "GEO" {
+		writeProblem(w, http.StatusBadRequest, fmt.Sprintf("Type %q has to be IP or GEO", create.Type))
+		return
+	}
+
+	l := &networkList{activations: make(map[string]*nlActivation)}
+	l.UniqueID = fmt.Sprintf("%d_%s", s.newID(), strings.Trim(notUniqueIDChars.ReplaceAllString(strings.ToUpper(create.Name), ""), "_"))
+	l.Name, l.Type, l.Description = create.Name, create.Type, create.Description
+	l.ContractID, l.GroupID = create.ContractID, create.GroupID
+	l.NetworkListType = "networkListResponse"
+	l.setList(create.List)
+	s.networkLists[l.UniqueID] = l
+	writeJSON(w, http.StatusCreated, l.GetNetworkListResponse)
+}
+
+func (s *Server) getNetworkList(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	l, ok := s.networkList(w, params)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, l.GetNetworkListResponse)
+}
+
+// updateNetworkList replaces the list, rejecting updates of another sync point than the current one
+func (s *Server) updateNetworkList(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	l, ok := s.networkList(w, params)
+	if !ok {
+		return
+	}
+	var update networklists.UpdateNetworkListRequest
+	if !readJSON(w, r, &update) {
+		return
+	}
+	if update.SyncPoint != l.SyncPoint {
+		writeProblem(w, http.StatusConflict, fmt.Sprintf("Sync point %d of network list %s is not the current one, %d", update.SyncPoint, l.UniqueID, l.SyncPoint))
+		return
+	}
+	if update.Type != "" && update.Type != l.Type {
+		writeProblem(w, http.StatusBadRequest, fmt.Sprintf("Type of network list %s cannot be changed from %s to %s", l.UniqueID, l.Type, update.Type))
+		return
+	}
+	l.Name, l.Description = update.Name, update.Description
+	l.setList(update.List)
+	l.SyncPoint++
+	writeJSON(w, http.StatusOK, l.GetNetworkListResponse)
+}
+
+func (s *Server) updateNetworkListDescription(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	l, ok := s.networkList(w, params)
+	if !ok {
+		return
+	}
+	var update networklists.UpdateNetworkListDescriptionRequest
+	if !readJSON(w, r, &update) {
+		return
+	}
+	l.Name, l.Description = update.Name, update.Description
+	l.SyncPoint++
+	writeJSON(w, http.StatusOK, l.GetNetworkListResponse)
+}
+
+func (s *Server) removeNetworkList(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	l, ok := s.networkList(w, params)
+	if !ok {
+		return
+	}
+	for network, a := range l.activations {
+		if a.ActivationStatus == string(networklists.StatusActive) {
+			writeProblem(w, http.StatusConflict, fmt.Sprintf("Network list %s is active on %s and cannot be deleted", l.UniqueID, network))
+			return
+		}
+	}
+	delete(s.networkLists, l.UniqueID)
+	writeJSON(w, http.StatusOK, networklists.RemoveNetworkListResponse{Status: http.StatusOK, UniqueID: l.UniqueID, SyncPoint: l.SyncPoint})
+}
+
+func (s *Server) getNetworkListStatus(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	l, network, ok := s.networkListNetwork(w, params)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, l.status(network))
+}
+
+func (s *Server) activateNetworkList(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	s.changeNetworkListActivation(w, r, params, networklists.StatusActive)
+}
+
+func (s *Server) deactivateNetworkList(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	s.changeNetworkListActivation(w, r, params, networklists.StatusDeactivated)
+}
+
+// changeNetworkListActivation activates or deactivates the current sync point of the list right away
+func (s *Server) changeNetworkListActivation(w http.ResponseWriter, r *http.Request, params map[string]string, status networklists.StatusValue) {
+	l, network, ok := s.networkListNetwork(w, params)
+	if !ok {
+		return
+	}
+	var request networklists.CreateActivationsRequest
+	if !readJSON(w, r, &request) {
+		return
+	}
+
+	a := &nlActivation{}
+	a.ActivationID = s.newID()
+	a.CreateDate = time.Now().UTC()
+	a.CreatedBy = "fakeapi"
+	a.Environment = network
+	a.ActivationStatus = string(status)
+	a.NetworkList.ActivationComments = request.Comments
+	a.NetworkList.ActivationStatus = string(status)
+	a.NetworkList.SyncPoint = l.SyncPoint
+	a.NetworkList.UniqueID = l.UniqueID
+	l.activations[network] = a
+	s.nlActivations[a.ActivationID] = a
+	writeJSON(w, http.StatusOK, l.status(network))
+}
+
+func (s *Server) getNetworkListActivation(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	id, err := strconv.Atoi(params["activationId"])
+	a, ok := s.nlActivations[id]
+	if err != nil || !ok {
+		writeProblem(w, http.StatusNotFound, fmt.Sprintf("Activation %q is not found", params["activationId"]))
+		return
+	}
+	writeJSON(w, http.StatusOK, a.GetActivationResponse)
+}
+
+func (s *Server) networkList(w http.ResponseWriter, params map[string]string) (*networkList, bool) {
+	l, ok := s.networkLists[params["uniqueId"]]
+	if !ok {
+		writeProblem(w, http.StatusNotFound, fmt.Sprintf("Network list %q is not found", params["uniqueId"]))
+	}
+	return l, ok
+}
+
+func (s *Server) networkListNetwork(w http.ResponseWriter, params map[string]string) (*networkList, string, bool) {
+	l, ok := s.networkList(w, params)
+	if !ok {
+		return nil, "", false
+	}
+	network := strings.ToUpper(params["network"])
+	if network != string(networklists.NetworkStaging) && network != string(networklists.NetworkProduction) {
+		writeProblem(w, http.StatusBadRequest, fmt.Sprintf("Network %q has to be STAGING or PRODUCTION", params["network"]))
+		return nil, "", false
+	}
+	return l, network, true
+}
+
+func (l *networkList) setList(list []string) {
+	if list == nil {
+		list = []string{}
+	}
+	l.List, l.ElementCount = list, len(list)
+}
+
+// status returns the status of the list on the network, INACTIVE when it has never been activated there
+func (l *networkList) status(network string) networklists.GetActivationsResponse {
+	status := networklists.GetActivationsResponse{
+		ActivationStatus: string(networklists.StatusInactive),
+		SyncPoint:        l.SyncPoint,
+		UniqueID:         l.UniqueID,
+	}
+	if a, ok := l.activations[network]; ok {
+		status.ActivationID = a.ActivationID
+		status.ActivationComments = a.NetworkList.ActivationComments
+		status.ActivationStatus = a.ActivationStatus
+		status.SyncPoint = a.NetworkList.SyncPoint
+	}
+	return status
+}
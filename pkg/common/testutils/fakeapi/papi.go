@@ -0,0 +1,736 @@
+package fakeapi
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/papi"
+)
+
+type (
+	property struct {
+		papi.Property
+		productID   string
+		versions    []*propertyVersion
+		activations []*papi.Activation
+	}
+
+	propertyVersion struct {
+		papi.PropertyVersionGetItem
+		rules     papi.Rules
+		comments  string
+		hostnames []papi.Hostname
+	}
+
+	cpCode struct {
+		papi.CPCode
+		contractID string
+		groupID    string
+	}
+
+	// activationRequest holds the fields of papi.Activation read by the fake
+	activationRequest struct {
+		ActivationType  papi.ActivationType    `json:"activationType"`
+		PropertyVersion int                    `json:"propertyVersion"`
+		Network         papi.ActivationNetwork `json:"network"`
+		Note            string                 `json:"note"`
+		NotifyEmails    []string               `json:"notifyEmails"`
+	}
+)
+
+// ruleFormatMediaType matches the rule format of media types like application/vnd.akamai.papirules.v2024-10-21+json
+var ruleFormatMediaType = regexp.MustCompile(`vnd\.akamai\.papirules\.([^+]+)\+json`)
+
+func (s *Server) papiRoutes() {
+	s.handle(http.MethodGet, "/papi/v1/contracts", s.getContracts)
+	s.handle(http.MethodGet, "/papi/v1/groups", s.getGroups)
+	s.handle(http.MethodGet, "/papi/v1/products", s.getProducts)
+	s.handle(http.MethodPost, "/papi/v1/search/find-by-value", s.searchProperties)
+
+	s.handle(http.MethodGet, "/papi/v1/properties", s.getProperties)
+	s.handle(http.MethodPost, "/papi/v1/properties", s.createProperty)
+	s.handle(http.MethodGet, "/papi/v1/properties/{propertyId}", s.getProperty)
+	s.handle(http.MethodDelete, "/papi/v1/properties/{propertyId}", s.removeProperty)
+	s.handle(http.MethodGet, "/papi/v1/properties/{propertyId}/versions", s.getPropertyVersions)
+	s.handle(http.MethodPost, "/papi/v1/properties/{propertyId}/versions", s.createPropertyVersion)
+	s.handle(http.MethodGet, "/papi/v1/properties/{propertyId}/versions/latest", s.getLatestVersion)
+	s.handle(http.MethodGet, "/papi/v1/properties/{propertyId}/versions/{version}", s.getPropertyVersion)
+	s.handle(http.MethodGet, "/papi/v1/properties/{propertyId}/versions/{version}/rules", s.getRuleTree)
+	s.handle(http.MethodPut, "/papi/v1/properties/{propertyId}/versions/{version}/rules", s.updateRuleTree)
+	s.handle(http.MethodGet, "/papi/v1/properties/{propertyId}/versions/{version}/hostnames", s.getPropertyVersionHostnames)
+	s.handle(http.MethodPut, "/papi/v1/properties/{propertyId}/versions/{version}/hostnames", s.updatePropertyVersionHostnames)
+	s.handle(http.MethodGet, "/papi/v1/properties/{propertyId}/activations", s.getActivations)
+	s.handle(http.MethodPost, "/papi/v1/properties/{propertyId}/activations", s.createActivation)
+	s.handle(http.MethodGet, "/papi/v1/properties/{propertyId}/activations/{activationId}", s.getActivation)
+
+	s.handle(http.MethodGet, "/papi/v1/edgehostnames", s.getEdgeHostnames)
+	s.handle(http.MethodPost, "/papi/v1/edgehostnames", s.createEdgeHostname)
+	s.handle(http.MethodGet, "/papi/v1/edgehostnames/{edgeHostnameId}", s.getEdgeHostname)
+
+	s.handle(http.MethodGet, "/papi/v1/cpcodes", s.getCPCodes)
+	s.handle(http.MethodPost, "/papi/v1/cpcodes", s.createCPCode)
+	s.handle(http.MethodGet, "/papi/v1/cpcodes/{cpCodeId}", s.getCPCode)
+}
+
+func (s *Server) getContracts(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+	writeJSON(w, http.StatusOK, papi.GetContractsResponse{
+		AccountID: AccountID,
+		Contracts: papi.ContractsItems{Items: []*papi.Contract{{ContractID: ContractID, ContractTypeName: "DIRECT_CUSTOMER"}}},
+	})
+}
+
+func (s *Server) getGroups(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+	writeJSON(w, http.StatusOK, papi.GetGroupsResponse{
+		AccountID:   AccountID,
+		AccountName: "Fake Account",
+		Groups:      papi.GroupItems{Items: []*papi.Group{{GroupID: GroupID, GroupName: GroupName, ContractIDs: []string{ContractID}}}},
+	})
+}
+
+func (s *Server) getProducts(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	contractID := r.URL.Query().Get("contractId")
+	if contractID != ContractID {
+		writeProblem(w, http.StatusForbidden, fmt.Sprintf("Contract %q is not accessible", contractID))
+		return
+	}
+	writeJSON(w, http.StatusOK, papi.GetProductsResponse{
+		AccountID:  AccountID,
+		ContractID: ContractID,
+		Products: papi.ProductsItems{Items: []papi.ProductItem{
+			{ProductID: ProductID, ProductName: "Fresca"},
+			{ProductID: "prd_SPM", ProductName: "Ion Premier"},
+		}},
+	})
+}
+
+func (s *Server) searchProperties(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	var search map[string]string
+	if !readJSON(w, r, &search) {
+		return
+	}
+
+	var items []papi.SearchItem
+	for _, id := range sortedKeys(s.properties) {
+		p := s.properties[id]
+		for _, v := range p.versions {
+			matches := search[papi.SearchKeyPropertyName] == p.PropertyName
+			for _, h := range v.hostnames {
+				matches = matches || search[papi.SearchKeyHostname] == h.CnameFrom || search[papi.SearchKeyEdgeHostname] == h.CnameTo
+			}
+			if !matches {
+				continue
+			}
+			items = append(items, papi.SearchItem{
+				AccountID:        AccountID,
+				AssetID:          p.AssetID,
+				ContractID:       p.ContractID,
+				GroupID:          p.GroupID,
+				ProductionStatus: string(v.ProductionStatus),
+				PropertyID:       p.PropertyID,
+				PropertyName:     p.PropertyName,
+				PropertyVersion:  v.PropertyVersion,
+				StagingStatus:    string(v.StagingStatus),
+				UpdatedByUser:    v.UpdatedByUser,
+				UpdatedDate:      v.UpdatedDate,
+			})
+		}
+	}
+	writeJSON(w, http.StatusOK, papi.SearchResponse{Versions: papi.SearchItems{Items: items}})
+}
+
+func (s *Server) getProperties(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	contractID, groupID := r.URL.Query().Get("contractId"), r.URL.Query().Get("groupId")
+	items := []*papi.Property{}
+	for _, id := range sortedKeys(s.properties) {
+		p := s.properties[id]
+		if p.ContractID == contractID && p.GroupID == groupID {
+			property := p.Property
+			items = append(items, &property)
+		}
+	}
+	writeJSON(w, http.StatusOK, papi.GetPropertiesResponse{Properties: papi.PropertiesItems{Items: items}})
+}
+
+func (s *Server) createProperty(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	contractID, groupID, ok := s.contractAndGroup(w, r)
+	if !ok {
+		return
+	}
+	var create papi.PropertyCreate
+	if !readJSON(w, r, &create) {
+		return
+	}
+	if create.PropertyName == "" || create.ProductID == "" {
+		writeProblem(w, http.StatusBadRequest, "propertyName and productId are required")
+		return
+	}
+	for _, p := range s.properties {
+		if p.PropertyName == create.PropertyName {
+			writeProblem(w, http.StatusConflict, fmt.Sprintf("Property %q already exists", create.PropertyName))
+			return
+		}
+	}
+
+	id := s.newID()
+	p := &property{
+		Property: papi.Property{
+			AccountID:     AccountID,
+			AssetID:       fmt.Sprintf("aid_%d", id),
+			ContractID:    contractID,
+			GroupID:       groupID,
+			LatestVersion: 1,
+			PropertyID:    fmt.Sprintf("prp_%d", id),
+			PropertyName:  create.PropertyName,
+		},
+		productID: create.ProductID,
+	}
+	version := s.newPropertyVersion(p, 1, papi.Rules{Name: "default"}, create.RuleFormat)
+	if from := create.CloneFrom; from != nil {
+		source, ok := s.properties[from.PropertyID]
+		if !ok || from.Version < 1 || from.Version > len(source.versions) {
+			writeProblem(w, http.StatusNotFound, fmt.Sprintf("Version %d of property %q to clone is not found", from.Version, from.PropertyID))
+			return
+		}
+		sourceVersion := source.versions[from.Version-1]
+		version.rules, version.RuleFormat = sourceVersion.rules, sourceVersion.RuleFormat
+		if from.CopyHostnames {
+			version.hostnames = append([]papi.Hostname{}, sourceVersion.hostnames...)
+		}
+	}
+	p.versions = []*propertyVersion{version}
+	s.properties[p.PropertyID] = p
+
+	writeJSON(w, http.StatusCreated, map[string]string{
+		"propertyLink": fmt.Sprintf("/papi/v1/properties/%s?contractId=%s&groupId=%s", p.PropertyID, contractID, groupID),
+	})
+}
+
+func (s *Server) getProperty(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	p, ok := s.property(w, params)
+	if !ok {
+		return
+	}
+	property := p.Property
+	writeJSON(w, http.StatusOK, papi.GetPropertyResponse{Properties: papi.PropertiesItems{Items: []*papi.Property{&property}}})
+}
+
+func (s *Server) removeProperty(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	p, ok := s.property(w, params)
+	if !ok {
+		return
+	}
+	if p.StagingVersion != nil || p.ProductionVersion != nil {
+		writeProblem(w, http.StatusForbidden, fmt.Sprintf("Property %q is active and cannot be deleted", p.PropertyID))
+		return
+	}
+	delete(s.properties, p.PropertyID)
+	writeJSON(w, http.StatusOK, papi.RemovePropertyResponse{Message: "Deletion Successful."})
+}
+
+func (s *Server) getPropertyVersions(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	p, ok := s.property(w, params)
+	if !ok {
+		return
+	}
+	items := make([]papi.PropertyVersionGetItem, 0, len(p.versions))
+	for i := len(p.versions) - 1; i >= 0; i-- {
+		items = append(items, p.versions[i].PropertyVersionGetItem)
+	}
+	writeJSON(w, http.StatusOK, s.versionsResponse(p, items))
+}
+
+func (s *Server) getLatestVersion(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.property(w, params)
+	if !ok {
+		return
+	}
+	latest := p.LatestVersion
+	switch network := strings.ToUpper(r.URL.Query().Get("activatedOn")); network {
+	case "":
+	case string(papi.ActivationNetworkStaging), string(papi.ActivationNetworkProduction):
+		active := p.StagingVersion
+		if network == string(papi.ActivationNetworkProduction) {
+			active = p.ProductionVersion
+		}
+		if active == nil {
+			writeProblem(w, http.StatusNotFound, fmt.Sprintf("Property %q is not active on %s", p.PropertyID, network))
+			return
+		}
+		latest = *active
+	default:
+		writeProblem(w, http.StatusBadRequest, fmt.Sprintf("activatedOn %q has to be STAGING or PRODUCTION", network))
+		return
+	}
+	writeJSON(w, http.StatusOK, s.versionsResponse(p, []papi.PropertyVersionGetItem{p.versions[latest-1].PropertyVersionGetItem}))
+}
+
+func (s *Server) getPropertyVersion(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	p, v, ok := s.propertyVersion(w, params)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, s.versionsResponse(p, []papi.PropertyVersionGetItem{v.PropertyVersionGetItem}))
+}
+
+func (s *Server) createPropertyVersion(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.property(w, params)
+	if !ok {
+		return
+	}
+	var create papi.PropertyVersionCreate
+	if !readJSON(w, r, &create) {
+		return
+	}
+	if create.CreateFromVersion < 1 || create.CreateFromVersion > len(p.versions) {
+		writeProblem(w, http.StatusNotFound, fmt.Sprintf("Version %d of property %q is not found", create.CreateFromVersion, p.PropertyID))
+		return
+	}
+	from := p.versions[create.CreateFromVersion-1]
+	if create.CreateFromVersionEtag != "" && create.CreateFromVersionEtag != from.Etag {
+		writeProblem(w, http.StatusPreconditionFailed, fmt.Sprintf("Etag of version %d of property %q has changed", from.PropertyVersion, p.PropertyID))
+		return
+	}
+
+	version := s.newPropertyVersion(p, len(p.versions)+1, from.rules, from.RuleFormat)
+	version.comments = from.comments
+	version.hostnames = append([]papi.Hostname{}, from.hostnames...)
+	p.versions = append(p.versions, version)
+	p.LatestVersion = version.PropertyVersion
+
+	writeJSON(w, http.StatusCreated, map[string]string{
+		"versionLink": fmt.Sprintf("/papi/v1/properties/%s/versions/%d?contractId=%s&groupId=%s",
+			p.PropertyID, version.PropertyVersion, p.ContractID, p.GroupID),
+	})
+}
+
+func (s *Server) getRuleTree(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, v, ok := s.propertyVersion(w, params)
+	if !ok {
+		return
+	}
+	ruleFormat := v.RuleFormat
+	if match := ruleFormatMediaType.FindStringSubmatch(r.Header.Get("Accept")); match != nil {
+		ruleFormat = match[1]
+	}
+	writeJSON(w, http.StatusOK, papi.GetRuleTreeResponse{
+		Response:        papi.Response{AccountID: AccountID, ContractID: p.ContractID, GroupID: p.GroupID},
+		PropertyID:      p.PropertyID,
+		PropertyVersion: v.PropertyVersion,
+		Etag:            v.Etag,
+		RuleFormat:      ruleFormat,
+		Rules:           v.rules,
+		Comments:        v.comments,
+	})
+}
+
+func (s *Server) updateRuleTree(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, v, ok := s.editablePropertyVersion(w, params)
+	if !ok {
+		return
+	}
+	var update papi.RulesUpdate
+	if !readJSON(w, r, &update) {
+		return
+	}
+	if update.Rules.Name != "default" {
+		writeProblem(w, http.StatusBadRequest, fmt.Sprintf("The name of the top level rule has to be default, not %q", update.Rules.Name))
+		return
+	}
+
+	if r.URL.Query().Get("dryRun") != "true" {
+		v.rules, v.comments = update.Rules, update.Comments
+		if match := ruleFormatMediaType.FindStringSubmatch(r.Header.Get("Content-Type")); match != nil {
+			v.RuleFormat = match[1]
+		}
+		s.touch(v)
+	}
+	writeJSON(w, http.StatusOK, papi.UpdateRulesResponse{
+		AccountID:       AccountID,
+		ContractID:      p.ContractID,
+		GroupID:         p.GroupID,
+		PropertyID:      p.PropertyID,
+		PropertyVersion: v.PropertyVersion,
+		Etag:            v.Etag,
+		RuleFormat:      v.RuleFormat,
+		Rules:           update.Rules,
+		Comments:        update.Comments,
+	})
+}
+
+func (s *Server) getPropertyVersionHostnames(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	p, v, ok := s.propertyVersion(w, params)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, s.hostnamesResponse(p, v))
+}
+
+func (s *Server) updatePropertyVersionHostnames(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, v, ok := s.editablePropertyVersion(w, params)
+	if !ok {
+		return
+	}
+	hostnames := []papi.Hostname{}
+	if !readJSON(w, r, &hostnames) {
+		return
+	}
+	for i, h := range hostnames {
+		if h.CnameType == "" {
+			hostnames[i].CnameType = papi.HostnameCnameTypeEdgeHostname
+		}
+		if h.CertProvisioningType == "" {
+			hostnames[i].CertProvisioningType = "CPS_MANAGED"
+		}
+		switch {
+		case h.EdgeHostnameID != "":
+			ehnID, err := strconv.Atoi(strings.TrimPrefix(h.EdgeHostnameID, "ehn_"))
+			ehn, found := s.edgeHostnames[ehnID]
+			if err != nil || !found {
+				writeProblem(w, http.StatusBadRequest, fmt.Sprintf("Edge hostname %q of %q is not found", h.EdgeHostnameID, h.CnameFrom))
+				return
+			}
+			hostnames[i].CnameTo = ehn.domain()
+		case h.CnameTo != "":
+			if ehn := s.edgeHostnameByDomain(h.CnameTo); ehn != nil {
+				hostnames[i].EdgeHostnameID = fmt.Sprintf("ehn_%d", ehn.EdgeHostnameID)
+			}
+		default:
+			writeProblem(w, http.StatusBadRequest, fmt.Sprintf("Either cnameTo or edgeHostnameId of %q is required", h.CnameFrom))
+			return
+		}
+	}
+	v.hostnames = hostnames
+	s.touch(v)
+	writeJSON(w, http.StatusOK, s.hostnamesResponse(p, v))
+}
+
+func (s *Server) getActivations(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	p, ok := s.property(w, params)
+	if !ok {
+		return
+	}
+	items := make([]*papi.Activation, 0, len(p.activations))
+	for i := len(p.activations) - 1; i >= 0; i-- {
+		items = append(items, p.activations[i])
+	}
+	writeJSON(w, http.StatusOK, papi.GetActivationsResponse{
+		Response:    papi.Response{AccountID: AccountID, ContractID: p.ContractID, GroupID: p.GroupID},
+		Activations: papi.ActivationsItems{Items: items},
+	})
+}
+
+func (s *Server) getActivation(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	p, ok := s.property(w, params)
+	if !ok {
+		return
+	}
+	for _, a := range p.activations {
+		if a.ActivationID == params["activationId"] {
+			writeJSON(w, http.StatusOK, papi.GetActivationsResponse{
+				Response:    papi.Response{AccountID: AccountID, ContractID: p.ContractID, GroupID: p.GroupID},
+				Activations: papi.ActivationsItems{Items: []*papi.Activation{a}},
+			})
+			return
+		}
+	}
+	writeProblem(w, http.StatusNotFound, fmt.Sprintf("Activation %q of property %q is not found", params["activationId"], p.PropertyID))
+}
+
+// createActivation activates or deactivates a version right away, the activation is created ACTIVE
+func (s *Server) createActivation(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.property(w, params)
+	if !ok {
+		return
+	}
+	var request activationRequest
+	if !readJSON(w, r, &request) {
+		return
+	}
+	if request.PropertyVersion < 1 || request.PropertyVersion > len(p.versions) {
+		writeProblem(w, http.StatusNotFound, fmt.Sprintf("Version %d of property %q is not found", request.PropertyVersion, p.PropertyID))
+		return
+	}
+	if request.ActivationType == "" {
+		request.ActivationType = papi.ActivationTypeActivate
+	}
+
+	var active **int
+	var status func(v *propertyVersion) *papi.VersionStatus
+	switch request.Network {
+	case papi.ActivationNetworkStaging:
+		active = &p.StagingVersion
+		status = func(v *propertyVersion) *papi.VersionStatus { return &v.StagingStatus }
+	case papi.ActivationNetworkProduction:
+		active = &p.ProductionVersion
+		status = func(v *propertyVersion) *papi.VersionStatus { return &v.ProductionStatus }
+	default:
+		writeProblem(w, http.StatusBadRequest, fmt.Sprintf("Network %q has to be STAGING or PRODUCTION", request.Network))
+		return
+	}
+
+	version := p.versions[request.PropertyVersion-1]
+	switch request.ActivationType {
+	case papi.ActivationTypeActivate:
+		if len(version.hostnames) == 0 {
+			writeProblem(w, http.StatusBadRequest, fmt.Sprintf("Version %d of property %q has no hostnames", version.PropertyVersion, p.PropertyID))
+			return
+		}
+		if *active != nil {
+			*status(p.versions[**active-1]) = papi.VersionStatusDeactivated
+		}
+		activeVersion := version.PropertyVersion
+		*active = &activeVersion
+		*status(version) = papi.VersionStatusActive
+	case papi.ActivationTypeDeactivate:
+		if *active == nil || **active != version.PropertyVersion {
+			writeProblem(w, http.StatusBadRequest, fmt.Sprintf("Version %d of property %q is not active on %s", version.PropertyVersion, p.PropertyID, request.Network))
+			return
+		}
+		*active = nil
+		*status(version) = papi.VersionStatusDeactivated
+	default:
+		writeProblem(w, http.StatusBadRequest, fmt.Sprintf("Activation type %q has to be ACTIVATE or DEACTIVATE", request.ActivationType))
+		return
+	}
+
+	now := timestamp()
+	activation := &papi.Activation{
+		AccountID:       AccountID,
+		ActivationID:    fmt.Sprintf("atv_%d", s.newID()),
+		ActivationType:  request.ActivationType,
+		GroupID:         p.GroupID,
+		PropertyName:    p.PropertyName,
+		PropertyID:      p.PropertyID,
+		PropertyVersion: version.PropertyVersion,
+		Network:         request.Network,
+		Status:          papi.ActivationStatusActive,
+		SubmitDate:      now,
+		UpdateDate:      now,
+		Note:            request.Note,
+		NotifyEmails:    request.NotifyEmails,
+	}
+	p.activations = append(p.activations, activation)
+
+	writeJSON(w, http.StatusCreated, map[string]string{
+		"activationLink": fmt.Sprintf("/papi/v1/properties/%s/activations/%s?contractId=%s&groupId=%s",
+			p.PropertyID, activation.ActivationID, p.ContractID, p.GroupID),
+	})
+}
+
+func (s *Server) getEdgeHostnames(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	contractID, groupID := r.URL.Query().Get("contractId"), r.URL.Query().Get("groupId")
+	items := []papi.EdgeHostnameGetItem{}
+	for _, id := range sortedIntKeys(s.edgeHostnames) {
+		ehn := s.edgeHostnames[id]
+		if ehn.contractID == contractID && ehn.groupID == groupID {
+			items = append(items, ehn.papiItem())
+		}
+	}
+	writeJSON(w, http.StatusOK, papi.GetEdgeHostnamesResponse{
+		AccountID:     AccountID,
+		ContractID:    contractID,
+		GroupID:       groupID,
+		EdgeHostnames: papi.EdgeHostnameItems{Items: items},
+	})
+}
+
+func (s *Server) getEdgeHostname(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	id, err := strconv.Atoi(strings.TrimPrefix(params["edgeHostnameId"], "ehn_"))
+	ehn, ok := s.edgeHostnames[id]
+	if err != nil || !ok {
+		writeProblem(w, http.StatusNotFound, fmt.Sprintf("Edge hostname %q is not found", params["edgeHostnameId"]))
+		return
+	}
+	writeJSON(w, http.StatusOK, papi.GetEdgeHostnamesResponse{
+		AccountID:     AccountID,
+		ContractID:    ehn.contractID,
+		GroupID:       ehn.groupID,
+		EdgeHostnames: papi.EdgeHostnameItems{Items: []papi.EdgeHostnameGetItem{ehn.papiItem()}},
+	})
+}
+
+func (s *Server) createEdgeHostname(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	contractID, groupID, ok := s.contractAndGroup(w, r)
+	if !ok {
+		return
+	}
+	var create papi.EdgeHostnameCreate
+	if !readJSON(w, r, &create) {
+		return
+	}
+	if create.DomainPrefix == "" || create.DomainSuffix == "" {
+		writeProblem(w, http.StatusBadRequest, "domainPrefix and domainSuffix are required")
+		return
+	}
+	if s.edgeHostnameByDomain(create.DomainPrefix+"."+create.DomainSuffix) != nil {
+		writeProblem(w, http.StatusConflict, fmt.Sprintf("Edge hostname %s.%s already exists", create.DomainPrefix, create.DomainSuffix))
+		return
+	}
+
+	ehn := s.newEdgeHostname(contractID, groupID, create)
+	writeJSON(w, http.StatusCreated, map[string]string{
+		"edgeHostnameLink": fmt.Sprintf("/papi/v1/edgehostnames/ehn_%d?contractId=%s&groupId=%s", ehn.EdgeHostnameID, contractID, groupID),
+	})
+}
+
+func (s *Server) getCPCodes(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	contractID, groupID := r.URL.Query().Get("contractId"), r.URL.Query().Get("groupId")
+	items := []papi.CPCode{}
+	for _, id := range sortedKeys(s.cpCodes) {
+		if c := s.cpCodes[id]; c.contractID == contractID && c.groupID == groupID {
+			items = append(items, c.CPCode)
+		}
+	}
+	writeJSON(w, http.StatusOK, papi.GetCPCodesResponse{
+		AccountID:  AccountID,
+		ContractID: contractID,
+		GroupID:    groupID,
+		CPCodes:    papi.CPCodeItems{Items: items},
+	})
+}
+
+func (s *Server) getCPCode(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	c, ok := s.cpCodes[params["cpCodeId"]]
+	if !ok {
+		writeProblem(w, http.StatusNotFound, fmt.Sprintf("CP code %q is not found", params["cpCodeId"]))
+		return
+	}
+	writeJSON(w, http.StatusOK, papi.GetCPCodesResponse{
+		AccountID:  AccountID,
+		ContractID: c.contractID,
+		GroupID:    c.groupID,
+		CPCodes:    papi.CPCodeItems{Items: []papi.CPCode{c.CPCode}},
+	})
+}
+
+func (s *Server) createCPCode(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	contractID, groupID, ok := s.contractAndGroup(w, r)
+	if !ok {
+		return
+	}
+	var create papi.CreateCPCode
+	if !readJSON(w, r, &create) {
+		return
+	}
+	if create.CPCodeName == "" || create.ProductID == "" {
+		writeProblem(w, http.StatusBadRequest, "cpcodeName and productId are required")
+		return
+	}
+
+	c := &cpCode{
+		CPCode: papi.CPCode{
+			ID:          fmt.Sprintf("cpc_%d", s.newID()),
+			Name:        create.CPCodeName,
+			CreatedDate: timestamp(),
+			ProductIDs:  []string{create.ProductID},
+		},
+		contractID: contractID,
+		groupID:    groupID,
+	}
+	s.cpCodes[c.ID] = c
+	writeJSON(w, http.StatusCreated, map[string]string{
+		"cpcodeLink": fmt.Sprintf("/papi/v1/cpcodes/%s?contractId=%s&groupId=%s", c.ID, contractID, groupID),
+	})
+}
+
+// contractAndGroup returns the contract and group of the request, writing a problem when they are not of the account
+func (s *Server) contractAndGroup(w http.ResponseWriter, r *http.Request) (string, string, bool) {
+	contractID, groupID := r.URL.Query().Get("contractId"), r.URL.Query().Get("groupId")
+	if contractID != ContractID || groupID != GroupID {
+		writeProblem(w, http.StatusForbidden, fmt.Sprintf("Contract %q and group %q are not accessible", contractID, groupID))
+		return "", "", false
+	}
+	return contractID, groupID, true
+}
+
+func (s *Server) property(w http.ResponseWriter, params map[string]string) (*property, bool) {
+	p, ok := s.properties[params["propertyId"]]
+	if !ok {
+		writeProblem(w, http.StatusNotFound, fmt.Sprintf("Property %q is not found", params["propertyId"]))
+	}
+	return p, ok
+}
+
+func (s *Server) propertyVersion(w http.ResponseWriter, params map[string]string) (*property, *propertyVersion, bool) {
+	p, ok := s.property(w, params)
+	if !ok {
+		return nil, nil, false
+	}
+	version, err := strconv.Atoi(params["version"])
+	if err != nil || version < 1 || version > len(p.versions) {
+		writeProblem(w, http.StatusNotFound, fmt.Sprintf("Version %s of property %q is not found", params["version"], p.PropertyID))
+		return nil, nil, false
+	}
+	return p, p.versions[version-1], true
+}
+
+// editablePropertyVersion returns the version to update, writing a problem when it has ever been activated
+func (s *Server) editablePropertyVersion(w http.ResponseWriter, params map[string]string) (*property, *propertyVersion, bool) {
+	p, v, ok := s.propertyVersion(w, params)
+	if !ok {
+		return nil, nil, false
+	}
+	if v.StagingStatus != papi.VersionStatusInactive || v.ProductionStatus != papi.VersionStatusInactive {
+		writeProblem(w, http.StatusForbidden, fmt.Sprintf("Version %d of property %q has been activated and cannot be modified", v.PropertyVersion, p.PropertyID))
+		return nil, nil, false
+	}
+	return p, v, true
+}
+
+func (s *Server) newPropertyVersion(p *property, version int, rules papi.Rules, ruleFormat string) *propertyVersion {
+	if ruleFormat == "" {
+		ruleFormat = "latest"
+	}
+	v := &propertyVersion{
+		PropertyVersionGetItem: papi.PropertyVersionGetItem{
+			ProductID:        p.productID,
+			ProductionStatus: papi.VersionStatusInactive,
+			PropertyVersion:  version,
+			RuleFormat:       ruleFormat,
+			StagingStatus:    papi.VersionStatusInactive,
+			UpdatedByUser:    "fakeapi",
+		},
+		rules:     rules,
+		hostnames: []papi.Hostname{},
+	}
+	s.touch(v)
+	return v
+}
+
+// touch updates the etag and the update date of a changed version
+func (s *Server) touch(v *propertyVersion) {
+	v.Etag = fmt.Sprintf("%x", s.newID())
+	v.UpdatedDate = timestamp()
+}
+
+func (s *Server) versionsResponse(p *property, items []papi.PropertyVersionGetItem) papi.GetPropertyVersionsResponse {
+	return papi.GetPropertyVersionsResponse{
+		PropertyID:   p.PropertyID,
+		PropertyName: p.PropertyName,
+		AccountID:    AccountID,
+		ContractID:   p.ContractID,
+		GroupID:      p.GroupID,
+		AssetID:      p.AssetID,
+		Versions:     papi.PropertyVersionItems{Items: items},
+	}
+}
+
+func (s *Server) hostnamesResponse(p *property, v *propertyVersion) papi.GetPropertyVersionHostnamesResponse {
+	return papi.GetPropertyVersionHostnamesResponse{
+		AccountID:       AccountID,
+		ContractID:      p.ContractID,
+		GroupID:         p.GroupID,
+		PropertyID:      p.PropertyID,
+		PropertyVersion: v.PropertyVersion,
+		Etag:            v.Etag,
+		Hostnames:       papi.HostnameResponseItems{Items: v.hostnames},
+	}
+}
+
+func timestamp() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
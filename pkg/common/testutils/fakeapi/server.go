@@ -0,0 +1,264 @@
+// Package fakeapi provides a fake Akamai API holding its state in memory, so that configurations using
+// the provider can be applied in tests without network access and without an Akamai account.
+//
+// The fake serves the PAPI, HAPI, DNS and network lists endpoints used by the resources of these subproviders.
+// Activations and changes complete right away. EdgeGrid signatures are not verified, only their presence is.
+package fakeapi
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/hapi"
+)
+
+const (
+	// AccountID is the ID of the account of the fake API
+	AccountID = "act_1-FAKE"
+	// ContractID is the ID of the only contract of the account
+	ContractID = "ctr_1-FAKE"
+	// GroupID is the ID of the only group of the account
+	GroupID = "grp_10000"
+	// GroupName is the name of the only group of the account
+	GroupName = "Fake Group"
+	// ProductID is the ID of the default product of the contract
+	ProductID = "prd_Fresca"
+
+	// CACertFileEnv is the environment variable pointing the provider to a PEM file of additional trusted CAs
+	CACertFileEnv = "AKAMAI_CA_CERT_FILE"
+
+	credential = "akab-fake"
+)
+
+type (
+	// Server is a fake Akamai API served over TLS on a local port
+	Server struct {
+		*httptest.Server
+
+		mu     sync.Mutex
+		routes []route
+		nextID int
+
+		caCertFile string
+
+		properties    map[string]*property
+		edgeHostnames map[int]*edgeHostname
+		changes       map[int]*hapi.ChangeRequest
+		cpCodes       map[string]*cpCode
+		zones         map[string]*zone
+		networkLists  map[string]*networkList
+		nlActivations map[int]*nlActivation
+	}
+
+	route struct {
+		method  string
+		pattern []string
+		handler func(w http.ResponseWriter, r *http.Request, params map[string]string)
+	}
+
+	problem struct {
+		Type   string `json:"type"`
+		Title  string `json:"title"`
+		Status int    `json:"status"`
+		Detail string `json:"detail"`
+	}
+)
+
+// New starts a fake API. The server has to be closed by the caller.
+func New() (*Server, error) {
+	s := &Server{
+		nextID:        10000,
+		properties:    make(map[string]*property),
+		edgeHostnames: make(map[int]*edgeHostname),
+		changes:       make(map[int]*hapi.ChangeRequest),
+		cpCodes:       make(map[string]*cpCode),
+		zones:         make(map[string]*zone),
+		networkLists:  make(map[string]*networkList),
+		nlActivations: make(map[int]*nlActivation),
+	}
+	s.papiRoutes()
+	s.hapiRoutes()
+	s.dnsRoutes()
+	s.networkListsRoutes()
+	s.Server = httptest.NewTLSServer(s)
+
+	dir, err := os.MkdirTemp("", "fakeapi")
+	if err != nil {
+		s.Server.Close()
+		return nil, fmt.Errorf("cannot create directory of the CA certificate: %w", err)
+	}
+	s.caCertFile = filepath.Join(dir, "ca.pem")
+	if err := os.WriteFile(s.caCertFile, s.caCertPEM(), 0600); err != nil {
+		s.Close()
+		return nil, fmt.Errorf("cannot write the CA certificate: %w", err)
+	}
+	return s, nil
+}
+
+// NewTest starts a fake API closed at the end of the test, and points the provider configured by the test to it
+func NewTest(t *testing.T) *Server {
+	t.Helper()
+	s, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(s.Close)
+	for key, value := range s.Env() {
+		t.Setenv(key, value)
+	}
+	return s
+}
+
+// Close shuts the server down and removes its CA certificate file
+func (s *Server) Close() {
+	s.Server.Close()
+	if s.caCertFile != "" {
+		_ = os.RemoveAll(filepath.Dir(s.caCertFile))
+	}
+}
+
+// Host returns the host and port of the server, to be used as the EdgeGrid host
+func (s *Server) Host() string {
+	return strings.TrimPrefix(s.URL, "https://")
+}
+
+// CACertFile returns the path of the PEM file of the certificate the server is served with
+func (s *Server) CACertFile() string {
+	return s.caCertFile
+}
+
+// Env returns the environment variables pointing the provider to the server, with placeholder credentials
+// of the default edgerc section
+func (s *Server) Env() map[string]string {
+	return map[string]string{
+		"AKAMAI_HOST":          s.Host(),
+		"AKAMAI_CLIENT_TOKEN":  credential,
+		"AKAMAI_CLIENT_SECRET": credential,
+		"AKAMAI_ACCESS_TOKEN":  credential,
+		CACertFileEnv:          s.caCertFile,
+	}
+}
+
+// ServeHTTP serves requests of the provider, one at a time
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "EG1-HMAC-SHA256 ") {
+		writeProblem(w, http.StatusUnauthorized, "The request is not signed with EdgeGrid")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	segments := splitPath(r.URL.Path)
+	methodNotAllowed := false
+	for _, rt := range s.routes {
+		params, ok := rt.match(segments)
+		if !ok {
+			continue
+		}
+		if rt.method != r.Method {
+			methodNotAllowed = true
+			continue
+		}
+		rt.handler(w, r, params)
+		return
+	}
+	if methodNotAllowed {
+		writeProblem(w, http.StatusMethodNotAllowed, fmt.Sprintf("%s is not allowed for %s", r.Method, r.URL.Path))
+		return
+	}
+	writeProblem(w, http.StatusNotFound, fmt.Sprintf("%s is not served by the fake API", r.URL.Path))
+}
+
+// handle registers the handler of requests of the method to paths matching the pattern,
+// in which segments like {id} match any value
+func (s *Server) handle(method, pattern string, handler func(w http.ResponseWriter, r *http.Request, params map[string]string)) {
+	s.routes = append(s.routes, route{method: method, pattern: splitPath(pattern), handler: handler})
+}
+
+func (rt route) match(segments []string) (map[string]string, bool) {
+	if len(segments) != len(rt.pattern) {
+		return nil, false
+	}
+	params := make(map[string]string)
+	for i, p := range rt.pattern {
+		if strings.HasPrefix(p, "{") && strings.HasSuffix(p, "}") {
+			params[strings.Trim(p, "{}")] = segments[i]
+			continue
+		}
+		if p != segments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+func splitPath(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+// newID returns a new numeric ID, unique across all objects of the server
+func (s *Server) newID() int {
+	s.nextID++
+	return s.nextID
+}
+
+func (s *Server) caCertPEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.Certificate().Raw})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeProblem(w http.ResponseWriter, status int, detail string) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(problem{
+		Type:   "https://problems.luna.akamaiapis.net/fakeapi/" + strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "-"),
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	})
+}
+
+// readJSON decodes the request body into v, writing a bad request problem when it is not valid.
+// An empty body leaves v as it is.
+func readJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil && !errors.Is(err, io.EOF) {
+		writeProblem(w, http.StatusBadRequest, fmt.Sprintf("The request body is not valid: %s", err))
+		return false
+	}
+	return true
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedIntKeys[V any](m map[int]V) []int {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
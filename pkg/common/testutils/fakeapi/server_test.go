@@ -0,0 +1,321 @@
+package fakeapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/dns"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/edgegrid"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/hapi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/networklists"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/papi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSession returns a session of the real EdgeGrid clients signing requests to the server
+func newSession(t *testing.T, s *Server) session.Session {
+	sess, err := session.New(
+		session.WithSigner(&edgegrid.Config{Host: s.Host(), ClientToken: credential, ClientSecret: credential, AccessToken: credential}),
+		session.WithClient(s.Client()),
+	)
+	require.NoError(t, err)
+	return sess
+}
+
+func TestServer(t *testing.T) {
+	s := NewTest(t)
+
+	assert.Equal(t, s.Host(), os.Getenv("AKAMAI_HOST"))
+	assert.Equal(t, s.CACertFile(), os.Getenv(CACertFileEnv))
+	ca, err := os.ReadFile(s.CACertFile())
+	require.NoError(t, err)
+	assert.Contains(t, string(ca), "BEGIN CERTIFICATE")
+
+	t.Run("unsigned request", func(t *testing.T) {
+		resp, err := s.Client().Get(s.URL + "/papi/v1/contracts")
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("unknown endpoint", func(t *testing.T) {
+		_, err := papi.Client(newSession(t, s)).GetRuleFormats(context.Background())
+		assert.ErrorIs(t, err, papi.ErrNotFound)
+	})
+}
+
+func TestPAPI(t *testing.T) {
+	s := NewTest(t)
+	client := papi.Client(newSession(t, s))
+	ctx := context.Background()
+
+	groups, err := client.GetGroups(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, GroupID, groups.Groups.Items[0].GroupID)
+	products, err := client.GetProducts(ctx, papi.GetProductsRequest{ContractID: ContractID})
+	require.NoError(t, err)
+	assert.Equal(t, ProductID, products.Products.Items[0].ProductID)
+
+	ehn, err := client.CreateEdgeHostname(ctx, papi.CreateEdgeHostnameRequest{
+		ContractID: ContractID,
+		GroupID:    GroupID,
+		EdgeHostname: papi.EdgeHostnameCreate{
+			ProductID:         ProductID,
+			DomainPrefix:      "www.example.com",
+			DomainSuffix:      "edgesuite.net",
+			IPVersionBehavior: "IPV6_COMPLIANCE",
+		},
+	})
+	require.NoError(t, err)
+
+	created, err := client.CreateProperty(ctx, papi.CreatePropertyRequest{
+		ContractID: ContractID,
+		GroupID:    GroupID,
+		Property:   papi.PropertyCreate{ProductID: ProductID, PropertyName: "example.com", RuleFormat: "v2024-10-21"},
+	})
+	require.NoError(t, err)
+	_, err = client.CreateProperty(ctx, papi.CreatePropertyRequest{
+		ContractID: ContractID,
+		GroupID:    GroupID,
+		Property:   papi.PropertyCreate{ProductID: ProductID, PropertyName: "example.com"},
+	})
+	assert.Error(t, err, "property names are unique")
+
+	rules, err := client.UpdateRuleTree(ctx, papi.UpdateRulesRequest{
+		PropertyID:      created.PropertyID,
+		PropertyVersion: 1,
+		ContractID:      ContractID,
+		GroupID:         GroupID,
+		Rules: papi.RulesUpdate{Comments: "first version", Rules: papi.Rules{
+			Name:      "default",
+			Behaviors: []papi.RuleBehavior{{Name: "origin", Options: papi.RuleOptionsMap{"hostname": "origin.example.com"}}},
+		}},
+	})
+	require.NoError(t, err)
+	tree, err := client.GetRuleTree(ctx, papi.GetRuleTreeRequest{PropertyID: created.PropertyID, PropertyVersion: 1, ContractID: ContractID, GroupID: GroupID})
+	require.NoError(t, err)
+	assert.Equal(t, "v2024-10-21", tree.RuleFormat)
+	assert.Equal(t, "first version", tree.Comments)
+	assert.Equal(t, rules.Etag, tree.Etag)
+	assert.Equal(t, "origin.example.com", tree.Rules.Behaviors[0].Options["hostname"])
+
+	hostnames, err := client.UpdatePropertyVersionHostnames(ctx, papi.UpdatePropertyVersionHostnamesRequest{
+		PropertyID:      created.PropertyID,
+		PropertyVersion: 1,
+		ContractID:      ContractID,
+		GroupID:         GroupID,
+		Hostnames:       []papi.Hostname{{CnameFrom: "www.example.com", EdgeHostnameID: ehn.EdgeHostnameID, CertProvisioningType: "DEFAULT"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "www.example.com.edgesuite.net", hostnames.Hostnames.Items[0].CnameTo)
+
+	activation, err := client.CreateActivation(ctx, papi.CreateActivationRequest{
+		PropertyID: created.PropertyID,
+		ContractID: ContractID,
+		GroupID:    GroupID,
+		Activation: papi.Activation{PropertyVersion: 1, Network: papi.ActivationNetworkStaging, NotifyEmails: []string{"jdoe@example.com"}},
+	})
+	require.NoError(t, err)
+	got, err := client.GetActivation(ctx, papi.GetActivationRequest{PropertyID: created.PropertyID, ActivationID: activation.ActivationID, ContractID: ContractID, GroupID: GroupID})
+	require.NoError(t, err)
+	assert.Equal(t, papi.ActivationStatusActive, got.Activation.Status)
+	assert.Equal(t, papi.ActivationTypeActivate, got.Activation.ActivationType)
+
+	property, err := client.GetProperty(ctx, papi.GetPropertyRequest{PropertyID: created.PropertyID, ContractID: ContractID, GroupID: GroupID})
+	require.NoError(t, err)
+	require.NotNil(t, property.Property.StagingVersion)
+	assert.Equal(t, 1, *property.Property.StagingVersion)
+	assert.Nil(t, property.Property.ProductionVersion)
+
+	_, err = client.UpdateRuleTree(ctx, papi.UpdateRulesRequest{
+		PropertyID:      created.PropertyID,
+		PropertyVersion: 1,
+		ContractID:      ContractID,
+		GroupID:         GroupID,
+		Rules:           papi.RulesUpdate{Rules: papi.Rules{Name: "default"}},
+	})
+	assert.Error(t, err, "activated versions cannot be modified")
+	_, err = client.RemoveProperty(ctx, papi.RemovePropertyRequest{PropertyID: created.PropertyID, ContractID: ContractID, GroupID: GroupID})
+	assert.Error(t, err, "active properties cannot be removed")
+
+	version, err := client.CreatePropertyVersion(ctx, papi.CreatePropertyVersionRequest{
+		PropertyID: created.PropertyID,
+		ContractID: ContractID,
+		GroupID:    GroupID,
+		Version:    papi.PropertyVersionCreate{CreateFromVersion: 1},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, version.PropertyVersion)
+	latest, err := client.GetLatestVersion(ctx, papi.GetLatestVersionRequest{PropertyID: created.PropertyID, ContractID: ContractID, GroupID: GroupID})
+	require.NoError(t, err)
+	assert.Equal(t, 2, latest.Version.PropertyVersion)
+	assert.Equal(t, papi.VersionStatusInactive, latest.Version.StagingStatus)
+	activeVersion, err := client.GetLatestVersion(ctx, papi.GetLatestVersionRequest{PropertyID: created.PropertyID, ActivatedOn: "STAGING", ContractID: ContractID, GroupID: GroupID})
+	require.NoError(t, err)
+	assert.Equal(t, 1, activeVersion.Version.PropertyVersion)
+	copied, err := client.GetPropertyVersionHostnames(ctx, papi.GetPropertyVersionHostnamesRequest{PropertyID: created.PropertyID, PropertyVersion: 2, ContractID: ContractID, GroupID: GroupID})
+	require.NoError(t, err)
+	assert.Equal(t, hostnames.Hostnames.Items, copied.Hostnames.Items)
+
+	found, err := client.SearchProperties(ctx, papi.SearchRequest{Key: papi.SearchKeyHostname, Value: "www.example.com"})
+	require.NoError(t, err)
+	assert.Len(t, found.Versions.Items, 2)
+
+	_, err = client.CreateActivation(ctx, papi.CreateActivationRequest{
+		PropertyID: created.PropertyID,
+		ContractID: ContractID,
+		GroupID:    GroupID,
+		Activation: papi.Activation{PropertyVersion: 1, Network: papi.ActivationNetworkStaging, ActivationType: papi.ActivationTypeDeactivate},
+	})
+	require.NoError(t, err)
+	activations, err := client.GetActivations(ctx, papi.GetActivationsRequest{PropertyID: created.PropertyID, ContractID: ContractID, GroupID: GroupID})
+	require.NoError(t, err)
+	require.Len(t, activations.Activations.Items, 2)
+	assert.Equal(t, papi.ActivationTypeDeactivate, activations.Activations.Items[0].ActivationType)
+
+	_, err = client.RemoveProperty(ctx, papi.RemovePropertyRequest{PropertyID: created.PropertyID, ContractID: ContractID, GroupID: GroupID})
+	require.NoError(t, err)
+	_, err = client.GetProperty(ctx, papi.GetPropertyRequest{PropertyID: created.PropertyID, ContractID: ContractID, GroupID: GroupID})
+	assert.ErrorIs(t, err, papi.ErrNotFound)
+}
+
+func TestHAPI(t *testing.T) {
+	s := NewTest(t)
+	sess := newSession(t, s)
+	ctx := context.Background()
+
+	created, err := papi.Client(sess).CreateEdgeHostname(ctx, papi.CreateEdgeHostnameRequest{
+		ContractID:   ContractID,
+		GroupID:      GroupID,
+		EdgeHostname: papi.EdgeHostnameCreate{ProductID: ProductID, DomainPrefix: "example.com", DomainSuffix: "edgekey.net", IPVersionBehavior: "IPV4"},
+	})
+	require.NoError(t, err)
+
+	client := hapi.Client(sess)
+	id := s.nextID
+	ehn, err := client.GetEdgeHostname(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("ehn_%d", id), created.EdgeHostnameID)
+	assert.Equal(t, "ENHANCED-TLS", ehn.SecurityType)
+	assert.True(t, ehn.UseDefaultTTL)
+
+	updated, err := client.UpdateEdgeHostname(ctx, hapi.UpdateEdgeHostnameRequest{
+		DNSZone:    "edgekey.net",
+		RecordName: "example.com",
+		Body:       []hapi.UpdateEdgeHostnameRequestBody{{Op: "replace", Path: "/ttl", Value: "300"}},
+	})
+	require.NoError(t, err)
+	change, err := client.GetChangeRequest(ctx, hapi.GetChangeRequest{ChangeID: updated.ChangeID})
+	require.NoError(t, err)
+	assert.Equal(t, "SUCCEEDED", change.Status)
+	ehn, err = client.GetEdgeHostname(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, 300, ehn.TTL)
+	assert.False(t, ehn.UseDefaultTTL)
+
+	_, err = client.GetCertificate(ctx, hapi.GetCertificateRequest{DNSZone: "edgekey.net", RecordName: "example.com"})
+	assert.ErrorIs(t, err, hapi.ErrNotFound)
+
+	_, err = client.DeleteEdgeHostname(ctx, hapi.DeleteEdgeHostnameRequest{DNSZone: "edgekey.net", RecordName: "example.com"})
+	require.NoError(t, err)
+	_, err = client.GetEdgeHostname(ctx, id)
+	assert.ErrorIs(t, err, hapi.ErrNotFound)
+}
+
+func TestDNS(t *testing.T) {
+	s := NewTest(t)
+	client := dns.Client(newSession(t, s))
+	ctx := context.Background()
+
+	err := client.CreateZone(ctx, dns.CreateZoneRequest{
+		CreateZone:      &dns.ZoneCreate{Zone: "example.com", Type: "primary", Comment: "managed by Terraform"},
+		ZoneQueryString: dns.ZoneQueryString{Contract: ContractID, Group: GroupID},
+	})
+	require.NoError(t, err)
+	require.NoError(t, client.SaveChangeList(ctx, dns.SaveChangeListRequest{Zone: "example.com"}))
+	require.NoError(t, client.SubmitChangeList(ctx, dns.SubmitChangeListRequest{Zone: "example.com"}))
+
+	zone, err := client.GetZone(ctx, dns.GetZoneRequest{Zone: "example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "PRIMARY", zone.Type)
+	assert.Equal(t, "ACTIVE", zone.ActivationState)
+
+	err = client.CreateRecord(ctx, dns.CreateRecordRequest{
+		Zone:   "example.com",
+		Record: &dns.RecordBody{Name: "www.example.com", RecordType: "A", TTL: 300, Target: []string{"192.0.2.1"}},
+	})
+	require.NoError(t, err)
+	err = client.UpdateRecord(ctx, dns.UpdateRecordRequest{
+		Zone:   "example.com",
+		Record: &dns.RecordBody{Name: "www.example.com", RecordType: "A", TTL: 600, Target: []string{"192.0.2.1", "192.0.2.2"}},
+	})
+	require.NoError(t, err)
+	record, err := client.GetRecord(ctx, dns.GetRecordRequest{Zone: "example.com", Name: "www.example.com", RecordType: "A"})
+	require.NoError(t, err)
+	assert.Equal(t, 600, record.TTL)
+	assert.Equal(t, []string{"192.0.2.1", "192.0.2.2"}, record.Target)
+
+	rdata, err := client.GetRdata(ctx, dns.GetRdataRequest{Zone: "example.com", Name: "example.com", RecordType: "NS"})
+	require.NoError(t, err)
+	assert.Equal(t, nameServers, rdata)
+	recordSets, err := client.GetRecordSets(ctx, dns.GetRecordSetsRequest{Zone: "example.com"})
+	require.NoError(t, err)
+	assert.Len(t, recordSets.RecordSets, 3)
+
+	require.NoError(t, client.DeleteRecord(ctx, dns.DeleteRecordRequest{Zone: "example.com", Name: "www.example.com", RecordType: "A"}))
+	_, err = client.GetRecord(ctx, dns.GetRecordRequest{Zone: "example.com", Name: "www.example.com", RecordType: "A"})
+	assert.Error(t, err)
+}
+
+func TestNetworkLists(t *testing.T) {
+	s := NewTest(t)
+	client := networklists.Client(newSession(t, s))
+	ctx := context.Background()
+
+	created, err := client.CreateNetworkList(ctx, networklists.CreateNetworkListRequest{
+		Name: "Blocked IPs", Type: "IP", Description: "blocked", List: []string{"192.0.2.0/24"},
+	})
+	require.NoError(t, err)
+	assert.Regexp(t, `^\d+_BLOCKEDIPS$`, created.UniqueID)
+
+	_, err = client.UpdateNetworkList(ctx, networklists.UpdateNetworkListRequest{
+		UniqueID: created.UniqueID, Name: "Blocked IPs", Type: "IP", SyncPoint: 1, List: []string{"192.0.2.0/24"},
+	})
+	assert.Error(t, err, "updates of another sync point are rejected")
+	_, err = client.UpdateNetworkList(ctx, networklists.UpdateNetworkListRequest{
+		UniqueID: created.UniqueID, Name: "Blocked IPs", Type: "IP", SyncPoint: 0, List: []string{"192.0.2.0/24", "198.51.100.0/24"},
+	})
+	require.NoError(t, err)
+
+	list, err := client.GetNetworkList(ctx, networklists.GetNetworkListRequest{UniqueID: created.UniqueID})
+	require.NoError(t, err)
+	assert.Equal(t, 1, list.SyncPoint)
+	assert.Equal(t, 2, list.ElementCount)
+
+	status, err := client.GetActivations(ctx, networklists.GetActivationsRequest{UniqueID: created.UniqueID, Network: "STAGING"})
+	require.NoError(t, err)
+	assert.Equal(t, string(networklists.StatusInactive), status.ActivationStatus)
+
+	activated, err := client.CreateActivations(ctx, networklists.CreateActivationsRequest{UniqueID: created.UniqueID, Network: "STAGING", Comments: "block"})
+	require.NoError(t, err)
+	activation, err := client.GetActivation(ctx, networklists.GetActivationRequest{ActivationID: activated.ActivationID})
+	require.NoError(t, err)
+	assert.Equal(t, string(networklists.StatusActive), activation.ActivationStatus)
+	assert.Equal(t, 1, activation.NetworkList.SyncPoint)
+
+	_, err = client.RemoveNetworkList(ctx, networklists.RemoveNetworkListRequest{UniqueID: created.UniqueID})
+	assert.Error(t, err, "active lists cannot be removed")
+	_, err = client.RemoveActivations(ctx, networklists.RemoveActivationsRequest{UniqueID: created.UniqueID, Network: "STAGING"})
+	require.NoError(t, err)
+	_, err = client.RemoveNetworkList(ctx, networklists.RemoveNetworkListRequest{UniqueID: created.UniqueID})
+	require.NoError(t, err)
+
+	lists, err := client.GetNetworkLists(ctx, networklists.GetNetworkListsRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, lists.NetworkLists)
+}
@@ -0,0 +1,54 @@
+package testutils
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/schemasnapshot"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/subprovider"
+	"github.com/spf13/cast"
+)
+
+// UpdateSchemaSnapshotEnv is the environment variable which makes CheckSchemaSnapshot write the current snapshot
+const UpdateSchemaSnapshotEnv = "UPDATE_SCHEMA_SNAPSHOT"
+
+// CheckSchemaSnapshot fails the test when the schemas of the resources and data sources of the subproviders differ from
+// the snapshot committed at path, listing breaking changes first. With UPDATE_SCHEMA_SNAPSHOT=true it writes the
+// current snapshot to path instead, which is meant to be reviewed and committed along with the changed schemas.
+func CheckSchemaSnapshot(t *testing.T, path string, subproviders ...subprovider.Subprovider) {
+	t.Helper()
+
+	current, err := schemasnapshot.New(context.Background(), akamai.NewSDKProvider(subproviders...)(),
+		akamai.NewFrameworkProvider(subproviders...)())
+	if err != nil {
+		t.Fatalf("taking schema snapshot: %s", err)
+	}
+	if cast.ToBool(os.Getenv(UpdateSchemaSnapshotEnv)) {
+		if err = current.WriteFile(path); err != nil {
+			t.Fatalf("writing schema snapshot: %s", err)
+		}
+		return
+	}
+
+	committed, err := schemasnapshot.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%s, run the test with %s=true to create the snapshot", err, UpdateSchemaSnapshotEnv)
+	}
+	changes := schemasnapshot.Compare(committed, current)
+	if len(changes) == 0 {
+		return
+	}
+	var breaking, nonBreaking []string
+	for _, change := range changes {
+		if change.Breaking {
+			breaking = append(breaking, change.String())
+		} else {
+			nonBreaking = append(nonBreaking, change.String())
+		}
+	}
+	t.Errorf("schemas differ from the snapshot %s, run the test with %s=true to update it if the changes are intended:\n%s",
+		path, UpdateSchemaSnapshotEnv, strings.Join(append(breaking, nonBreaking...), "\n"))
+}
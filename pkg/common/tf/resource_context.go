@@ -0,0 +1,19 @@
+package tf
+
+import "context"
+
+// resourceTypeKey is the context key of the type of the resource or data source an operation is performed for
+type resourceTypeKey struct{}
+
+// WithResourceType returns the context of an operation performed for the given resource or data source type,
+// so that the session transport can tell which resource sent a request
+func WithResourceType(ctx context.Context, resourceType string) context.Context {
+	return context.WithValue(ctx, resourceTypeKey{}, resourceType)
+}
+
+// ResourceTypeFromContext returns the type of the resource or data source the operation is performed for,
+// or an empty string for requests made outside of resource operations, e.g. while configuring the provider
+func ResourceTypeFromContext(ctx context.Context) string {
+	resourceType, _ := ctx.Value(resourceTypeKey{}).(string)
+	return resourceType
+}
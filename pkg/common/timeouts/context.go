@@ -0,0 +1,15 @@
+package timeouts
+
+import (
+	"context"
+	"time"
+)
+
+// WithDefault returns the context with the timeout applied, unless the context already has a deadline,
+// e.g. the one of an operation of an SDK resource set from its timeouts block
+func WithDefault(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
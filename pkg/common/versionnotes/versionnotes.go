@@ -0,0 +1,149 @@
+// Package versionnotes provides the notes of versions and activations created by the provider, rendered from
+// the provider level template, so that every version is traceable to the Terraform run that produced it
+package versionnotes
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+)
+
+type (
+	// Config configures the notes of versions and activations created by the provider
+	Config struct {
+		// Template is the Go template of the notes, the configured notes are used as they are when empty
+		Template string
+		// ChangeID identifies the change applied by the Terraform run, e.g. a ticket number
+		ChangeID string
+	}
+
+	// Data is what the template is executed with
+	Data struct {
+		// ResourceType is the type of the resource creating the version or activation, e.g. akamai_property.
+		// Terraform does not pass the address of the resource, e.g. akamai_property.example, to providers.
+		ResourceType string
+		// Name is the name of the versioned object, e.g. the property name, or the activated one
+		Name string
+		// Notes are the notes configured for the resource, if any
+		Notes string
+		// Workspace is the Terraform workspace from TF_WORKSPACE or TFC_WORKSPACE_NAME, default when not set
+		Workspace string
+		// OperationID is the ID of the provider operation, also found in the provider logs
+		OperationID string
+		// ChangeID is the change ID of the provider configuration
+		ChangeID string
+		// Timestamp is the time of rendering in UTC
+		Timestamp time.Time
+	}
+)
+
+// ErrTemplate is returned when the template of the notes cannot be parsed or executed
+var ErrTemplate = errors.New("version notes template")
+
+var global = struct {
+	mu       sync.Mutex
+	config   Config
+	template *template.Template
+}{}
+
+// now returns the timestamp of rendered notes
+var now = time.Now
+
+// Configure sets the template and the change ID of the notes of the process. Configuring the same config again
+// is a no-op, so that both providers served by the plugin can configure it.
+func Configure(cfg Config) error {
+	global.mu.Lock()
+	defer global.mu.Unlock()
+
+	if cfg == global.config {
+		return nil
+	}
+	if cfg.Template == "" {
+		global.config, global.template = cfg, nil
+		return nil
+	}
+
+	tmpl, err := template.New("version_notes_template").Parse(cfg.Template)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrTemplate, err)
+	}
+	// executing the template once reports references to unknown fields right away instead of on the first change
+	if err := tmpl.Execute(&strings.Builder{}, Data{Timestamp: now().UTC()}); err != nil {
+		return fmt.Errorf("%w: %s", ErrTemplate, err)
+	}
+	global.config, global.template = cfg, tmpl
+	return nil
+}
+
+// Enabled tells if a template is configured. The notes of resources then differ from the notes sent to the API.
+func Enabled() bool {
+	global.mu.Lock()
+	defer global.mu.Unlock()
+
+	return global.template != nil
+}
+
+// Render returns the notes of a version or activation created by the given resource type, the notes configured
+// for the resource when no template is configured
+func Render(m meta.Meta, resourceType, name, notes string) (string, error) {
+	global.mu.Lock()
+	tmpl, changeID := global.template, global.config.ChangeID
+	global.mu.Unlock()
+
+	if tmpl == nil {
+		return notes, nil
+	}
+
+	var rendered strings.Builder
+	err := tmpl.Execute(&rendered, Data{
+		ResourceType: resourceType,
+		Name:         name,
+		Notes:        notes,
+		Workspace:    workspace(),
+		OperationID:  m.OperationID(),
+		ChangeID:     changeID,
+		Timestamp:    now().UTC(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrTemplate, err)
+	}
+	return strings.TrimSpace(rendered.String()), nil
+}
+
+// Log writes the notes of a version which cannot hold notes, e.g. of an Image and Video Manager policy,
+// to the provider log, so that the version is still traceable. Nothing is logged when no template is configured.
+func Log(m meta.Meta, resourceType, name string) error {
+	if !Enabled() {
+		return nil
+	}
+	notes, err := Render(m, resourceType, name, "")
+	if err != nil {
+		return err
+	}
+	m.Log("VersionNotes", resourceType).Infof("version of %s created: %s", name, notes)
+	return nil
+}
+
+// State returns the notes to keep in the state for notes read from the API. The API holds the rendered notes
+// when a template is configured, so the notes of the state are kept to avoid a diff with the configuration.
+func State(stateNotes, apiNotes string) string {
+	if Enabled() {
+		return stateNotes
+	}
+	return apiNotes
+}
+
+func workspace() string {
+	for _, env := range []string{"TF_WORKSPACE", "TFC_WORKSPACE_NAME"} {
+		if v := os.Getenv(env); v != "" {
+			return v
+		}
+	}
+	return "default"
+}
@@ -0,0 +1,69 @@
+package versionnotes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigure(t *testing.T) {
+	defer func() { require.NoError(t, Configure(Config{})) }()
+
+	assert.ErrorIs(t, Configure(Config{Template: "{{.Notes"}), ErrTemplate)
+	assert.ErrorContains(t, Configure(Config{Template: "{{.Ticket}}"}), "can't evaluate field Ticket")
+	assert.False(t, Enabled())
+
+	require.NoError(t, Configure(Config{Template: "{{.Notes}}"}))
+	assert.True(t, Enabled())
+
+	require.NoError(t, Configure(Config{}))
+	assert.False(t, Enabled())
+}
+
+func TestRender(t *testing.T) {
+	defer func() { require.NoError(t, Configure(Config{})) }()
+	defer func() { now = time.Now }()
+	now = func() time.Time { return time.Date(2025, 11, 28, 10, 30, 0, 0, time.FixedZone("CET", 3600)) }
+	t.Setenv("TF_WORKSPACE", "")
+	t.Setenv("TFC_WORKSPACE_NAME", "")
+
+	m, err := meta.New(session.Must(session.New()), hclog.NewNullLogger(), "f3b0ad5e")
+	require.NoError(t, err)
+
+	t.Run("no template", func(t *testing.T) {
+		notes, err := Render(m, "akamai_property", "example.com", "manual notes")
+		require.NoError(t, err)
+		assert.Equal(t, "manual notes", notes)
+		assert.Equal(t, "from API", State("from state", "from API"))
+	})
+
+	t.Run("template", func(t *testing.T) {
+		require.NoError(t, Configure(Config{
+			Template: `{{with .Notes}}{{.}} {{end}}[{{.ChangeID}} {{.ResourceType}} {{.Name}} {{.Workspace}} {{.OperationID}} {{.Timestamp.Format "2006-01-02T15:04Z07:00"}}]` + "\n",
+			ChangeID: "CHG-123",
+		}))
+		notes, err := Render(m, "akamai_property", "example.com", "manual notes")
+		require.NoError(t, err)
+		assert.Equal(t, "manual notes [CHG-123 akamai_property example.com default f3b0ad5e 2025-11-28T09:30Z]", notes)
+		assert.Equal(t, "from state", State("from state", "from API"))
+	})
+
+	t.Run("workspace", func(t *testing.T) {
+		require.NoError(t, Configure(Config{Template: "{{.Workspace}}"}))
+		t.Setenv("TFC_WORKSPACE_NAME", "cdn-prod")
+		notes, err := Render(m, "akamai_property", "example.com", "")
+		require.NoError(t, err)
+		assert.Equal(t, "cdn-prod", notes)
+	})
+
+	t.Run("execution error", func(t *testing.T) {
+		require.NoError(t, Configure(Config{Template: `{{if .ResourceType}}{{index .Name 20}}{{end}}`}))
+		_, err := Render(m, "akamai_property", "example.com", "")
+		assert.ErrorIs(t, err, ErrTemplate)
+	})
+}
@@ -17,7 +17,26 @@ type Logger struct {
 	hclog hclog.Logger
 }
 
-const defaultTimestampFormat = "2006/01/02 03:04:05"
+const (
+	defaultTimestampFormat = "2006/01/02 03:04:05"
+
+	// formatEnv is the environment variable selecting the log format, "json" for one JSON object per line
+	formatEnv = "AKAMAI_LOG_FORMAT"
+
+	// OperationIDKey is the key of the operation ID field
+	OperationIDKey = "OperationID"
+)
+
+var (
+	// jsonFormat tells if logs are written as JSON objects
+	jsonFormat bool
+
+	// jsonFieldNames are names of fields in JSON objects, which are easier to query than names of the text format
+	jsonFieldNames = map[string]string{
+		OperationIDKey:     "operation_id",
+		"AccountSwitchKey": "account_switch_key",
+	}
+)
 
 func init() {
 	if fmt, ok := os.LookupEnv("AKAMAI_TS_FORMAT"); ok {
@@ -25,6 +44,10 @@ func init() {
 	} else {
 		hclog.DefaultOptions.TimeFormat = defaultTimestampFormat
 	}
+	if strings.EqualFold(os.Getenv(formatEnv), "json") {
+		hclog.DefaultOptions.JSONFormat = true
+		jsonFormat = true
+	}
 }
 
 // HCLog returns Logger's hclog
@@ -61,33 +84,77 @@ func FromHCLog(hclog hclog.Logger) *Logger {
 	return rval
 }
 
-// Get returns a global log object, there is no context like operation id
+// Get returns a global log object, there is no context like operation id.
+// The arguments name the subprovider and the function logging, e.g. ("PAPI", "resourcePropertyCreate").
 func Get(args ...interface{}) *Logger {
-	return FromHCLog(hclog.Default().With(args...))
+	return FromHCLog(hclog.Default().With(FunctionArgs(args...)...))
 }
 
-// FromContext returns the logger from the context
+// FromContext returns the logger from the context with the given key value pairs
 func FromContext(ctx context.Context, args ...interface{}) *Logger {
-	return FromHCLog(hclog.FromContext(ctx).With(args...))
+	return FromHCLog(hclog.FromContext(ctx).With(fieldArgs(args)...))
+}
+
+// FunctionArgs returns hclog arguments of a logger of the function of a subprovider, e.g. ("PAPI", "resourcePropertyCreate").
+// In the JSON format they are logged as subprovider and function fields, followed by remaining key value pairs.
+func FunctionArgs(args ...interface{}) []interface{} {
+	if !jsonFormat || len(args) == 0 {
+		return args
+	}
+	named := []interface{}{"subprovider", args[0]}
+	if len(args) > 1 {
+		named = append(named, "function", args[1])
+	}
+	return append(named, fieldArgs(args[2:])...)
+}
+
+// fieldArgs renames keys of key value pairs in the JSON format
+func fieldArgs(args []interface{}) []interface{} {
+	if !jsonFormat {
+		return args
+	}
+	renamed := make([]interface{}, len(args))
+	for i, arg := range args {
+		if key, ok := arg.(string); ok && i%2 == 0 {
+			arg = fieldName(key)
+		}
+		renamed[i] = arg
+	}
+	return renamed
+}
+
+func fieldName(key string) string {
+	if name, ok := jsonFieldNames[key]; jsonFormat && ok {
+		return name
+	}
+	return key
 }
 
-// HandleLog implements the logic for handling log events
+// HandleLog implements the logic for handling log events.
+// Secrets in the message and fields, e.g. in HTTP request dumps, are redacted before being logged.
 func (l *Logger) HandleLog(e *log.Entry) error {
 	fields := make([]interface{}, 0)
 
 	for k, v := range e.Fields {
-		fields = append(fields, k, v)
+		switch val := v.(type) {
+		case string:
+			v = Redact(val)
+		case error:
+			v = Redact(val.Error())
+		}
+		fields = append(fields, fieldName(k), v)
 	}
+	message := Redact(e.Message)
 
 	switch e.Level {
 	case log.DebugLevel:
-		l.hclog.Debug(e.Message, fields...)
+		l.hclog.Debug(message, fields...)
 	case log.InfoLevel:
-		l.hclog.Info(e.Message, fields...)
+		l.hclog.Info(message, fields...)
 	case log.WarnLevel:
-		l.hclog.Warn(e.Message, fields...)
+		l.hclog.Warn(message, fields...)
 	case log.ErrorLevel:
-		l.hclog.Error(e.Message, fields...)
+		l.hclog.Error(message, fields...)
 	case log.FatalLevel:
 		panic(e.Message)
 	}
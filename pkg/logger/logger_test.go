@@ -0,0 +1,49 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONFormat(t *testing.T) {
+	jsonFormat = true
+	defer func() { jsonFormat = false }()
+
+	var buf bytes.Buffer
+	base := hclog.New(&hclog.LoggerOptions{Output: &buf, JSONFormat: true, Level: hclog.Debug})
+	operationLog := FromHCLog(base.With(fieldArgs([]interface{}{OperationIDKey, "op-1"})...))
+	log := FromHCLog(operationLog.HCLog().With(FunctionArgs("PAPI", "resourcePropertyCreate")...))
+
+	log.WithField("AccountSwitchKey", "1-ABCD").
+		WithError(errors.New(`{"password": "pass"}`)).
+		Error("Authorization: EG1-HMAC-SHA256 client_token=akab-1")
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	delete(entry, "@timestamp")
+	assert.Equal(t, map[string]any{
+		"@level":             "error",
+		"@message":           "Authorization: REDACTED",
+		"operation_id":       "op-1",
+		"subprovider":        "PAPI",
+		"function":           "resourcePropertyCreate",
+		"account_switch_key": "1-ABCD",
+		"error":              `{"password": "REDACTED"}`,
+	}, entry)
+}
+
+func TestTextFormat(t *testing.T) {
+	var buf bytes.Buffer
+	base := hclog.New(&hclog.LoggerOptions{Output: &buf, Level: hclog.Debug})
+	log := FromHCLog(base.With(FunctionArgs("PAPI", "resourcePropertyCreate")...))
+
+	log.WithField("AccountSwitchKey", "1-ABCD").Info("creating property")
+
+	assert.Contains(t, buf.String(), "[INFO]  creating property: PAPI=resourcePropertyCreate AccountSwitchKey=1-ABCD")
+}
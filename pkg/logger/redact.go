@@ -0,0 +1,84 @@
+package logger
+
+import (
+	"regexp"
+	"strings"
+)
+
+// RedactedValue replaces secrets in logs and recorded HTTP traffic
+const RedactedValue = "REDACTED"
+
+var (
+	// RedactedHeaders are headers carrying credentials, e.g. the EdgeGrid Authorization header
+	RedactedHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+	// redactedFields are names of JSON fields holding secrets, lowercase
+	redactedFields = map[string]struct{}{
+		"access_token":            {},
+		"accesstoken":             {},
+		"client_secret":           {},
+		"clientsecret":            {},
+		"client_token":            {},
+		"clienttoken":             {},
+		"cloud_secret_access_key": {},
+		"cloudsecretaccesskey":    {},
+		"password":                {},
+		"privatekey":              {},
+		"private_key":             {},
+		"secret":                  {},
+	}
+
+	redactedHeaderLine = regexp.MustCompile(`(?im)^((?:` + strings.Join(RedactedHeaders, "|") + `):)[^\r\n]*`)
+	redactedJSONField  = regexp.MustCompile(`(?i)("(?:` + strings.Join(fieldNames(), "|") + `)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+	redactedPEM        = regexp.MustCompile(`-----BEGIN ([A-Z ]*(?:PRIVATE KEY|CERTIFICATE REQUEST))-----[\s\S]*?-----END [A-Z ]*-----`)
+)
+
+// IsSensitiveField tells if the JSON field with the given name holds a secret
+func IsSensitiveField(name string) bool {
+	_, ok := redactedFields[strings.ToLower(name)]
+	return ok
+}
+
+// IsSensitiveValue tells if the value is a private key or a certificate signing request in the PEM format
+func IsSensitiveValue(value string) bool {
+	return strings.Contains(value, "PRIVATE KEY-----") || strings.Contains(value, "CERTIFICATE REQUEST-----")
+}
+
+// Redact masks secrets in a log message, e.g. an HTTP request dump: values of credential headers,
+// JSON fields holding secrets, and PEM encoded private keys and certificate signing requests
+func Redact(s string) string {
+	s = redactedHeaderLine.ReplaceAllString(s, "$1 "+RedactedValue)
+	s = redactedJSONField.ReplaceAllString(s, `$1"`+RedactedValue+`"`)
+	return redactedPEM.ReplaceAllString(s, "-----BEGIN $1-----"+RedactedValue+"-----END $1-----")
+}
+
+// RedactJSON masks secrets in the value of a decoded JSON document in place, and returns it
+func RedactJSON(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, field := range val {
+			if IsSensitiveField(k) {
+				val[k] = RedactedValue
+				continue
+			}
+			val[k] = RedactJSON(field)
+		}
+	case []any:
+		for i, item := range val {
+			val[i] = RedactJSON(item)
+		}
+	case string:
+		if IsSensitiveValue(val) {
+			return RedactedValue
+		}
+	}
+	return v
+}
+
+func fieldNames() []string {
+	names := make([]string, 0, len(redactedFields))
+	for name := range redactedFields {
+		names = append(names, regexp.QuoteMeta(name))
+	}
+	return names
+}
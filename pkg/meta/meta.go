@@ -4,6 +4,8 @@ package meta
 import (
 	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/session"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/logger"
@@ -24,13 +26,71 @@ type (
 
 		// Session returns the operation API session
 		Session() session.Session
+
+		// AccountSwitchKey returns the account switch key requests of the session are signed with,
+		// empty for the account of the provider credentials
+		AccountSwitchKey() string
+
+		// WithAccountSwitchKey returns the meta whose session signs requests with the given account switch key,
+		// or the meta itself for the empty key
+		WithAccountSwitchKey(accountSwitchKey string) (Meta, error)
+
+		// Defaults returns the provider level values resources fall back to when their attributes are not set
+		Defaults() Defaults
+
+		// ChangeFreeze returns the windows of the provider level change freeze
+		ChangeFreeze() []FreezeWindow
+	}
+
+	// Defaults holds the provider level default contract, group, notification emails and deletion protection
+	Defaults struct {
+		// ContractID is the ID of the default contract without the ctr_ prefix
+		ContractID string
+
+		// GroupID is the ID of the default group without the grp_ prefix
+		GroupID string
+
+		// NotificationEmails are the emails notified about activations
+		NotificationEmails []string
+
+		// DeletionProtection tells if resources which can be active on the network refuse deletion while active
+		DeletionProtection bool
+	}
+
+	// FreezeWindow is a period during which activations to its networks are not allowed
+	FreezeWindow struct {
+		// Start and End delimit the window, the end excluded
+		Start, End time.Time
+
+		// Networks are the frozen networks, staging or production, all networks when empty
+		Networks []string
+
+		// Reason tells users why changes are frozen, e.g. Black Friday
+		Reason string
 	}
 
 	// OperationMeta is the implementation of Meta interface
 	OperationMeta struct {
-		operationID string
-		log         hclog.Logger
-		sess        session.Session
+		operationID      string
+		log              hclog.Logger
+		sess             session.Session
+		accountSwitchKey string
+		accounts         *accountSessions
+		defaults         Defaults
+		changeFreeze     []FreezeWindow
+	}
+
+	// SessionFactory creates a session signing requests with the given account switch key
+	SessionFactory func(accountSwitchKey string) (session.Session, error)
+
+	// Option configures OperationMeta
+	Option func(*OperationMeta)
+
+	// accountSessions holds sessions created for account switch keys, shared by all metas of an operation
+	accountSessions struct {
+		mu         sync.Mutex
+		newSession SessionFactory
+		sessions   map[string]*OperationMeta
 	}
 )
 
@@ -40,19 +100,50 @@ var ErrNilLog = errors.New("nil log argument")
 // ErrNilSession is an error returned from New(...) when session argument is nil
 var ErrNilSession = errors.New("nil session argument")
 
+// ErrAccountSwitchUnsupported is returned from WithAccountSwitchKey(...) when meta has no session factory
+var ErrAccountSwitchUnsupported = errors.New("account switch key is not supported")
+
 // New returns a new OperationMeta
-func New(sess session.Session, log hclog.Logger, operationID string) (*OperationMeta, error) {
+func New(sess session.Session, log hclog.Logger, operationID string, opts ...Option) (*OperationMeta, error) {
 	if log == nil {
 		return nil, ErrNilLog
 	}
 	if sess == nil {
 		return nil, ErrNilSession
 	}
-	return &OperationMeta{
+	m := &OperationMeta{
 		operationID: operationID,
 		sess:        sess,
 		log:         log,
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m, nil
+}
+
+// WithSessionFactory allows the meta to create sessions signing requests with account switch keys
+func WithSessionFactory(newSession SessionFactory) Option {
+	return func(m *OperationMeta) {
+		m.accounts = &accountSessions{
+			newSession: newSession,
+			sessions:   map[string]*OperationMeta{m.accountSwitchKey: m},
+		}
+	}
+}
+
+// WithDefaults sets the values resources fall back to when their attributes are not set
+func WithDefaults(defaults Defaults) Option {
+	return func(m *OperationMeta) {
+		m.defaults = defaults
+	}
+}
+
+// WithChangeFreeze sets the windows during which activations are not allowed
+func WithChangeFreeze(windows []FreezeWindow) Option {
+	return func(m *OperationMeta) {
+		m.changeFreeze = windows
+	}
 }
 
 // Must performs type assertion on m and panics if m does not hold Meta value
@@ -66,7 +157,7 @@ func Must(m any) Meta {
 
 // Log creates a logger for the provider from the meta
 func (m *OperationMeta) Log(args ...interface{}) log.Interface {
-	return logger.FromHCLog(m.log.With(args...))
+	return logger.FromHCLog(m.log.With(logger.FunctionArgs(args...)...))
 }
 
 // OperationID returns the operation id from the meta
@@ -78,3 +169,53 @@ func (m *OperationMeta) OperationID() string {
 func (m *OperationMeta) Session() session.Session {
 	return m.sess
 }
+
+// AccountSwitchKey returns the account switch key of the meta session
+func (m *OperationMeta) AccountSwitchKey() string {
+	return m.accountSwitchKey
+}
+
+// Defaults returns the provider level defaults of the meta
+func (m *OperationMeta) Defaults() Defaults {
+	return m.defaults
+}
+
+// ChangeFreeze returns the change freeze windows of the meta
+func (m *OperationMeta) ChangeFreeze() []FreezeWindow {
+	return m.changeFreeze
+}
+
+// WithAccountSwitchKey returns the meta whose session signs requests with the given account switch key.
+// Sessions are created once per key and reused afterwards.
+func (m *OperationMeta) WithAccountSwitchKey(accountSwitchKey string) (Meta, error) {
+	if accountSwitchKey == m.accountSwitchKey {
+		return m, nil
+	}
+	if m.accounts == nil {
+		return nil, ErrAccountSwitchUnsupported
+	}
+
+	m.accounts.mu.Lock()
+	defer m.accounts.mu.Unlock()
+
+	if accountMeta, ok := m.accounts.sessions[accountSwitchKey]; ok {
+		return accountMeta, nil
+	}
+
+	sess, err := m.accounts.newSession(accountSwitchKey)
+	if err != nil {
+		return nil, fmt.Errorf("creating session for account switch key %q: %w", accountSwitchKey, err)
+	}
+	accountMeta := &OperationMeta{
+		operationID:      m.operationID,
+		log:              m.log,
+		sess:             sess,
+		accountSwitchKey: accountSwitchKey,
+		accounts:         m.accounts,
+		defaults:         m.defaults,
+		changeFreeze:     m.changeFreeze,
+	}
+	m.accounts.sessions[accountSwitchKey] = accountMeta
+
+	return accountMeta, nil
+}
@@ -1,7 +1,9 @@
 package meta
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/session"
 	"github.com/hashicorp/go-hclog"
@@ -61,3 +63,56 @@ func TestMust(t *testing.T) {
 		})
 	})
 }
+
+func TestWithAccountSwitchKey(t *testing.T) {
+	var logger = hclog.New(hclog.DefaultOptions)
+
+	t.Run("without session factory", func(t *testing.T) {
+		meta, err := New(session.Must(session.New()), logger, "opID")
+		require.NoError(t, err)
+
+		same, err := meta.WithAccountSwitchKey("")
+		require.NoError(t, err)
+		assert.Equal(t, meta, same)
+
+		_, err = meta.WithAccountSwitchKey("1-ABCD")
+		assert.ErrorIs(t, err, ErrAccountSwitchUnsupported)
+	})
+
+	t.Run("with session factory", func(t *testing.T) {
+		var created []string
+		factory := func(accountSwitchKey string) (session.Session, error) {
+			created = append(created, accountSwitchKey)
+			if accountSwitchKey == "invalid" {
+				return nil, errors.New("oops")
+			}
+			return session.New()
+		}
+		defaults := Defaults{ContractID: "1-AB123", GroupID: "12345", NotificationEmails: []string{"jsmith@example.com"}}
+		changeFreeze := []FreezeWindow{{Start: time.Now(), End: time.Now().Add(time.Hour), Networks: []string{"production"}}}
+		meta, err := New(session.Must(session.New()), logger, "opID", WithSessionFactory(factory), WithDefaults(defaults), WithChangeFreeze(changeFreeze))
+		require.NoError(t, err)
+		assert.Empty(t, meta.AccountSwitchKey())
+
+		accountMeta, err := meta.WithAccountSwitchKey("1-ABCD")
+		require.NoError(t, err)
+		assert.Equal(t, "1-ABCD", accountMeta.AccountSwitchKey())
+		assert.Equal(t, "opID", accountMeta.OperationID())
+		assert.Equal(t, defaults, accountMeta.Defaults())
+		assert.Equal(t, changeFreeze, accountMeta.ChangeFreeze())
+		assert.NotSame(t, meta.Session(), accountMeta.Session())
+
+		again, err := meta.WithAccountSwitchKey("1-ABCD")
+		require.NoError(t, err)
+		assert.Same(t, accountMeta, again)
+
+		base, err := accountMeta.WithAccountSwitchKey("")
+		require.NoError(t, err)
+		assert.Same(t, meta, base)
+
+		_, err = meta.WithAccountSwitchKey("invalid")
+		assert.ErrorContains(t, err, "oops")
+
+		assert.Equal(t, []string{"1-ABCD", "invalid"}, created)
+	})
+}
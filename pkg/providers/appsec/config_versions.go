@@ -8,11 +8,16 @@ import (
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/appsec"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/cache"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/versionnotes"
 	akameta "github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
 )
 
 // Utility functions for determining current and latest versions of a security
 // configuration, and for identifying a modifiable (editable) version.
+//
+// Version information changes while resources are applied, so it is cached
+// in memory only and never shared across provider runs.
 
 var (
 	configCloneMutex   sync.Mutex
@@ -30,6 +35,12 @@ var (
 	GetLatestConfigVersion = getLatestConfigVersion
 )
 
+// accountCacheBucket returns the bucket keeping values read with the account switch key of m,
+// so that values read for different accounts never mix
+func accountCacheBucket(m interface{}) cache.Bucket {
+	return cache.ForAccount(cache.MemoryBucketName(SubproviderName), akameta.Must(m).AccountSwitchKey())
+}
+
 // getModifiableConfigVersion returns the number of the latest editable version
 // of the given security configuration. If the most recent version is not editable
 // (because it is active in staging or production) a new version is cloned and the
@@ -44,7 +55,7 @@ func getModifiableConfigVersion(ctx context.Context, configID int, resource stri
 	// If the version info is in the cache, return it immediately.
 	cacheKey := fmt.Sprintf("%s:%d", "getModifiableConfigVersion", configID)
 	configuration := &appsec.GetConfigurationResponse{}
-	if err := cache.Get(cache.BucketName(SubproviderName), cacheKey, configuration); err == nil {
+	if err := cache.Get(accountCacheBucket(meta), cacheKey, configuration); err == nil {
 		logger.Debugf("Resource %s returning modifiable version %d from cache", resource, configuration.LatestVersion)
 		return configuration.LatestVersion, nil
 	}
@@ -57,7 +68,7 @@ func getModifiableConfigVersion(ctx context.Context, configID int, resource stri
 	}()
 
 	// If the version info is in the cache, return it immediately.
-	err := cache.Get(cache.BucketName(SubproviderName), cacheKey, configuration)
+	err := cache.Get(accountCacheBucket(meta), cacheKey, configuration)
 	if err == nil {
 		logger.Debugf("Resource %s returning modifiable version %d from cache", resource, configuration.LatestVersion)
 		return configuration.LatestVersion, nil
@@ -81,7 +92,7 @@ func getModifiableConfigVersion(ctx context.Context, configID int, resource stri
 	stagingVersion := configuration.StagingVersion
 	productionVersion := configuration.ProductionVersion
 	if latestVersion != stagingVersion && latestVersion != productionVersion {
-		if err := cache.Set(cache.BucketName(SubproviderName), cacheKey, configuration); err != nil {
+		if err := cache.Set(accountCacheBucket(meta), cacheKey, configuration); err != nil {
 			if !errors.Is(err, cache.ErrDisabled) {
 				logger.Errorf("unable to set latestVersion %d into cache")
 			}
@@ -101,9 +112,29 @@ func getModifiableConfigVersion(ctx context.Context, configID int, resource stri
 		logger.Errorf("error calling 'createConfigurationVersionClone': %s", err.Error())
 		return 0, err
 	}
+	if versionnotes.Enabled() {
+		resourceType := tf.ResourceTypeFromContext(ctx)
+		if resourceType == "" {
+			resourceType = resource
+		}
+		notes, err := versionnotes.Render(meta, resourceType, configuration.Name, "")
+		if err != nil {
+			return 0, err
+		}
+		if _, err := client.UpdateVersionNotes(ctx, appsec.UpdateVersionNotesRequest{
+			ConfigID: configID,
+			Version:  ccr.Version,
+			Notes:    notes,
+		}); err != nil {
+			logger.Errorf("error calling 'updateVersionNotes': %s", err.Error())
+			return 0, err
+		}
+	}
 
 	configuration.LatestVersion = ccr.Version
-	if err := cache.Set(cache.BucketName(SubproviderName), cacheKey, configuration); err != nil && !errors.Is(err, cache.ErrDisabled) {
+	// the latest version has just changed
+	cache.Invalidate(accountCacheBucket(meta), fmt.Sprintf("%s:%d:", "getLatestConfigVersion", configID))
+	if err := cache.Set(accountCacheBucket(meta), cacheKey, configuration); err != nil && !errors.Is(err, cache.ErrDisabled) {
 		logger.Errorf("unable to set latestVersion %d into cache: %s", err.Error())
 	}
 
@@ -122,7 +153,7 @@ func getLatestConfigVersion(ctx context.Context, configID int, m interface{}) (i
 	// Return the cached value if we have one
 	cacheKey := fmt.Sprintf("%s:%d", "getLatestConfigVersion", configID)
 	configuration := &appsec.GetConfigurationResponse{}
-	if err := cache.Get(cache.BucketName(SubproviderName), cacheKey, configuration); err == nil {
+	if err := cache.Get(accountCacheBucket(meta), cacheKey, configuration); err == nil {
 		logger.Debugf("Found config %d, returning %d as its latest version", configuration.ID, configuration.LatestVersion)
 		return configuration.LatestVersion, nil
 	}
@@ -134,7 +165,7 @@ func getLatestConfigVersion(ctx context.Context, configID int, m interface{}) (i
 		latestVersionMutex.Unlock()
 	}()
 
-	err := cache.Get(cache.BucketName(SubproviderName), cacheKey, configuration)
+	err := cache.Get(accountCacheBucket(meta), cacheKey, configuration)
 	if err == nil {
 		logger.Debugf("Found config %d, returning %d as its latest version", configuration.ID, configuration.LatestVersion)
 		return configuration.LatestVersion, nil
@@ -150,7 +181,7 @@ func getLatestConfigVersion(ctx context.Context, configID int, m interface{}) (i
 		logger.Errorf("error calling GetConfiguration: %s", err.Error())
 		return 0, err
 	}
-	if err := cache.Set(cache.BucketName(SubproviderName), cacheKey, configuration); err != nil && !errors.Is(err, cache.ErrDisabled) {
+	if err := cache.Set(accountCacheBucket(meta), cacheKey, configuration); err != nil && !errors.Is(err, cache.ErrDisabled) {
 		logger.Errorf("error caching latestVersion into cache: %s", err.Error())
 	}
 
@@ -158,6 +189,12 @@ func getLatestConfigVersion(ctx context.Context, configID int, m interface{}) (i
 	return configuration.LatestVersion, nil
 }
 
+// invalidateModifiableConfigVersion removes the cached modifiable version of the given security
+// configuration. It should be called after the version is activated, as it is no longer editable.
+func invalidateModifiableConfigVersion(configID int, m interface{}) {
+	cache.Invalidate(accountCacheBucket(m), fmt.Sprintf("%s:%d:", "getModifiableConfigVersion", configID))
+}
+
 // getActiveConfigVersions returns the version numbers of the given security configuration
 // active in staging and production respectively. API calls are made using the supplied
 // context and the API client obtained from m. Log messages are written to m's logger.
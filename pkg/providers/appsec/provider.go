@@ -27,7 +27,10 @@ var (
 	inst *Subprovider
 )
 
-var _ subprovider.Subprovider = &Subprovider{}
+var (
+	_ subprovider.Subprovider            = &Subprovider{}
+	_ subprovider.WithDeletionProtection = &Subprovider{}
+)
 
 // NewSubprovider returns a new appsec subprovider
 func NewSubprovider(opts ...option) *Subprovider {
@@ -116,6 +119,14 @@ func (p *Subprovider) SDKResources() map[string]*schema.Resource {
 	}
 }
 
+// SDKActiveVersions returns the functions returning the active versions of the appsec resources
+// implemented using terraform-plugin-sdk, which are protected from deletion while active
+func (p *Subprovider) SDKActiveVersions() map[string]subprovider.ActiveVersionsFunc {
+	return map[string]subprovider.ActiveVersionsFunc{
+		"akamai_appsec_configuration": configurationActiveVersions,
+	}
+}
+
 // SDKDataSources returns the appsec data sources implemented using terraform-plugin-sdk
 func (p *Subprovider) SDKDataSources() map[string]*schema.Resource {
 	return map[string]*schema.Resource{
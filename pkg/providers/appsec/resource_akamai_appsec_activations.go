@@ -8,7 +8,9 @@ import (
 	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/appsec"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/activation"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/versionnotes"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -118,6 +120,9 @@ func resourceActivationsCreate(ctx context.Context, d *schema.ResourceData, m in
 			return diag.FromErr(err)
 		}
 	}
+	if note, err = versionnotes.Render(meta, "akamai_appsec_activations", strconv.Itoa(configID), note); err != nil {
+		return diag.FromErr(err)
+	}
 	notificationEmailsSet, err := tf.GetSetValue("notification_emails", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -135,10 +140,17 @@ func resourceActivationsCreate(ctx context.Context, d *schema.ResourceData, m in
 		ConfigVersion: version,
 	})
 
-	activationResp, err := createActivation(ctx, client, createActivationRequest)
+	release, err := activation.Acquire(ctx, "security configuration activation", network)
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	defer release()
+
+	activationResp, err := createActivation(ctx, client, createActivationRequest)
+	if err != nil {
+		return activation.Diagnostics(err)
+	}
+	invalidateModifiableConfigVersion(configID, m)
 
 	d.SetId(strconv.Itoa(activationResp.ActivationID))
 
@@ -150,12 +162,12 @@ func resourceActivationsCreate(ctx context.Context, d *schema.ResourceData, m in
 		ActivationID: activationResp.ActivationID,
 	}
 
-	activation, err := lookupActivation(ctx, client, getActivationRequest)
+	act, err := lookupActivation(ctx, client, getActivationRequest)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	if err = pollActivation(ctx, client, activation.Status, getActivationRequest); err != nil {
-		return diag.FromErr(err)
+	if err = pollActivation(ctx, client, act.Status, getActivationRequest); err != nil {
+		return activation.Diagnostics(err)
 	}
 	return resourceActivationsRead(ctx, d, m)
 }
@@ -229,6 +241,9 @@ func resourceActivationsUpdate(ctx context.Context, d *schema.ResourceData, m in
 			return diag.FromErr(err)
 		}
 	}
+	if note, err = versionnotes.Render(meta, "akamai_appsec_activations", strconv.Itoa(configID), note); err != nil {
+		return diag.FromErr(err)
+	}
 	notificationEmailsSet, err := tf.GetSetValue("notification_emails", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -246,10 +261,17 @@ func resourceActivationsUpdate(ctx context.Context, d *schema.ResourceData, m in
 		ConfigVersion: version,
 	})
 
-	activationResp, err := createActivation(ctx, client, createActivationRequest)
+	release, err := activation.Acquire(ctx, "security configuration activation", network)
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	defer release()
+
+	activationResp, err := createActivation(ctx, client, createActivationRequest)
+	if err != nil {
+		return activation.Diagnostics(err)
+	}
+	invalidateModifiableConfigVersion(configID, m)
 
 	d.SetId(strconv.Itoa(activationResp.ActivationID))
 
@@ -261,12 +283,12 @@ func resourceActivationsUpdate(ctx context.Context, d *schema.ResourceData, m in
 		ActivationID: activationResp.ActivationID,
 	}
 
-	activation, err := lookupActivation(ctx, client, getActivationRequest)
+	act, err := lookupActivation(ctx, client, getActivationRequest)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	if err = pollActivation(ctx, client, activation.Status, getActivationRequest); err != nil {
-		return diag.FromErr(err)
+	if err = pollActivation(ctx, client, act.Status, getActivationRequest); err != nil {
+		return activation.Diagnostics(err)
 	}
 
 	return resourceActivationsRead(ctx, d, m)
@@ -306,6 +328,9 @@ func resourceActivationsDelete(ctx context.Context, d *schema.ResourceData, m in
 			return diag.FromErr(err)
 		}
 	}
+	if note, err = versionnotes.Render(meta, "akamai_appsec_activations", strconv.Itoa(configID), note); err != nil {
+		return diag.FromErr(err)
+	}
 	notificationEmailsSet, err := tf.GetSetValue("notification_emails", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -324,6 +349,12 @@ func resourceActivationsDelete(ctx context.Context, d *schema.ResourceData, m in
 		ConfigVersion: version,
 	})
 
+	release, err := activation.Acquire(ctx, "security configuration deactivation", network)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer release()
+
 	postresp, err := client.RemoveActivations(ctx, removeActivationRequest)
 	if err != nil {
 		logger.Errorf("calling 'removeActivations': %s", err.Error())
@@ -340,26 +371,16 @@ func resourceActivationsDelete(ctx context.Context, d *schema.ResourceData, m in
 		ActivationID: postresp.ActivationID,
 	}
 
-	activation, err := lookupActivation(ctx, client, getActivationRequest)
+	act, err := lookupActivation(ctx, client, getActivationRequest)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	for activation.Status != appsec.StatusDeactivated && activation.Status != appsec.StatusAborted && activation.Status != appsec.StatusFailed {
-		select {
-		case <-time.After(tf.MaxDuration(ActivationPollInterval, ActivationPollMinimum)):
-			act, err := client.GetActivations(ctx, getActivationRequest)
-
-			if err != nil {
-				return diag.FromErr(err)
-			}
-			activation = act
-
-		case <-ctx.Done():
-			return diag.Errorf("activation context terminated: %s", ctx.Err())
-		}
+	act, err = pollDeactivation(ctx, client, act, getActivationRequest)
+	if err != nil {
+		return activation.Diagnostics(err)
 	}
 
-	if err := d.Set("status", activation.Status); err != nil {
+	if err := d.Set("status", act.Status); err != nil {
 		return diag.Errorf("%s: %s", tf.ErrValueSet, err.Error())
 	}
 	return nil
@@ -457,63 +478,88 @@ func createActivation(ctx context.Context, client appsec.APPSEC, request appsec.
 		errMsg = "create deactivation failed"
 	}
 
-	createActivationRetry := CreateActivationRetry
-
-	for {
+	var create *appsec.CreateActivationsResponse
+	poller := activation.Poller{Name: "security configuration activation creation", Interval: CreateActivationRetry, MaxInterval: 5 * time.Minute}
+	err := poller.Retry(ctx, func(ctx context.Context) (bool, error) {
 		log.Debug("creating activation")
-		create, err := client.CreateActivations(ctx, request, true)
-
+		var err error
+		create, err = client.CreateActivations(ctx, request, true)
 		if err == nil {
-			return create, nil
+			return false, nil
 		}
 		log.Debug("%s: retrying: %w", errMsg, err)
 
 		if !isCreateActivationErrorRetryable(err) {
-			return nil, fmt.Errorf("%s: %s", errMsg, err)
-		}
-
-		select {
-		case <-time.After(createActivationRetry):
-			createActivationRetry = capDuration(createActivationRetry*2, 5*time.Minute)
-			continue
-
-		case <-ctx.Done():
-			return nil, fmt.Errorf("activation context terminated: %w", ctx.Err())
+			return false, fmt.Errorf("%s: %s", errMsg, err)
 		}
+		return true, err
+	})
+	if err != nil {
+		return nil, err
 	}
-
+	return create, nil
 }
 
 func pollActivation(ctx context.Context, client appsec.APPSEC, activationStatus appsec.StatusValue, getActivationRequest appsec.GetActivationsRequest) error {
 	retriesMax := 5
 	retries5xx := 0
 
-	for activationStatus != appsec.StatusActive && activationStatus != appsec.StatusAborted && activationStatus != appsec.StatusFailed {
-		select {
-		case <-time.After(tf.MaxDuration(ActivationPollInterval, ActivationPollMinimum)):
-			act, err := client.GetActivations(ctx, getActivationRequest)
-			if err != nil {
-				var target = &appsec.Error{}
-				if !errors.As(err, &target) {
-					return fmt.Errorf("error has unexpected type: %T", err)
-				}
-				if isCreateActivationErrorRetryable(target) {
-					retries5xx = retries5xx + 1
-					if retries5xx > retriesMax {
-						return fmt.Errorf("reached max number of 5xx retries: %d", retries5xx)
-					}
-					continue
+	poller := activationPoller("security configuration activation")
+	return poller.Wait(ctx, statusOf(activationStatus, appsec.StatusActive), func(ctx context.Context) (activation.Status, error) {
+		act, err := client.GetActivations(ctx, getActivationRequest)
+		if err != nil {
+			var target = &appsec.Error{}
+			if !errors.As(err, &target) {
+				return activation.Status{}, fmt.Errorf("error has unexpected type: %T", err)
+			}
+			if isCreateActivationErrorRetryable(target) {
+				retries5xx = retries5xx + 1
+				if retries5xx > retriesMax {
+					return activation.Status{}, fmt.Errorf("reached max number of 5xx retries: %d", retries5xx)
 				}
-				return err
+				return statusOf(activationStatus, appsec.StatusActive), nil
 			}
-			retries5xx = 0
-			activationStatus = act.Status
+			return activation.Status{}, err
+		}
+		retries5xx = 0
+		activationStatus = act.Status
+		return statusOf(activationStatus, appsec.StatusActive), nil
+	})
+}
 
-		case <-ctx.Done():
-			return fmt.Errorf("activation context terminated: %s", ctx.Err())
+// pollDeactivation waits for the deactivation and returns its last state
+func pollDeactivation(ctx context.Context, client appsec.APPSEC, act *appsec.GetActivationsResponse, getActivationRequest appsec.GetActivationsRequest) (*appsec.GetActivationsResponse, error) {
+	poller := activationPoller("security configuration deactivation")
+	err := poller.Wait(ctx, statusOf(act.Status, appsec.StatusDeactivated), func(ctx context.Context) (activation.Status, error) {
+		resp, err := client.GetActivations(ctx, getActivationRequest)
+		if err != nil {
+			return activation.Status{}, err
 		}
+		act = resp
+		return statusOf(act.Status, appsec.StatusDeactivated), nil
+	})
+	return act, err
+}
+
+// activationPoller returns the poller of security configuration activations
+func activationPoller(name string) activation.Poller {
+	return activation.Poller{
+		Name:     name,
+		Interval: tf.MaxDuration(ActivationPollInterval, ActivationPollMinimum),
 	}
-	return nil
+}
+
+// statusOf returns the status of a security configuration activation expected to end in the succeeded status
+func statusOf(status, succeeded appsec.StatusValue) activation.Status {
+	switch status {
+	case succeeded:
+		return activation.Succeeded(string(status))
+	case appsec.StatusAborted:
+		return activation.Aborted(string(status), "")
+	case appsec.StatusFailed:
+		return activation.Failed(string(status), "")
+	}
+	return activation.Pending(string(status))
 }
 
 func isCreateActivationErrorRetryable(err error) bool {
@@ -527,10 +573,3 @@ func isCreateActivationErrorRetryable(err error) bool {
 	}
 	return true
 }
-
-func capDuration(t time.Duration, tMax time.Duration) time.Duration {
-	if t > tMax {
-		return tMax
-	}
-	return t
-}
@@ -9,6 +9,7 @@ import (
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/appsec"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/subprovider"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -276,6 +277,30 @@ func resourceConfigurationUpdate(ctx context.Context, d *schema.ResourceData, m
 	return resourceConfigurationRead(ctx, d, m)
 }
 
+// configurationActiveVersions returns the versions of the configuration active on staging and production
+func configurationActiveVersions(ctx context.Context, d *schema.ResourceData, m interface{}) (subprovider.ActiveVersions, error) {
+	meta := meta.Must(m)
+	client := inst.Client(meta)
+
+	configID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return subprovider.ActiveVersions{}, err
+	}
+	configuration, err := client.GetConfiguration(ctx, appsec.GetConfigurationRequest{ConfigID: configID})
+	if err != nil {
+		return subprovider.ActiveVersions{}, err
+	}
+
+	var versions subprovider.ActiveVersions
+	if configuration.StagingVersion != 0 {
+		versions.Staging = fmt.Sprintf("version %d", configuration.StagingVersion)
+	}
+	if configuration.ProductionVersion != 0 {
+		versions.Production = fmt.Sprintf("version %d", configuration.ProductionVersion)
+	}
+	return versions, nil
+}
+
 func resourceConfigurationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	meta := meta.Must(m)
 	client := inst.Client(meta)
@@ -1,14 +1,21 @@
 package appsec
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"regexp"
 	"testing"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/appsec"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/session"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/testutils"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/subprovider"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -160,3 +167,37 @@ func TestAkamaiConfiguration_res_error_updating_configuration(t *testing.T) {
 		client.AssertExpectations(t)
 	})
 }
+
+func TestConfigurationActiveVersions(t *testing.T) {
+	m, err := meta.New(session.Must(session.New()), hclog.NewNullLogger(), "")
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		configuration appsec.GetConfigurationResponse
+		expected      subprovider.ActiveVersions
+	}{
+		"active": {
+			configuration: appsec.GetConfigurationResponse{ID: 43253, StagingVersion: 7, ProductionVersion: 6},
+			expected:      subprovider.ActiveVersions{Staging: "version 7", Production: "version 6"},
+		},
+		"inactive": {
+			configuration: appsec.GetConfigurationResponse{ID: 43253},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &appsec.Mock{}
+			client.On("GetConfiguration", mock.Anything, appsec.GetConfigurationRequest{ConfigID: 43253}).
+				Return(&test.configuration, nil).Once()
+			d := schema.TestResourceDataRaw(t, resourceConfiguration().Schema, nil)
+			d.SetId("43253")
+
+			useClient(client, func() {
+				versions, err := configurationActiveVersions(context.Background(), d, m)
+				require.NoError(t, err)
+				assert.Equal(t, test.expected, versions)
+			})
+			client.AssertExpectations(t)
+		})
+	}
+}
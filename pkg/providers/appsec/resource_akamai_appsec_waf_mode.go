@@ -7,6 +7,7 @@ import (
 	"strconv"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/appsec"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/cache"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -116,6 +117,7 @@ func resourceWAFModeCreate(ctx context.Context, d *schema.ResourceData, m interf
 		logger.Errorf("calling 'createWAFMode': %s", err.Error())
 		return diag.FromErr(err)
 	}
+	cache.Invalidate(accountCacheBucket(m), fmt.Sprintf("%s:%d:%d:%s:", "getWAFMode", createWAFMode.ConfigID, createWAFMode.Version, createWAFMode.PolicyID))
 
 	d.SetId(fmt.Sprintf("%d:%s", createWAFMode.ConfigID, createWAFMode.PolicyID))
 
@@ -224,6 +226,7 @@ func resourceWAFModeUpdate(ctx context.Context, d *schema.ResourceData, m interf
 		logger.Errorf("calling 'updateWAFMode': %s", err.Error())
 		return diag.FromErr(err)
 	}
+	cache.Invalidate(accountCacheBucket(m), fmt.Sprintf("%s:%d:%d:%s:", "getWAFMode", updateWAFMode.ConfigID, updateWAFMode.Version, updateWAFMode.PolicyID))
 
 	return resourceWAFModeRead(ctx, d, m)
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/botman"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/cache"
@@ -24,6 +25,25 @@ var (
 	contentProtectionJavaScriptInjectionRuleMutex sync.Mutex
 )
 
+var (
+	// cacheBucket keeps lists read for a security policy in a configuration version
+	cacheBucket = cache.BucketName(SubproviderName)
+	// akamaiDefinedCacheBucket keeps lists defined by Akamai, which rarely change
+	akamaiDefinedCacheBucket = cache.NewBucket(SubproviderName+":akamaiDefined", time.Hour)
+)
+
+// accountBucket returns the bucket keeping values read with the account switch key of m,
+// so that lists read for different accounts never mix
+func accountBucket(bucket cache.Bucket, m interface{}) cache.Bucket {
+	return cache.ForAccount(bucket, akameta.Must(m).AccountSwitchKey())
+}
+
+// invalidateCache removes lists of the given kind cached for a security policy, so that
+// reads following a write do not return stale data
+func invalidateCache(m interface{}, kind string, configID, version int64, securityPolicyID string) {
+	cache.Invalidate(accountBucket(cacheBucket, m), fmt.Sprintf("%s:%d:%d:%s:", kind, configID, version, securityPolicyID))
+}
+
 // getBotDetectionAction reads from the cache if present, or makes a getAll call to fetch all Bot Detection Actions for a security policy, stores in the cache and filters the required Bot Detection Action using ID.
 func getBotDetectionAction(ctx context.Context, request botman.GetBotDetectionActionRequest, m interface{}) (map[string]interface{}, error) {
 	meta := akameta.Must(m)
@@ -32,7 +52,7 @@ func getBotDetectionAction(ctx context.Context, request botman.GetBotDetectionAc
 
 	cacheKey := fmt.Sprintf("%s:%d:%d:%s", "getBotDetectionAction", request.ConfigID, request.Version, request.SecurityPolicyID)
 	botDetectionActions := &botman.GetBotDetectionActionListResponse{}
-	err := cache.Get(cache.BucketName(SubproviderName), cacheKey, botDetectionActions)
+	err := cache.Get(accountBucket(cacheBucket, meta), cacheKey, botDetectionActions)
 	// if cache is disabled use GetBotDetectionAction to fetch one action at a time
 	if errors.Is(err, cache.ErrDisabled) {
 		return client.GetBotDetectionAction(ctx, request)
@@ -47,7 +67,7 @@ func getBotDetectionAction(ctx context.Context, request botman.GetBotDetectionAc
 		botDetectionActionMutex.Unlock()
 	}()
 
-	err = cache.Get(cache.BucketName(SubproviderName), cacheKey, botDetectionActions)
+	err = cache.Get(accountBucket(cacheBucket, meta), cacheKey, botDetectionActions)
 	if err == nil {
 		return filterBotDetectionAction(botDetectionActions, request, logger)
 	}
@@ -67,7 +87,7 @@ func getBotDetectionAction(ctx context.Context, request botman.GetBotDetectionAc
 		return nil, err
 	}
 
-	err = cache.Set(cache.BucketName(SubproviderName), cacheKey, botDetectionActions)
+	err = cache.Set(accountBucket(cacheBucket, meta), cacheKey, botDetectionActions)
 	if err != nil && !errors.Is(err, cache.ErrDisabled) {
 		logger.Errorf("error caching botDetectionActions into cache: %s", err.Error())
 		return nil, err
@@ -94,7 +114,7 @@ func getCustomBotCategoryAction(ctx context.Context, request botman.GetCustomBot
 
 	cacheKey := fmt.Sprintf("%s:%d:%d:%s", "getCustomBotCategoryAction", request.ConfigID, request.Version, request.SecurityPolicyID)
 	customBotCategoryActions := &botman.GetCustomBotCategoryActionListResponse{}
-	err := cache.Get(cache.BucketName(SubproviderName), cacheKey, customBotCategoryActions)
+	err := cache.Get(accountBucket(cacheBucket, meta), cacheKey, customBotCategoryActions)
 	// if cache is disabled use GetCustomBotCategoryAction to fetch one action at a time
 	if errors.Is(err, cache.ErrDisabled) {
 		return client.GetCustomBotCategoryAction(ctx, request)
@@ -109,7 +129,7 @@ func getCustomBotCategoryAction(ctx context.Context, request botman.GetCustomBot
 		customBotCategoryActionMutex.Unlock()
 	}()
 
-	err = cache.Get(cache.BucketName(SubproviderName), cacheKey, customBotCategoryActions)
+	err = cache.Get(accountBucket(cacheBucket, meta), cacheKey, customBotCategoryActions)
 	if err == nil {
 		return filterCustomBotCategoryAction(customBotCategoryActions, request, logger)
 	}
@@ -129,7 +149,7 @@ func getCustomBotCategoryAction(ctx context.Context, request botman.GetCustomBot
 		return nil, err
 	}
 
-	err = cache.Set(cache.BucketName(SubproviderName), cacheKey, customBotCategoryActions)
+	err = cache.Set(accountBucket(cacheBucket, meta), cacheKey, customBotCategoryActions)
 	if err != nil && !errors.Is(err, cache.ErrDisabled) {
 		logger.Errorf("error caching customBotCategoryActions into cache: %s", err.Error())
 		return nil, err
@@ -156,7 +176,7 @@ func getAkamaiBotCategoryAction(ctx context.Context, request botman.GetAkamaiBot
 
 	cacheKey := fmt.Sprintf("%s:%d:%d:%s", "getAkamaiBotCategoryAction", request.ConfigID, request.Version, request.SecurityPolicyID)
 	akamaiBotCategoryActions := &botman.GetAkamaiBotCategoryActionListResponse{}
-	err := cache.Get(cache.BucketName(SubproviderName), cacheKey, akamaiBotCategoryActions)
+	err := cache.Get(accountBucket(cacheBucket, meta), cacheKey, akamaiBotCategoryActions)
 	// if cache is disabled use GetAkamaiBotCategoryAction to fetch one action at a time
 	if errors.Is(err, cache.ErrDisabled) {
 		return client.GetAkamaiBotCategoryAction(ctx, request)
@@ -171,7 +191,7 @@ func getAkamaiBotCategoryAction(ctx context.Context, request botman.GetAkamaiBot
 		akamaiBotCategoryActionMutex.Unlock()
 	}()
 
-	err = cache.Get(cache.BucketName(SubproviderName), cacheKey, akamaiBotCategoryActions)
+	err = cache.Get(accountBucket(cacheBucket, meta), cacheKey, akamaiBotCategoryActions)
 	if err == nil {
 		return filterAkamaiBotCategoryAction(akamaiBotCategoryActions, request, logger)
 	}
@@ -191,7 +211,7 @@ func getAkamaiBotCategoryAction(ctx context.Context, request botman.GetAkamaiBot
 		return nil, err
 	}
 
-	err = cache.Set(cache.BucketName(SubproviderName), cacheKey, akamaiBotCategoryActions)
+	err = cache.Set(accountBucket(cacheBucket, meta), cacheKey, akamaiBotCategoryActions)
 	if err != nil && !errors.Is(err, cache.ErrDisabled) {
 		logger.Errorf("error caching akamaiBotCategoryActions into cache: %s", err.Error())
 		return nil, err
@@ -218,7 +238,7 @@ func getTransactionalEndpoint(ctx context.Context, request botman.GetTransaction
 
 	cacheKey := fmt.Sprintf("%s:%d:%d:%s", "getTransactionalEndpoint", request.ConfigID, request.Version, request.SecurityPolicyID)
 	transactionalEndpoints := &botman.GetTransactionalEndpointListResponse{}
-	err := cache.Get(cache.BucketName(SubproviderName), cacheKey, transactionalEndpoints)
+	err := cache.Get(accountBucket(cacheBucket, meta), cacheKey, transactionalEndpoints)
 	// if cache is disabled use GetTransactionalEndpoint to fetch one action at a time
 	if errors.Is(err, cache.ErrDisabled) {
 		return client.GetTransactionalEndpoint(ctx, request)
@@ -233,7 +253,7 @@ func getTransactionalEndpoint(ctx context.Context, request botman.GetTransaction
 		transactionalEndpointMutex.Unlock()
 	}()
 
-	err = cache.Get(cache.BucketName(SubproviderName), cacheKey, transactionalEndpoints)
+	err = cache.Get(accountBucket(cacheBucket, meta), cacheKey, transactionalEndpoints)
 	if err == nil {
 		return filterTransactionalEndpoint(transactionalEndpoints, request, logger)
 	}
@@ -253,7 +273,7 @@ func getTransactionalEndpoint(ctx context.Context, request botman.GetTransaction
 		return nil, err
 	}
 
-	err = cache.Set(cache.BucketName(SubproviderName), cacheKey, transactionalEndpoints)
+	err = cache.Set(accountBucket(cacheBucket, meta), cacheKey, transactionalEndpoints)
 	if err != nil && !errors.Is(err, cache.ErrDisabled) {
 		logger.Errorf("error caching transactionalEndpoints into cache: %s", err.Error())
 		return nil, err
@@ -279,7 +299,7 @@ func getAkamaiBotCategoryList(ctx context.Context, request botman.GetAkamaiBotCa
 
 	cacheKey := fmt.Sprintf("%s", "getAkamaiBotCategory")
 	akamaiBotCategoryList := &botman.GetAkamaiBotCategoryListResponse{}
-	err := cache.Get(cache.BucketName(SubproviderName), cacheKey, akamaiBotCategoryList)
+	err := cache.Get(accountBucket(akamaiDefinedCacheBucket, meta), cacheKey, akamaiBotCategoryList)
 	// if cache is disabled make a direct all to GetAkamaiBotCategoryList
 	if errors.Is(err, cache.ErrDisabled) {
 		return client.GetAkamaiBotCategoryList(ctx, request)
@@ -294,7 +314,7 @@ func getAkamaiBotCategoryList(ctx context.Context, request botman.GetAkamaiBotCa
 		akamaiBotCategoryMutex.Unlock()
 	}()
 
-	err = cache.Get(cache.BucketName(SubproviderName), cacheKey, akamaiBotCategoryList)
+	err = cache.Get(accountBucket(akamaiDefinedCacheBucket, meta), cacheKey, akamaiBotCategoryList)
 	if err == nil {
 		return filterAkamaiBotCategoryList(akamaiBotCategoryList, request), nil
 	}
@@ -311,7 +331,7 @@ func getAkamaiBotCategoryList(ctx context.Context, request botman.GetAkamaiBotCa
 		return nil, err
 	}
 
-	err = cache.Set(cache.BucketName(SubproviderName), cacheKey, akamaiBotCategoryList)
+	err = cache.Set(accountBucket(akamaiDefinedCacheBucket, meta), cacheKey, akamaiBotCategoryList)
 	if err != nil && !errors.Is(err, cache.ErrDisabled) {
 		logger.Errorf("error caching akamaiBotCategoryList into cache: %s", err.Error())
 		return nil, err
@@ -341,7 +361,7 @@ func getAkamaiDefinedBotList(ctx context.Context, request botman.GetAkamaiDefine
 
 	cacheKey := fmt.Sprintf("%s", "getAkamaiDefinedBot")
 	akamaiDefinedBotList := &botman.GetAkamaiDefinedBotListResponse{}
-	err := cache.Get(cache.BucketName(SubproviderName), cacheKey, akamaiDefinedBotList)
+	err := cache.Get(accountBucket(akamaiDefinedCacheBucket, meta), cacheKey, akamaiDefinedBotList)
 	// if cache is disabled make a direct all to GetAkamaiDefinedBotList
 	if errors.Is(err, cache.ErrDisabled) {
 		return client.GetAkamaiDefinedBotList(ctx, request)
@@ -356,7 +376,7 @@ func getAkamaiDefinedBotList(ctx context.Context, request botman.GetAkamaiDefine
 		akamaiDefinedBotMutex.Unlock()
 	}()
 
-	err = cache.Get(cache.BucketName(SubproviderName), cacheKey, akamaiDefinedBotList)
+	err = cache.Get(accountBucket(akamaiDefinedCacheBucket, meta), cacheKey, akamaiDefinedBotList)
 	if err == nil {
 		return filterAkamaiDefinedBotList(akamaiDefinedBotList, request), nil
 	}
@@ -373,7 +393,7 @@ func getAkamaiDefinedBotList(ctx context.Context, request botman.GetAkamaiDefine
 		return nil, err
 	}
 
-	err = cache.Set(cache.BucketName(SubproviderName), cacheKey, akamaiDefinedBotList)
+	err = cache.Set(accountBucket(akamaiDefinedCacheBucket, meta), cacheKey, akamaiDefinedBotList)
 	if err != nil && !errors.Is(err, cache.ErrDisabled) {
 		logger.Errorf("error caching akamaiDefinedBotList into cache: %s", err.Error())
 		return nil, err
@@ -402,7 +422,7 @@ func getBotDetectionList(ctx context.Context, request botman.GetBotDetectionList
 
 	cacheKey := fmt.Sprintf("%s", "getBotDetection")
 	botDetectionList := &botman.GetBotDetectionListResponse{}
-	err := cache.Get(cache.BucketName(SubproviderName), cacheKey, botDetectionList)
+	err := cache.Get(accountBucket(akamaiDefinedCacheBucket, meta), cacheKey, botDetectionList)
 	// if cache is disabled make a direct all to GetBotDetectionList
 	if errors.Is(err, cache.ErrDisabled) {
 		return client.GetBotDetectionList(ctx, request)
@@ -417,7 +437,7 @@ func getBotDetectionList(ctx context.Context, request botman.GetBotDetectionList
 		botDetectionMutex.Unlock()
 	}()
 
-	err = cache.Get(cache.BucketName(SubproviderName), cacheKey, botDetectionList)
+	err = cache.Get(accountBucket(akamaiDefinedCacheBucket, meta), cacheKey, botDetectionList)
 	if err == nil {
 		return filterBotDetectionList(botDetectionList, request), nil
 	}
@@ -434,7 +454,7 @@ func getBotDetectionList(ctx context.Context, request botman.GetBotDetectionList
 		return nil, err
 	}
 
-	err = cache.Set(cache.BucketName(SubproviderName), cacheKey, botDetectionList)
+	err = cache.Set(accountBucket(akamaiDefinedCacheBucket, meta), cacheKey, botDetectionList)
 	if err != nil && !errors.Is(err, cache.ErrDisabled) {
 		logger.Errorf("error caching botDetectionList into cache: %s", err.Error())
 		return nil, err
@@ -465,7 +485,7 @@ func getContentProtectionRule(ctx context.Context, request botman.GetContentProt
 
 	cacheKey := fmt.Sprintf("%s:%d:%d:%s", "getContentProtectionRule", request.ConfigID, request.Version, request.SecurityPolicyID)
 	contentProtectionRules := &botman.GetContentProtectionRuleListResponse{}
-	err := cache.Get(cache.BucketName(SubproviderName), cacheKey, contentProtectionRules)
+	err := cache.Get(accountBucket(cacheBucket, meta), cacheKey, contentProtectionRules)
 	// if cache is disabled use GetTransactionalEndpoint to fetch one action at a time
 	if errors.Is(err, cache.ErrDisabled) {
 		return client.GetContentProtectionRule(ctx, request)
@@ -480,7 +500,7 @@ func getContentProtectionRule(ctx context.Context, request botman.GetContentProt
 		contentProtectionRuleMutex.Unlock()
 	}()
 
-	err = cache.Get(cache.BucketName(SubproviderName), cacheKey, contentProtectionRules)
+	err = cache.Get(accountBucket(cacheBucket, meta), cacheKey, contentProtectionRules)
 	if err == nil {
 		return filterContentProtectionRule(contentProtectionRules, request, logger)
 	}
@@ -500,7 +520,7 @@ func getContentProtectionRule(ctx context.Context, request botman.GetContentProt
 		return nil, err
 	}
 
-	err = cache.Set(cache.BucketName(SubproviderName), cacheKey, contentProtectionRules)
+	err = cache.Set(accountBucket(cacheBucket, meta), cacheKey, contentProtectionRules)
 	if err != nil && !errors.Is(err, cache.ErrDisabled) {
 		logger.Errorf("error caching transactionalEndpoints into cache: %s", err.Error())
 		return nil, err
@@ -527,7 +547,7 @@ func getContentProtectionJavaScriptInjectionRule(ctx context.Context, request bo
 
 	cacheKey := fmt.Sprintf("%s:%d:%d:%s", "getContentProtectionJavaScriptInjectionRule", request.ConfigID, request.Version, request.SecurityPolicyID)
 	contentProtectionJavaScriptInjectionRules := &botman.GetContentProtectionJavaScriptInjectionRuleListResponse{}
-	err := cache.Get(cache.BucketName(SubproviderName), cacheKey, contentProtectionJavaScriptInjectionRules)
+	err := cache.Get(accountBucket(cacheBucket, meta), cacheKey, contentProtectionJavaScriptInjectionRules)
 	// if cache is disabled use GetTransactionalEndpoint to fetch one action at a time
 	if errors.Is(err, cache.ErrDisabled) {
 		return client.GetContentProtectionJavaScriptInjectionRule(ctx, request)
@@ -542,7 +562,7 @@ func getContentProtectionJavaScriptInjectionRule(ctx context.Context, request bo
 		contentProtectionJavaScriptInjectionRuleMutex.Unlock()
 	}()
 
-	err = cache.Get(cache.BucketName(SubproviderName), cacheKey, contentProtectionJavaScriptInjectionRules)
+	err = cache.Get(accountBucket(cacheBucket, meta), cacheKey, contentProtectionJavaScriptInjectionRules)
 	if err == nil {
 		return filterContentProtectionJavaScriptInjectionRule(contentProtectionJavaScriptInjectionRules, request, logger)
 	}
@@ -562,7 +582,7 @@ func getContentProtectionJavaScriptInjectionRule(ctx context.Context, request bo
 		return nil, err
 	}
 
-	err = cache.Set(cache.BucketName(SubproviderName), cacheKey, contentProtectionJavaScriptInjectionRules)
+	err = cache.Set(accountBucket(cacheBucket, meta), cacheKey, contentProtectionJavaScriptInjectionRules)
 	if err != nil && !errors.Is(err, cache.ErrDisabled) {
 		logger.Errorf("error caching transactionalEndpoints into cache: %s", err.Error())
 		return nil, err
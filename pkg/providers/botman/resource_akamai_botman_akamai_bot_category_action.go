@@ -96,6 +96,7 @@ func resourceAkamaiBotCategoryActionCreate(ctx context.Context, d *schema.Resour
 		logger.Errorf("calling 'UpdateAkamaiBotCategoryAction': %s", err.Error())
 		return diag.FromErr(err)
 	}
+	invalidateCache(m, "getAkamaiBotCategoryAction", request.ConfigID, request.Version, request.SecurityPolicyID)
 
 	d.SetId(fmt.Sprintf("%d:%s:%s", configID, securityPolicyID, categoryID))
 
@@ -216,6 +217,7 @@ func resourceAkamaiBotCategoryActionUpdate(ctx context.Context, d *schema.Resour
 		logger.Errorf("calling 'UpdateAkamaiBotCategoryAction': %s", err.Error())
 		return diag.FromErr(err)
 	}
+	invalidateCache(m, "getAkamaiBotCategoryAction", request.ConfigID, request.Version, request.SecurityPolicyID)
 
 	return akamaiBotCategoryActionRead(ctx, d, m, false)
 }
@@ -97,6 +97,7 @@ func resourceBotDetectionActionCreate(ctx context.Context, d *schema.ResourceDat
 		logger.Errorf("calling 'request': %s", err.Error())
 		return diag.FromErr(err)
 	}
+	invalidateCache(m, "getBotDetectionAction", request.ConfigID, request.Version, request.SecurityPolicyID)
 
 	d.SetId(fmt.Sprintf("%d:%s:%s", configID, securityPolicyID, detectionID))
 
@@ -214,6 +215,7 @@ func resourceBotDetectionActionUpdate(ctx context.Context, d *schema.ResourceDat
 		logger.Errorf("calling 'request': %s", err.Error())
 		return diag.FromErr(err)
 	}
+	invalidateCache(m, "getBotDetectionAction", request.ConfigID, request.Version, request.SecurityPolicyID)
 
 	return botDetectionActionRead(ctx, d, m, false)
 }
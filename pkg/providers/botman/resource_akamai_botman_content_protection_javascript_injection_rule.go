@@ -92,6 +92,7 @@ func resourceContentProtectionJavaScriptInjectionRuleCreate(ctx context.Context,
 		logger.Errorf("calling 'CreateContentProtectionJavaScriptInjectionRule': %s", err.Error())
 		return diag.FromErr(err)
 	}
+	invalidateCache(m, "getContentProtectionJavaScriptInjectionRule", request.ConfigID, request.Version, request.SecurityPolicyID)
 
 	d.SetId(fmt.Sprintf("%d:%s:%s", configID, securityPolicyID, response["contentProtectionJavaScriptInjectionRuleId"]))
 	return ContentProtectionJavaScriptInjectionRuleRead(ctx, d, m, false)
@@ -217,6 +218,7 @@ func resourceContentProtectionJavaScriptInjectionRuleUpdate(ctx context.Context,
 		logger.Errorf("calling 'UpdateContentProtectionJavaScriptInjectionRule': %s", err.Error())
 		return diag.FromErr(err)
 	}
+	invalidateCache(m, "getContentProtectionJavaScriptInjectionRule", request.ConfigID, request.Version, request.SecurityPolicyID)
 	return ContentProtectionJavaScriptInjectionRuleRead(ctx, d, m, false)
 }
 
@@ -256,5 +258,6 @@ func resourceContentProtectionJavaScriptInjectionRuleDelete(ctx context.Context,
 		logger.Errorf("calling 'RemoveContentProtectionJavaScriptInjectionRule': %s", err.Error())
 		return diag.FromErr(err)
 	}
+	invalidateCache(m, "getContentProtectionJavaScriptInjectionRule", request.ConfigID, request.Version, request.SecurityPolicyID)
 	return nil
 }
@@ -92,6 +92,7 @@ func resourceContentProtectionRuleCreate(ctx context.Context, d *schema.Resource
 		logger.Errorf("calling 'CreateContentProtectionRule': %s", err.Error())
 		return diag.FromErr(err)
 	}
+	invalidateCache(m, "getContentProtectionRule", request.ConfigID, request.Version, request.SecurityPolicyID)
 
 	d.SetId(fmt.Sprintf("%d:%s:%s", configID, securityPolicyID, response["contentProtectionRuleId"]))
 	return ContentProtectionRuleRead(ctx, d, m, false)
@@ -217,6 +218,7 @@ func resourceContentProtectionRuleUpdate(ctx context.Context, d *schema.Resource
 		logger.Errorf("calling 'UpdateContentProtectionRule': %s", err.Error())
 		return diag.FromErr(err)
 	}
+	invalidateCache(m, "getContentProtectionRule", request.ConfigID, request.Version, request.SecurityPolicyID)
 	return ContentProtectionRuleRead(ctx, d, m, false)
 }
 
@@ -256,5 +258,6 @@ func resourceContentProtectionRuleDelete(ctx context.Context, d *schema.Resource
 		logger.Errorf("calling 'RemoveContentProtectionRule': %s", err.Error())
 		return diag.FromErr(err)
 	}
+	invalidateCache(m, "getContentProtectionRule", request.ConfigID, request.Version, request.SecurityPolicyID)
 	return nil
 }
@@ -98,6 +98,7 @@ func resourceCustomBotCategoryActionCreate(ctx context.Context, d *schema.Resour
 		logger.Errorf("calling 'request': %s", err.Error())
 		return diag.FromErr(err)
 	}
+	invalidateCache(m, "getCustomBotCategoryAction", request.ConfigID, request.Version, request.SecurityPolicyID)
 
 	d.SetId(fmt.Sprintf("%d:%s:%s", configID, securityPolicyID, categoryID))
 
@@ -216,6 +217,7 @@ func resourceCustomBotCategoryActionUpdate(ctx context.Context, d *schema.Resour
 		logger.Errorf("calling 'request': %s", err.Error())
 		return diag.FromErr(err)
 	}
+	invalidateCache(m, "getCustomBotCategoryAction", request.ConfigID, request.Version, request.SecurityPolicyID)
 
 	return customBotCategoryActionRead(ctx, d, m, false)
 }
@@ -95,6 +95,7 @@ func resourceTransactionalEndpointCreate(ctx context.Context, d *schema.Resource
 		logger.Errorf("calling 'CreateTransactionalEndpoint': %s", err.Error())
 		return diag.FromErr(err)
 	}
+	invalidateCache(m, "getTransactionalEndpoint", request.ConfigID, request.Version, request.SecurityPolicyID)
 
 	d.SetId(fmt.Sprintf("%d:%s:%s", configID, securityPolicyID, (response)["operationId"]))
 
@@ -223,6 +224,7 @@ func resourceTransactionalEndpointUpdate(ctx context.Context, d *schema.Resource
 		logger.Errorf("calling 'UpdateTransactionalEndpoint': %s", err.Error())
 		return diag.FromErr(err)
 	}
+	invalidateCache(m, "getTransactionalEndpoint", request.ConfigID, request.Version, request.SecurityPolicyID)
 
 	return transactionalEndpointRead(ctx, d, m, false)
 }
@@ -264,5 +266,6 @@ func resourceTransactionalEndpointDelete(ctx context.Context, d *schema.Resource
 		logger.Errorf("calling 'RemoveTransactionalEndpoint': %s", err.Error())
 		return diag.FromErr(err)
 	}
+	invalidateCache(m, "getTransactionalEndpoint", request.ConfigID, request.Version, request.SecurityPolicyID)
 	return nil
 }
@@ -9,7 +9,9 @@ import (
 	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/clientlists"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/activation"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/versionnotes"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
@@ -19,7 +21,6 @@ import (
 
 var (
 	pollActivationInterval = 30 * time.Second
-	errActivationFailed    = errors.New("activation failed")
 )
 
 func resourceClientListActivation() *schema.Resource {
@@ -114,7 +115,7 @@ func resourceActivationRead(ctx context.Context, d *schema.ResourceData, m inter
 
 	fields := map[string]interface{}{
 		"list_id":                 activationRes.ListID,
-		"comments":                activationRes.Comments,
+		"comments":                versionnotes.State(d.Get("comments").(string), activationRes.Comments),
 		"network":                 activationRes.Network,
 		"notification_recipients": activationRes.NotificationRecipients,
 		"siebel_ticket_id":        activationRes.SiebelTicketID,
@@ -135,7 +136,7 @@ func resourceActivationCreate(ctx context.Context, d *schema.ResourceData, m int
 	logger := meta.Log("CLIENTLIST", "resourceActivationCreate")
 	logger.Debug("Creating client list activation")
 
-	attrs, err := getResourceAttrs(d)
+	attrs, err := getResourceAttrs(meta, d)
 	if err != nil {
 		diag.FromErr(err)
 	}
@@ -151,6 +152,12 @@ func resourceActivationCreate(ctx context.Context, d *schema.ResourceData, m int
 		},
 	}
 
+	release, err := activation.Acquire(ctx, "client list activation", attrs.Network)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer release()
+
 	res, err := client.CreateActivation(ctx, req)
 	if err != nil {
 		logger.Errorf("calling 'CreateActivation' failed: %s", err.Error())
@@ -161,7 +168,7 @@ func resourceActivationCreate(ctx context.Context, d *schema.ResourceData, m int
 
 	_, err = waitForActivationCompletion(ctx, client, res.ActivationID)
 	if err != nil {
-		return diag.FromErr(err)
+		return activation.Diagnostics(err)
 	}
 
 	return resourceActivationRead(ctx, d, m)
@@ -177,7 +184,7 @@ func resourceActivationUpdate(ctx context.Context, d *schema.ResourceData, m int
 	hasChanges := d.HasChanges("list_id", "version", "network")
 
 	if !isActiveStatus || hasChanges {
-		attrs, err := getResourceAttrs(d)
+		attrs, err := getResourceAttrs(meta, d)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -193,6 +200,12 @@ func resourceActivationUpdate(ctx context.Context, d *schema.ResourceData, m int
 			},
 		}
 
+		release, err := activation.Acquire(ctx, "client list activation", attrs.Network)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		defer release()
+
 		res, err := client.CreateActivation(ctx, req)
 		if err != nil {
 			logger.Errorf("calling 'CreateActivation' failed: %s", err.Error())
@@ -203,7 +216,7 @@ func resourceActivationUpdate(ctx context.Context, d *schema.ResourceData, m int
 
 		_, err = waitForActivationCompletion(ctx, client, res.ActivationID)
 		if err != nil {
-			return diag.FromErr(err)
+			return activation.Diagnostics(err)
 		}
 	}
 
@@ -233,7 +246,7 @@ type resourceAttrs struct {
 	Version        int64
 }
 
-func getResourceAttrs(d *schema.ResourceData) (*resourceAttrs, error) {
+func getResourceAttrs(m meta.Meta, d *schema.ResourceData) (*resourceAttrs, error) {
 	listID, err := tf.GetStringValue("list_id", d)
 	if err != nil {
 		return nil, err
@@ -250,6 +263,9 @@ func getResourceAttrs(d *schema.ResourceData) (*resourceAttrs, error) {
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return nil, err
 	}
+	if comments, err = versionnotes.Render(m, "akamai_clientlist_activation", listID, comments); err != nil {
+		return nil, err
+	}
 	siebelTicketID, err := tf.GetStringValue("siebel_ticket_id", d)
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return nil, err
@@ -270,24 +286,26 @@ func getResourceAttrs(d *schema.ResourceData) (*resourceAttrs, error) {
 	}, nil
 }
 
+// waitForActivationCompletion returns the activation once it completes, along with an error when it failed
 func waitForActivationCompletion(ctx context.Context, client clientlists.ClientLists, activationID int64) (*clientlists.GetActivationResponse, error) {
-	for {
-		select {
-		case <-time.After(pollActivationInterval):
-			activation, err := client.GetActivation(ctx, clientlists.GetActivationRequest{ActivationID: activationID})
-			if err != nil {
-				return nil, fmt.Errorf("polling activation failed: %s", err)
-			}
-
-			if activation.ActivationStatus == clientlists.Active {
-				return activation, nil
-			} else if activation.ActivationStatus == clientlists.Failed {
-				return nil, errActivationFailed
-			}
-		case <-ctx.Done():
-			return nil, fmt.Errorf("activation context terminated: %s", ctx.Err())
+	var act *clientlists.GetActivationResponse
+	poller := activation.Poller{Name: "client list activation", Interval: pollActivationInterval}
+	err := poller.Wait(ctx, activation.Pending(""), func(ctx context.Context) (activation.Status, error) {
+		var err error
+		act, err = client.GetActivation(ctx, clientlists.GetActivationRequest{ActivationID: activationID})
+		if err != nil {
+			return activation.Status{}, fmt.Errorf("polling activation failed: %s", err)
 		}
-	}
+
+		switch act.ActivationStatus {
+		case clientlists.Active:
+			return activation.Succeeded(string(act.ActivationStatus)), nil
+		case clientlists.Failed:
+			return activation.Failed(string(act.ActivationStatus), ""), nil
+		}
+		return activation.Pending(string(act.ActivationStatus)), nil
+	})
+	return act, err
 }
 
 // Suppress diff on callers field when activation is not required
@@ -318,11 +336,11 @@ func resourceActivationImport(ctx context.Context, d *schema.ResourceData, m int
 	}
 
 	if res.ActivationStatus == clientlists.PendingActivation {
-		activation, err := waitForActivationCompletion(ctx, client, res.ActivationID)
-		if err != nil && !errors.Is(err, errActivationFailed) {
+		act, err := waitForActivationCompletion(ctx, client, res.ActivationID)
+		if err != nil && !errors.Is(err, activation.ErrFailed) {
 			return nil, err
 		}
-		res.ActivationStatus = activation.ActivationStatus
+		res.ActivationStatus = act.ActivationStatus
 	}
 
 	fields := map[string]interface{}{
@@ -1359,14 +1359,14 @@ func TestAccessKeyResource(t *testing.T) {
 		"missing contract id": {
 			steps: []resource.TestStep{{
 				Config:      testutils.LoadFixtureString(t, "testdata/TestResAccessKey/missing_contract.tf"),
-				ExpectError: regexp.MustCompile(`The argument "contract_id" is required, but no definition was found`),
+				ExpectError: regexp.MustCompile(`"contract_id" is required`),
 			}},
 		},
 		"missing group id": {
 			steps: []resource.TestStep{
 				{
 					Config:      testutils.LoadFixtureString(t, "testdata/TestResAccessKey/missing_group.tf"),
-					ExpectError: regexp.MustCompile(`The argument "group_id" is required, but no definition was found`),
+					ExpectError: regexp.MustCompile(`"group_id" is required`),
 				},
 			},
 		},
@@ -11,6 +11,7 @@ import (
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/cloudlets"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/session"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/versionnotes"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
 	ozzo "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
@@ -336,6 +337,9 @@ func resourceALBCreate(ctx context.Context, d *schema.ResourceData, m interface{
 	}
 	d.SetId(createLBConfigResp.OriginID)
 	loadBalancerVersion := getLoadBalancerVersion(d)
+	if loadBalancerVersion.Description, err = versionnotes.Render(meta, "akamai_cloudlets_application_load_balancer", originID, loadBalancerVersion.Description); err != nil {
+		return diag.FromErr(err)
+	}
 	createVersionResp, err := client.CreateLoadBalancerVersion(ctx, cloudlets.CreateLoadBalancerVersionRequest{
 		OriginID:            originID,
 		LoadBalancerVersion: loadBalancerVersion,
@@ -389,7 +393,7 @@ func resourceALBRead(ctx context.Context, d *schema.ResourceData, m interface{})
 	attrs["origin_description"] = origin.Description
 	attrs["balancing_type"] = loadBalancerVersion.BalancingType
 	attrs["version"] = loadBalancerVersion.Version
-	attrs["description"] = loadBalancerVersion.Description
+	attrs["description"] = versionnotes.State(d.Get("description").(string), loadBalancerVersion.Description)
 	attrs["data_centers"] = populateDataCenters(loadBalancerVersion.DataCenters)
 	if loadBalancerVersion.LivenessSettings != nil {
 		attrs["liveness_settings"] = populateLivenessSettings(loadBalancerVersion.LivenessSettings)
@@ -459,6 +463,9 @@ func resourceALBUpdate(ctx context.Context, d *schema.ResourceData, m interface{
 			}
 		}
 		loadBalancerVersion := getLoadBalancerVersion(d)
+		if loadBalancerVersion.Description, err = versionnotes.Render(meta, "akamai_cloudlets_application_load_balancer", originID, loadBalancerVersion.Description); err != nil {
+			return diag.FromErr(err)
+		}
 		var loadBalancerVersionResp *cloudlets.LoadBalancerVersion
 		if versionActive {
 			loadBalancerVersionResp, err = client.CreateLoadBalancerVersion(ctx, cloudlets.CreateLoadBalancerVersionRequest{
@@ -2,7 +2,6 @@ package cloudlets
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"sort"
 	"strconv"
@@ -11,6 +10,7 @@ import (
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/cloudlets"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/activation"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/timeouts"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
@@ -124,11 +124,11 @@ func resourceApplicationLoadBalancerActivationUpdate(ctx context.Context, rd *sc
 	ctx = session.ContextWithOptions(ctx, session.WithContextLog(logger))
 	client := Client(meta)
 
-	activation, err := resourceApplicationLoadBalancerActivationChange(ctx, rd, logger, client)
+	act, err := resourceApplicationLoadBalancerActivationChange(ctx, rd, logger, client)
 	if err != nil {
-		return diag.Errorf("%v update: %s", ErrApplicationLoadBalancerActivation, err.Error())
+		return activationDiagnostics(ErrApplicationLoadBalancerActivation, "update", err)
 	}
-	rd.SetId(fmt.Sprintf("%s:%s", activation.OriginID, activation.Network))
+	rd.SetId(fmt.Sprintf("%s:%s", act.OriginID, act.Network))
 	return resourceApplicationLoadBalancerActivationRead(ctx, rd, m)
 }
 
@@ -140,11 +140,11 @@ func resourceApplicationLoadBalancerActivationCreate(ctx context.Context, rd *sc
 
 	logger.Debug("Creating application load balancer activation")
 
-	activation, err := resourceApplicationLoadBalancerActivationChange(ctx, rd, logger, client)
+	act, err := resourceApplicationLoadBalancerActivationChange(ctx, rd, logger, client)
 	if err != nil {
-		return diag.Errorf("%v create: %s", ErrApplicationLoadBalancerActivation, err.Error())
+		return activationDiagnostics(ErrApplicationLoadBalancerActivation, "create", err)
 	}
-	rd.SetId(fmt.Sprintf("%s:%s", activation.OriginID, activation.Network))
+	rd.SetId(fmt.Sprintf("%s:%s", act.OriginID, act.Network))
 	return resourceApplicationLoadBalancerActivationRead(ctx, rd, m)
 }
 
@@ -231,13 +231,19 @@ func resourceApplicationLoadBalancerActivationChange(ctx context.Context, rd *sc
 		}
 	}
 
+	release, err := activation.Acquire(ctx, "application load balancer activation", network)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	// at this point, we are sure that the given version is not active
 	logger.Debugf("activating application load balancer version %d", version)
-	pollingActivationTries := ALBActivationPollMinimum
-	var activation *cloudlets.LoadBalancerActivation
-
-	for {
-		activation, err = client.ActivateLoadBalancerVersion(ctx, cloudlets.ActivateLoadBalancerVersionRequest{
+	retryCtx, cancel := context.WithTimeout(ctx, ApplicationLoadBalancerActivationRetryTimeout)
+	defer cancel()
+	poller := activation.Poller{Name: "application load balancer activation request", Interval: ALBActivationPollMinimum}
+	err = poller.Retry(retryCtx, func(ctx context.Context) (bool, error) {
+		_, err := client.ActivateLoadBalancerVersion(ctx, cloudlets.ActivateLoadBalancerVersionRequest{
 			OriginID: originID,
 			Async:    true,
 			LoadBalancerVersionActivation: cloudlets.LoadBalancerVersionActivation{
@@ -245,50 +251,35 @@ func resourceApplicationLoadBalancerActivationChange(ctx context.Context, rd *sc
 				Version: version,
 			},
 		})
-		if err == nil {
-			break
+		return err != nil && strings.Contains(strings.ToLower(err.Error()), ErrApplicationLoadBalancerActivationOriginNotDefined.Error()), err
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, err
 		}
-
-		select {
-		case <-time.After(pollingActivationTries):
-			logger.Debugf("retrying ALB activation after %s", pollingActivationTries)
-			pollingActivationTries = 2 * pollingActivationTries
-			if pollingActivationTries > ApplicationLoadBalancerActivationRetryTimeout ||
-				!strings.Contains(strings.ToLower(err.Error()), ErrApplicationLoadBalancerActivationOriginNotDefined.Error()) {
-				if errOnRestore := tf.RestoreOldValues(rd, []string{"network", "version"}); errOnRestore != nil {
-					return activation, fmt.Errorf(`%w failed. No changes were written to server:
+		if errOnRestore := tf.RestoreOldValues(rd, []string{"network", "version"}); errOnRestore != nil {
+			return nil, fmt.Errorf(`%w failed. No changes were written to server:
 %s
 
 Failed to restore previous local schema values. The schema will remain in tainted state:
 %s`, ErrApplicationLoadBalancerActivation, err.Error(), errOnRestore.Error())
-				}
-				return activation, fmt.Errorf("%w failed. No changes were written to server:\n%s", ErrApplicationLoadBalancerActivation, err.Error())
-			}
-			continue
-		case <-ctx.Done():
-			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-				return nil, fmt.Errorf("timeout waiting for retrying activation: last error: %s", err)
-			}
-			if errors.Is(ctx.Err(), context.Canceled) {
-				return nil, fmt.Errorf("operation canceled while waiting for retrying activation, last error: %s", err)
-			}
-			return nil, fmt.Errorf("operation context terminated: %w", ctx.Err())
 		}
+		return nil, fmt.Errorf("%w failed. No changes were written to server:\n%s", ErrApplicationLoadBalancerActivation, err.Error())
 	}
 
 	// wait until application load balancer activation is done
-	activation, err = waitForLoadBalancerActivation(ctx, client, originID, version, activationNetwork)
+	act, err := waitForLoadBalancerActivation(ctx, client, originID, version, activationNetwork)
 	if err != nil {
-		return nil, fmt.Errorf("error while waiting until load balancer activation status == 'active':\n%s", err.Error())
+		return nil, fmt.Errorf("error while waiting until load balancer activation status == 'active':\n%w", err)
 	}
 
-	if err := rd.Set("status", activation.Status); err != nil {
+	if err := rd.Set("status", act.Status); err != nil {
 		return nil, err
 	}
-	if err := rd.Set("version", activation.Version); err != nil {
+	if err := rd.Set("version", act.Version); err != nil {
 		return nil, err
 	}
-	return activation, nil
+	return act, nil
 }
 
 func resourceApplicationLoadBalancerActivationRead(ctx context.Context, rd *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -367,36 +358,38 @@ func getApplicationLoadBalancerActivation(ctx context.Context, client cloudlets.
 
 // waitForLoadBalancerActivation polls server until the activation has active status or until context is closed (because of timeout, cancellation or context termination)
 func waitForLoadBalancerActivation(ctx context.Context, client cloudlets.Cloudlets, originID string, version int64, network cloudlets.LoadBalancerActivationNetwork) (*cloudlets.LoadBalancerActivation, error) {
-	activation, err := getApplicationLoadBalancerActivation(ctx, client, originID, version, network)
+	act, err := getApplicationLoadBalancerActivation(ctx, client, originID, version, network)
 	if err != nil {
 		return nil, err
 	}
-	for activation.Status != cloudlets.LoadBalancerActivationStatusActive {
-		if activation.Status != cloudlets.LoadBalancerActivationStatusPending {
-			return nil, fmt.Errorf("%v: originID: %s, status: %s", ErrApplicationLoadBalancerActivation, activation.OriginID, activation.Status)
-		}
-		select {
-		case <-time.After(tf.MaxDuration(ALBActivationPollInterval, ALBActivationPollMinimum)):
-			activation, err = getApplicationLoadBalancerActivation(ctx, client, originID, version, network)
-			if err != nil {
-				return nil, err
-			}
 
-		case <-ctx.Done():
-			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-				return nil, ErrApplicationLoadBalancerActivationTimeout
-			}
-			if errors.Is(ctx.Err(), context.Canceled) {
-				return nil, ErrApplicationLoadBalancerActivationCanceled
-			}
-			return nil, fmt.Errorf("%v: %w", ErrApplicationLoadBalancerActivationContextTerminated, ctx.Err())
+	poller := activation.Poller{
+		Name:     "application load balancer activation",
+		Interval: tf.MaxDuration(ALBActivationPollInterval, ALBActivationPollMinimum),
+	}
+	err = poller.Wait(ctx, loadBalancerActivationStatus(act), func(ctx context.Context) (activation.Status, error) {
+		act, err = getApplicationLoadBalancerActivation(ctx, client, originID, version, network)
+		if err != nil {
+			return activation.Status{}, err
 		}
+		return loadBalancerActivationStatus(act), nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	if activation.Status == cloudlets.LoadBalancerActivationStatusActive {
-		return activation, nil
+	return act, nil
+}
+
+// loadBalancerActivationStatus returns the status of the application load balancer activation,
+// any status other than active or pending is a failure
+func loadBalancerActivationStatus(act *cloudlets.LoadBalancerActivation) activation.Status {
+	switch act.Status {
+	case cloudlets.LoadBalancerActivationStatusActive:
+		return activation.Succeeded(string(act.Status))
+	case cloudlets.LoadBalancerActivationStatusPending:
+		return activation.Pending(string(act.Status))
 	}
-	// should not reach here
-	return nil, ErrApplicationLoadBalancerActivation
+	return activation.Failed(string(act.Status), fmt.Sprintf("originID: %s", act.OriginID))
 }
 
 func getALBActivationNetwork(net string) (cloudlets.LoadBalancerActivationNetwork, error) {
@@ -17,6 +17,7 @@ import (
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/str"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/timeouts"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/versionnotes"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/logger"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -242,6 +243,10 @@ func resourcePolicyCreate(ctx context.Context, d *schema.ResourceData, m interfa
 		}
 	}
 
+	if description, err = versionnotes.Render(meta, "akamai_cloudlets_policy", name, description); err != nil {
+		return diag.FromErr(err)
+	}
+
 	err, updateError := executionStrategy.updatePolicyVersion(ctx, d, policyID, 1, description, matchRulesJSON, !executionStrategy.isFirstVersionCreated())
 	if err != nil {
 		return diag.FromErr(err)
@@ -290,6 +295,9 @@ func resourcePolicyRead(ctx context.Context, d *schema.ResourceData, m interface
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	if description, ok := attrs["description"].(string); ok {
+		attrs["description"] = versionnotes.State(d.Get("description").(string), description)
+	}
 
 	if err := tf.SetAttrs(d, attrs); err != nil {
 		return diag.FromErr(err)
@@ -362,6 +370,9 @@ func resourcePolicyUpdate(ctx context.Context, d *schema.ResourceData, m interfa
 		if err != nil && !errors.Is(err, tf.ErrNotFound) {
 			return diag.FromErr(err)
 		}
+		if description, err = versionnotes.Render(meta, "akamai_cloudlets_policy", d.Get("name").(string), description); err != nil {
+			return diag.FromErr(err)
+		}
 
 		err, updateVersionErr := executionStrategy.updatePolicyVersion(ctx, d, policyID, int64(version), description, matchRulesJSON, isNewVersionNeeded)
 		if err != nil {
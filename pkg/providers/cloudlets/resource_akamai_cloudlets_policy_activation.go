@@ -15,6 +15,7 @@ import (
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/cloudlets"
 	v3 "github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/cloudlets/v3"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/activation"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/timeouts"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
@@ -121,6 +122,9 @@ var (
 	// ErrNetworkName is used when the user inputs an invalid network name
 	ErrNetworkName = errors.New("invalid network name")
 
+	// errNoPolicyActivations is retried while listing policy activations returns none
+	errNoPolicyActivations = errors.New("no policy activations listed")
+
 	policyActivationRetryRegexp = regexp.MustCompile(`requested propertyname \\"[A-Za-z0-9.\-_]+\\" does not exist`)
 )
 
@@ -147,6 +151,12 @@ func resourcePolicyActivationDelete(ctx context.Context, rd *schema.ResourceData
 		return diag.FromErr(err)
 	}
 
+	release, err := activation.Acquire(ctx, "policy deactivation", network)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer release()
+
 	if err = strategy.deactivatePolicy(ctx, policyID, version, network); err != nil {
 		return diag.FromErr(err)
 	}
@@ -203,6 +213,12 @@ func resourcePolicyActivationUpdate(ctx context.Context, rd *schema.ResourceData
 		return resourcePolicyActivationRead(ctx, rd, m)
 	}
 
+	release, err := activation.Acquire(ctx, "policy activation", network)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer release()
+
 	// something has changed, we need to reactivate it
 	if err = strategy.reactivateVersion(ctx, policyID, version); err != nil {
 		if restoreDiags := diag.FromErr(tf.RestoreOldValues(rd, []string{"version", "associated_properties"})); restoreDiags != nil {
@@ -214,7 +230,7 @@ func resourcePolicyActivationUpdate(ctx context.Context, rd *schema.ResourceData
 	// poll until active
 	id, err = strategy.waitForActivation(ctx, policyID, version)
 	if err != nil {
-		return diag.Errorf("%v update: %s", ErrPolicyActivation, err.Error())
+		return activationDiagnostics(ErrPolicyActivation, "update", err)
 	}
 	rd.SetId(id)
 
@@ -265,39 +281,24 @@ func resourcePolicyActivationCreate(ctx context.Context, rd *schema.ResourceData
 		return resourcePolicyActivationRead(ctx, rd, m)
 	}
 
-	// at this point, we are sure that the given version is not active
-	pollingActivationTries := PolicyActivationRetryPollMinimum
-
-	for {
-		err = strategy.activateVersion(ctx, policyID, version)
-		if err == nil {
-			break
-		}
-
-		select {
-		case <-time.After(pollingActivationTries):
-			logger.Debugf("retrying policy activation after %s", pollingActivationTries)
-			if pollingActivationTries > PolicyActivationRetryTimeout || !strategy.shouldRetryActivation(err) {
-				return diag.Errorf("%v create: %s", ErrPolicyActivation, err.Error())
-			}
-
-			pollingActivationTries = 2 * pollingActivationTries
-			continue
-		case <-ctx.Done():
-			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-				return diag.Errorf("timeout waiting for retrying policy activation: last error: %s", err)
-			}
-			if errors.Is(ctx.Err(), context.Canceled) {
-				return diag.Errorf("operation canceled while waiting for retrying policy activation, last error: %s", err)
-			}
+	release, err := activation.Acquire(ctx, "policy activation", network)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer release()
 
+	// at this point, we are sure that the given version is not active
+	if err = activatePolicyVersion(ctx, strategy, policyID, version); err != nil {
+		if ctx.Err() != nil {
+			return activation.Diagnostics(err)
 		}
+		return diag.Errorf("%v create: %s", ErrPolicyActivation, err.Error())
 	}
 
 	// wait until policy activation is done
 	id, err = strategy.waitForActivation(ctx, policyID, version)
 	if err != nil {
-		return diag.Errorf("%v create: %s", ErrPolicyActivation, err.Error())
+		return activationDiagnostics(ErrPolicyActivation, "create", err)
 	}
 
 	rd.SetId(id)
@@ -406,66 +407,62 @@ func getActiveProperties(policyActivations []cloudlets.PolicyActivation) []strin
 
 // waitForPolicyActivation polls server until the activation has active status or until context is closed (because of timeout, cancellation or context termination)
 func waitForPolicyActivation(ctx context.Context, client cloudlets.Cloudlets, policyID, version int64, network cloudlets.PolicyActivationNetwork, additionalProps, removedProperties []string) ([]cloudlets.PolicyActivation, error) {
-	activations, err := waitForListPolicyActivations(ctx, client, cloudlets.ListPolicyActivationsRequest{
-		PolicyID: policyID,
-		Network:  network,
-	})
+	var activations []cloudlets.PolicyActivation
+	poll := func(ctx context.Context) (activation.Status, error) {
+		var err error
+		activations, err = waitForListPolicyActivations(ctx, client, cloudlets.ListPolicyActivationsRequest{
+			PolicyID: policyID,
+			Network:  network,
+		})
+		if err != nil {
+			return activation.Status{}, err
+		}
+		activations = filterActivations(activations, version, additionalProps)
+		if len(activations) == 0 {
+			return activation.Status{}, fmt.Errorf("%v: policyID %d: not all properties are active", ErrPolicyActivation, policyID)
+		}
+		return policyActivationsStatus(activations, version, removedProperties), nil
+	}
+
+	status, err := poll(ctx)
 	if err != nil {
 		return nil, err
 	}
-	activations = filterActivations(activations, version, additionalProps)
-
-	for len(activations) > 0 {
-		allActive, allRemoved := true, true
-	activations:
-		for _, act := range activations {
-			if act.PolicyInfo.Version == version {
-				if act.PolicyInfo.Status == cloudlets.PolicyActivationStatusFailed ||
-					strings.Contains(act.PolicyInfo.StatusDetail, "fail") {
-					return nil, fmt.Errorf("%v: policyID %d activation failure: %s", ErrPolicyActivation, act.PolicyInfo.PolicyID, act.PolicyInfo.StatusDetail)
-				}
-				if act.PolicyInfo.Status != cloudlets.PolicyActivationStatusActive {
-					allActive = false
-					break
-				}
+	if err = activationPoller("policy activation").Wait(ctx, status, poll); err != nil {
+		return nil, err
+	}
+	return activations, nil
+}
+
+// policyActivationsStatus returns the status of the policy activation for the given properties, which succeeds when
+// the version is active on all of them and none of the removed properties is listed
+func policyActivationsStatus(activations []cloudlets.PolicyActivation, version int64, removedProperties []string) activation.Status {
+	allActive, allRemoved := true, true
+	var status cloudlets.PolicyActivationStatus
+activations:
+	for _, act := range activations {
+		if act.PolicyInfo.Version == version {
+			if act.PolicyInfo.Status == cloudlets.PolicyActivationStatusFailed ||
+				strings.Contains(act.PolicyInfo.StatusDetail, "fail") {
+				return activation.Failed(string(act.PolicyInfo.Status),
+					fmt.Sprintf("policyID %d activation failure: %s", act.PolicyInfo.PolicyID, act.PolicyInfo.StatusDetail))
 			}
-			for _, property := range removedProperties {
-				if property == act.PropertyInfo.Name {
-					allRemoved = false
-					break activations
-				}
+			if act.PolicyInfo.Status != cloudlets.PolicyActivationStatusActive {
+				allActive, status = false, act.PolicyInfo.Status
+				break
 			}
 		}
-		if allActive && allRemoved {
-			return activations, nil
-		}
-		select {
-		case <-time.After(tf.MaxDuration(ActivationPollInterval, ActivationPollMinimum)):
-			activations, err = waitForListPolicyActivations(ctx, client, cloudlets.ListPolicyActivationsRequest{
-				PolicyID: policyID,
-				Network:  network,
-			})
-			if err != nil {
-				return nil, err
-			}
-			activations = filterActivations(activations, version, additionalProps)
-
-		case <-ctx.Done():
-			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-				return nil, ErrPolicyActivationTimeout
+		for _, property := range removedProperties {
+			if property == act.PropertyInfo.Name {
+				allRemoved = false
+				break activations
 			}
-			if errors.Is(ctx.Err(), context.Canceled) {
-				return nil, ErrPolicyActivationCanceled
-			}
-			return nil, fmt.Errorf("%v: %w", ErrPolicyActivationContextTerminated, ctx.Err())
 		}
 	}
-
-	if len(activations) == 0 {
-		return nil, fmt.Errorf("%v: policyID %d: not all properties are active", ErrPolicyActivation, policyID)
+	if allActive && allRemoved {
+		return activation.Succeeded(string(cloudlets.PolicyActivationStatusActive))
 	}
-
-	return activations, nil
+	return activation.Pending(string(status))
 }
 
 // filterActivations filters the latest activation for the given properties and version. In case of length mismatch (not all
@@ -591,73 +588,81 @@ func waitForNotPendingPolicyActivation(ctx context.Context, logger log.Interface
 	if err != nil {
 		return fmt.Errorf("%w: failed to list policy activations for policy %d: %s", ErrPolicyActivation, policyID, err.Error())
 	}
-	for len(activations) > 0 {
-		pending := false
-		for _, act := range activations {
-			if act.PolicyInfo.Status == cloudlets.PolicyActivationStatusFailed {
-				return fmt.Errorf("%v: policyID %d: %s", ErrPolicyActivation, act.PolicyInfo.PolicyID, act.PolicyInfo.StatusDetail)
-			}
-			if act.PolicyInfo.Status == cloudlets.PolicyActivationStatusPending {
-				pending = true
-				break
-			}
-		}
-		if !pending {
-			break
+
+	return activationPoller("pending policy activations").Wait(ctx, notPendingStatus(activations), func(ctx context.Context) (activation.Status, error) {
+		activations, err := waitForListPolicyActivations(ctx, client, cloudlets.ListPolicyActivationsRequest{
+			PolicyID: policyID,
+			Network:  network,
+		})
+		if err != nil {
+			return activation.Status{}, fmt.Errorf("%w: failed to list policy activations for policy %d: %s", ErrPolicyActivation, policyID, err.Error())
 		}
-		select {
-		case <-time.After(tf.MaxDuration(ActivationPollInterval, ActivationPollMinimum)):
-			activations, err = waitForListPolicyActivations(ctx, client, cloudlets.ListPolicyActivationsRequest{
-				PolicyID: policyID,
-				Network:  network,
-			})
-			if err != nil {
-				return fmt.Errorf("%w: failed to list policy activations for policy %d: %s", ErrPolicyActivation, policyID, err.Error())
-			}
+		return notPendingStatus(activations), nil
+	})
+}
 
-		case <-ctx.Done():
-			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-				return ErrPolicyActivationTimeout
-			}
-			if errors.Is(ctx.Err(), context.Canceled) {
-				return ErrPolicyActivationCanceled
-			}
-			return fmt.Errorf("%v: %w", ErrPolicyActivationContextTerminated, ctx.Err())
+// notPendingStatus returns the status of the policy activations which succeeds when none of them is pending
+func notPendingStatus(activations []cloudlets.PolicyActivation) activation.Status {
+	for _, act := range activations {
+		if act.PolicyInfo.Status == cloudlets.PolicyActivationStatusFailed {
+			return activation.Failed(string(act.PolicyInfo.Status), fmt.Sprintf("policyID %d: %s", act.PolicyInfo.PolicyID, act.PolicyInfo.StatusDetail))
+		}
+		if act.PolicyInfo.Status == cloudlets.PolicyActivationStatusPending {
+			return activation.Pending(string(act.PolicyInfo.Status))
 		}
 	}
-
-	return nil
+	return activation.Succeeded("")
 }
 
 // waitForListPolicyActivations polls server until the ListPolicyActivations returns non-empty list
 func waitForListPolicyActivations(ctx context.Context, client cloudlets.Cloudlets, listPolicyActivationsRequest cloudlets.ListPolicyActivationsRequest) ([]cloudlets.PolicyActivation, error) {
-	listActivationsPollRetries := MaxListActivationsPollRetries
-	activations, err := client.ListPolicyActivations(ctx, listPolicyActivationsRequest)
+	var activations []cloudlets.PolicyActivation
+	retries := 0
+	err := activationPoller("policy activations listing").Retry(ctx, func(ctx context.Context) (bool, error) {
+		var err error
+		activations, err = client.ListPolicyActivations(ctx, listPolicyActivationsRequest)
+		if err != nil {
+			return false, err
+		}
+		if len(activations) == 0 && retries < MaxListActivationsPollRetries {
+			retries++
+			return true, errNoPolicyActivations
+		}
+		return false, nil
+	})
 	if err != nil {
 		return nil, err
 	}
+	return activations, nil
+}
 
-	for len(activations) == 0 && listActivationsPollRetries > 0 {
-		select {
-		case <-time.After(tf.MaxDuration(ActivationPollInterval, ActivationPollMinimum)):
-			activations, err = client.ListPolicyActivations(ctx, listPolicyActivationsRequest)
-			if err != nil {
-				return nil, err
-			}
-			listActivationsPollRetries--
+// activatePolicyVersion activates the policy version, retrying for PolicyActivationRetryTimeout when the strategy allows it
+func activatePolicyVersion(ctx context.Context, strategy activationStrategy, policyID, version int64) error {
+	retryCtx, cancel := context.WithTimeout(ctx, PolicyActivationRetryTimeout)
+	defer cancel()
 
-		case <-ctx.Done():
-			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-				return nil, ErrPolicyActivationTimeout
-			}
-			if errors.Is(ctx.Err(), context.Canceled) {
-				return nil, ErrPolicyActivationCanceled
-			}
-			return nil, fmt.Errorf("%v: %w", ErrPolicyActivationContextTerminated, ctx.Err())
-		}
+	poller := activation.Poller{Name: "policy activation request", Interval: PolicyActivationRetryPollMinimum}
+	return poller.Retry(retryCtx, func(ctx context.Context) (bool, error) {
+		err := strategy.activateVersion(ctx, policyID, version)
+		return err != nil && strategy.shouldRetryActivation(err), err
+	})
+}
+
+// activationPoller returns the poller of policy activations
+func activationPoller(name string) activation.Poller {
+	return activation.Poller{
+		Name:     name,
+		Interval: tf.MaxDuration(ActivationPollInterval, ActivationPollMinimum),
 	}
+}
 
-	return activations, nil
+// activationDiagnostics returns the diagnostics reporting the error of the activation operation,
+// the common ones when the operation timed out or was canceled while waiting for the activation
+func activationDiagnostics(errActivation error, operation string, err error) diag.Diagnostics {
+	if errors.Is(err, activation.ErrTimeout) || errors.Is(err, activation.ErrCanceled) {
+		return activation.Diagnostics(err)
+	}
+	return diag.Errorf("%v %s: %s", errActivation, operation, err.Error())
 }
 
 func discoverActivationStrategy(ctx context.Context, policyID int64, meta meta.Meta, logger log.Interface) (activationStrategy, bool, error) {
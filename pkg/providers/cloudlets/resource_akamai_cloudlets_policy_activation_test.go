@@ -1183,7 +1183,7 @@ func TestResourceV3CloudletsPolicyActivation(t *testing.T) {
 			steps: []resource.TestStep{
 				{
 					Config:      testutils.LoadFixtureString(t, "./testdata/TestResCloudletsPolicyV3Activation/shared_policy_activation_version1.tf"),
-					ExpectError: regexp.MustCompile("policy activation create: activation failed for policy 1234"),
+					ExpectError: regexp.MustCompile("policy activation create: policy activation failed with status FAILED: policy 1234"),
 				},
 			},
 		},
@@ -5,10 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/cloudlets"
 	v3 "github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/cloudlets/v3"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/activation"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
 	"github.com/apex/log"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -79,34 +79,26 @@ func (strategy *v3ActivationStrategy) reactivateVersion(ctx context.Context, pol
 }
 
 func (strategy *v3ActivationStrategy) waitForActivation(ctx context.Context, policyID, _ int64) (string, error) {
-	for {
-		select {
-		case <-time.After(tf.MaxDuration(ActivationPollInterval, ActivationPollMinimum)):
-			activation, err := strategy.client.GetPolicyActivation(ctx, v3.GetPolicyActivationRequest{
-				PolicyID:     policyID,
-				ActivationID: strategy.activationID,
-			})
-			if err != nil {
-				return "", err
-			}
-			if activation != nil {
-				switch activation.Status {
-				case v3.ActivationStatusSuccess:
-					return strategy.getID(policyID, strategy.network), nil
-				case v3.ActivationStatusFailed:
-					return "", fmt.Errorf("activation failed for policy %d", policyID)
-				}
-			}
-		case <-ctx.Done():
-			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-				return "", ErrPolicyActivationTimeout
-			}
-			if errors.Is(ctx.Err(), context.Canceled) {
-				return "", ErrPolicyActivationCanceled
-			}
-			return "", fmt.Errorf("%v: %w", ErrPolicyActivationContextTerminated, ctx.Err())
+	err := activationPoller("policy activation").Wait(ctx, activation.Pending(""), func(ctx context.Context) (activation.Status, error) {
+		act, err := strategy.client.GetPolicyActivation(ctx, v3.GetPolicyActivationRequest{
+			PolicyID:     policyID,
+			ActivationID: strategy.activationID,
+		})
+		if err != nil || act == nil {
+			return activation.Pending(""), err
 		}
+		switch act.Status {
+		case v3.ActivationStatusSuccess:
+			return activation.Succeeded(string(act.Status)), nil
+		case v3.ActivationStatusFailed:
+			return activation.Failed(string(act.Status), fmt.Sprintf("policy %d", policyID)), nil
+		}
+		return activation.Pending(string(act.Status)), nil
+	})
+	if err != nil {
+		return "", err
 	}
+	return strategy.getID(policyID, strategy.network), nil
 }
 
 func (strategy *v3ActivationStrategy) getID(policyID int64, network v3.Network) string {
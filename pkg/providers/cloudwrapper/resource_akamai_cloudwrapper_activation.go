@@ -2,11 +2,13 @@ package cloudwrapper
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/cloudwrapper"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/activation"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -180,8 +182,16 @@ func (a *activationResource) Create(ctx context.Context, req resource.CreateRequ
 func (a *activationResource) upsert(ctx context.Context, data activationResourceModel, activationTimeout time.Duration) (*activationResourceModel, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
+	// configurations are not activated on a particular network, so only the total limit applies
+	release, err := activation.Acquire(ctx, "configuration activation", "")
+	if err != nil {
+		diags.AddError("Activating Configuration Failed", err.Error())
+		return nil, diags
+	}
+	defer release()
+
 	configID := int(data.ConfigID.ValueInt64())
-	err := a.client.ActivateConfiguration(ctx, cloudwrapper.ActivateConfigurationRequest{ConfigurationIDs: []int{configID}})
+	err = a.client.ActivateConfiguration(ctx, cloudwrapper.ActivateConfigurationRequest{ConfigurationIDs: []int{configID}})
 	if err != nil {
 		diags.AddError("Activating Configuration Failed", err.Error())
 		return nil, diags
@@ -218,27 +228,38 @@ func (a *activationResource) readStateFromAPI(ctx context.Context, model activat
 func (a *activationResource) waitUntilActivationCompleted(ctx context.Context, configID int, timeout time.Duration) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	for {
+	poll := func(ctx context.Context) (activation.Status, error) {
 		configuration, err := a.client.GetConfiguration(ctx, cloudwrapper.GetConfigurationRequest{ConfigID: int64(configID)})
 		if err != nil {
-			diags.AddError(readError, err.Error())
-			return diags
-		}
-		if configuration.Status == cloudwrapper.StatusActive {
-			return diags
+			return activation.Status{}, err
 		}
+		return configurationStatus(configuration.Status), nil
+	}
+	status, err := poll(ctx)
+	if err == nil {
+		err = activation.Poller{Name: "configuration activation", Interval: a.activationPollInterval}.Wait(ctx, status, poll)
+	}
+	var activationErr *activation.Error
+	switch {
+	case errors.As(err, &activationErr):
+		diags.AddError(activation.Describe(err))
+	case err != nil:
+		diags.AddError(readError, err.Error())
+	}
+	return diags
+}
 
-		select {
-		case <-time.After(a.activationPollInterval):
-			continue
-		case <-timeoutCtx.Done():
-			diags.AddError("Reached Activation Timeout", timeoutCtx.Err().Error())
-			return diags
-		}
+func configurationStatus(status cloudwrapper.StatusType) activation.Status {
+	switch status {
+	case cloudwrapper.StatusActive:
+		return activation.Succeeded(string(status))
+	case cloudwrapper.StatusFailed:
+		return activation.Failed(string(status), "")
 	}
+	return activation.Pending(string(status))
 }
 
 // Read implements resource.Resource
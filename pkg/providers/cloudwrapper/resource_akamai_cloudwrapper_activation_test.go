@@ -159,7 +159,7 @@ func TestActivation(t *testing.T) {
 			steps: []resource.TestStep{
 				{
 					Config:      testutils.LoadFixtureString(t, "testdata/TestResActivation/create_timeout.tf"),
-					ExpectError: regexp.MustCompile(`Reached Activation Timeout`),
+					ExpectError: regexp.MustCompile(`Timeout waiting for activation status`),
 				},
 			},
 		},
@@ -186,7 +186,7 @@ func TestActivation(t *testing.T) {
 				},
 				{
 					Config:      testutils.LoadFixtureString(t, "testdata/TestResActivation/update_timeout.tf"),
-					ExpectError: regexp.MustCompile(`Reached Activation Timeout`),
+					ExpectError: regexp.MustCompile(`Timeout waiting for activation status`),
 				},
 			},
 		},
@@ -25,8 +25,4 @@ var (
 	ErrEdgeworkerActivationCancelled = errors.New("operation cancelled while waiting for edgeworker activation status")
 	// ErrEdgeworkerDeactivationCancelled is returned on deactivation poll cancel
 	ErrEdgeworkerDeactivationCancelled = errors.New("operation cancelled while waiting for edgeworker deactivation status")
-	// ErrEdgeworkerActivationContextTerminated is returned on activation context termination
-	ErrEdgeworkerActivationContextTerminated = errors.New("edgeworker activation context terminated")
-	// ErrEdgeworkerDeactivationContextTerminated is returned on deactivation context termination
-	ErrEdgeworkerDeactivationContextTerminated = errors.New("edgeworker deactivation context terminated")
 )
@@ -11,9 +11,11 @@ import (
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/edgeworkers"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/activation"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/collections"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/timeouts"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/versionnotes"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -166,7 +168,7 @@ func resourceEdgeworkersActivationRead(ctx context.Context, rd *schema.ResourceD
 		return diag.Errorf("%v: %s", tf.ErrValueSet, err.Error())
 	}
 
-	if err := rd.Set("note", activation.Note); err != nil {
+	if err := rd.Set("note", versionnotes.State(rd.Get("note").(string), activation.Note)); err != nil {
 		return diag.Errorf("%v: %s", tf.ErrValueSet, err.Error())
 	}
 	return nil
@@ -215,6 +217,15 @@ func resourceEdgeworkersActivationDelete(ctx context.Context, rd *schema.Resourc
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return diag.FromErr(err)
 	}
+	if note, err = versionnotes.Render(meta, "akamai_edgeworkers_activation", strconv.Itoa(edgeworkerID), note); err != nil {
+		return diag.FromErr(err)
+	}
+
+	release, err := activation.Acquire(ctx, "edgeworker deactivation", network)
+	if err != nil {
+		return diag.Errorf("%s: %s", ErrEdgeworkerDeactivation, err)
+	}
+	defer release()
 
 	findLatestDeactivation := false
 	deactivation, err := client.DeactivateVersion(ctx, edgeworkers.DeactivateVersionRequest{
@@ -328,8 +339,17 @@ func upsertActivation(ctx context.Context, rd *schema.ResourceData, m interface{
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return diag.FromErr(err)
 	}
+	if note, err = versionnotes.Render(meta.Must(m), "akamai_edgeworkers_activation", strconv.Itoa(edgeworkerID), note); err != nil {
+		return diag.FromErr(err)
+	}
 
-	activation, err := client.ActivateVersion(ctx, edgeworkers.ActivateVersionRequest{
+	release, err := activation.Acquire(ctx, "edgeworker activation", network)
+	if err != nil {
+		return diag.Errorf("%s: %s", ErrEdgeworkerActivation, err.Error())
+	}
+	defer release()
+
+	act, err := client.ActivateVersion(ctx, edgeworkers.ActivateVersionRequest{
 		EdgeWorkerID: edgeworkerID,
 		ActivateVersion: edgeworkers.ActivateVersion{
 			Network: edgeworkers.ActivationNetwork(network),
@@ -342,7 +362,7 @@ func upsertActivation(ctx context.Context, rd *schema.ResourceData, m interface{
 		return diag.Errorf("%s: %s", ErrEdgeworkerActivation, err.Error())
 	}
 
-	if _, err := waitForEdgeworkerActivation(ctx, client, edgeworkerID, activation.ActivationID); err != nil {
+	if _, err := waitForEdgeworkerActivation(ctx, client, edgeworkerID, act.ActivationID); err != nil {
 		return diag.Errorf("%s: %s", ErrEdgeworkerActivation, err.Error())
 	}
 
@@ -455,71 +475,94 @@ func versionExists(version string, versions []edgeworkers.EdgeWorkerVersion) boo
 }
 
 func waitForEdgeworkerActivation(ctx context.Context, client edgeworkers.Edgeworkers, edgeworkerID, activationID int) (*edgeworkers.Activation, error) {
-	activation, err := client.GetActivation(ctx, edgeworkers.GetActivationRequest{
+	act, err := client.GetActivation(ctx, edgeworkers.GetActivationRequest{
 		EdgeWorkerID: edgeworkerID,
 		ActivationID: activationID,
 	})
 	if err != nil {
 		return nil, err
 	}
-	for activation != nil && activation.Status != activationStatusComplete {
-		if !statusOngoing(activation.Status) {
-			return nil, ErrEdgeworkerActivationFailure
+	statusOf := func(act *edgeworkers.Activation) activation.Status {
+		if act == nil {
+			return activation.Succeeded("")
 		}
-		select {
-		case <-time.After(tf.MaxDuration(activationPollInterval, activationPollMinimum)):
-			activation, err = client.GetActivation(ctx, edgeworkers.GetActivationRequest{
-				EdgeWorkerID: edgeworkerID,
-				ActivationID: activationID,
-			})
-			if err != nil {
-				return nil, err
-			}
-		case <-ctx.Done():
-			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-				return nil, ErrEdgeworkerActivationTimeout
-			}
-			if errors.Is(ctx.Err(), context.Canceled) {
-				return nil, ErrEdgeworkerActivationCancelled
-			}
-			return nil, fmt.Errorf("%v: %w", ErrEdgeworkerActivationContextTerminated, ctx.Err())
+		return activationStatus(act.Status)
+	}
+	err = activationPoller("edgeworker activation").Wait(ctx, statusOf(act), func(ctx context.Context) (activation.Status, error) {
+		act, err = client.GetActivation(ctx, edgeworkers.GetActivationRequest{
+			EdgeWorkerID: edgeworkerID,
+			ActivationID: activationID,
+		})
+		if err != nil {
+			return activation.Status{}, err
 		}
+		return statusOf(act), nil
+	})
+	if err != nil {
+		return nil, activationError(err, ErrEdgeworkerActivationFailure, ErrEdgeworkerActivationTimeout, ErrEdgeworkerActivationCancelled)
 	}
-	return activation, nil
+	return act, nil
 }
 
 func waitForEdgeworkerDeactivation(ctx context.Context, client edgeworkers.Edgeworkers, edgeworkerID, deactivationID int) (*edgeworkers.Deactivation, error) {
-	deactivation, err := client.GetDeactivation(ctx, edgeworkers.GetDeactivationRequest{
+	deact, err := client.GetDeactivation(ctx, edgeworkers.GetDeactivationRequest{
 		EdgeWorkerID:   edgeworkerID,
 		DeactivationID: deactivationID,
 	})
 	if err != nil {
 		return nil, err
 	}
-	for deactivation != nil && deactivation.Status != activationStatusComplete {
-		if !statusOngoing(deactivation.Status) {
-			return nil, ErrEdgeworkerDeactivationFailure
+	statusOf := func(deact *edgeworkers.Deactivation) activation.Status {
+		if deact == nil {
+			return activation.Succeeded("")
 		}
-		select {
-		case <-time.After(tf.MaxDuration(activationPollInterval, activationPollMinimum)):
-			deactivation, err = client.GetDeactivation(ctx, edgeworkers.GetDeactivationRequest{
-				EdgeWorkerID:   edgeworkerID,
-				DeactivationID: deactivationID,
-			})
-			if err != nil {
-				return nil, err
-			}
-		case <-ctx.Done():
-			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-				return nil, ErrEdgeworkerDeactivationTimeout
-			}
-			if errors.Is(ctx.Err(), context.Canceled) {
-				return nil, ErrEdgeworkerDeactivationCancelled
-			}
-			return nil, fmt.Errorf("%v: %w", ErrEdgeworkerDeactivationContextTerminated, ctx.Err())
+		return activationStatus(deact.Status)
+	}
+	err = activationPoller("edgeworker deactivation").Wait(ctx, statusOf(deact), func(ctx context.Context) (activation.Status, error) {
+		deact, err = client.GetDeactivation(ctx, edgeworkers.GetDeactivationRequest{
+			EdgeWorkerID:   edgeworkerID,
+			DeactivationID: deactivationID,
+		})
+		if err != nil {
+			return activation.Status{}, err
 		}
+		return statusOf(deact), nil
+	})
+	if err != nil {
+		return nil, activationError(err, ErrEdgeworkerDeactivationFailure, ErrEdgeworkerDeactivationTimeout, ErrEdgeworkerDeactivationCancelled)
 	}
-	return deactivation, nil
+	return deact, nil
+}
+
+func activationPoller(name string) activation.Poller {
+	return activation.Poller{
+		Name:     name,
+		Interval: tf.MaxDuration(activationPollInterval, activationPollMinimum),
+	}
+}
+
+func activationStatus(status string) activation.Status {
+	switch {
+	case status == activationStatusComplete:
+		return activation.Succeeded(status)
+	case statusOngoing(status):
+		return activation.Pending(status)
+	}
+	return activation.Failed(status, "")
+}
+
+// activationError wraps the error of waiting for an (de)activation with the errors of this subprovider,
+// which its callers and users rely on
+func activationError(err, errFailure, errTimeout, errCanceled error) error {
+	switch {
+	case errors.Is(err, activation.ErrFailed):
+		return fmt.Errorf("%w: %w", errFailure, err)
+	case errors.Is(err, activation.ErrTimeout):
+		return fmt.Errorf("%w: %w", errTimeout, err)
+	case errors.Is(err, activation.ErrCanceled):
+		return fmt.Errorf("%w: %w", errCanceled, err)
+	}
+	return err
 }
 
 func filterActivationsByNetwork(acts []edgeworkers.Activation, net string) (activations []edgeworkers.Activation) {
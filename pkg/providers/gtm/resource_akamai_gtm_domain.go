@@ -10,6 +10,7 @@ import (
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/gtm"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/activation"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/ptr"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
@@ -907,31 +908,38 @@ func waitForCompletion(ctx context.Context, domain string, m interface{}) (bool,
 	}
 	logger.Debugf("WAIT: Sleep Interval [%v]", sleepInterval/time.Second)
 	logger.Debugf("WAIT: Sleep Timeout [%v]", sleepTimeout/time.Second)
-	for {
+
+	ctx, cancel := context.WithTimeout(ctx, sleepTimeout)
+	defer cancel()
+	poll := func(ctx context.Context) (activation.Status, error) {
 		propStat, err := Client(meta).GetDomainStatus(ctx, gtm.GetDomainStatusRequest{
 			DomainName: domain,
 		})
 		if err != nil {
-			return false, fmt.Errorf("GetDomainStatus error: %s", err.Error())
+			return activation.Status{}, fmt.Errorf("GetDomainStatus error: %s", err.Error())
 		}
 		logger.Debugf("WAIT: propStat.PropagationStatus [%v]", propStat.PropagationStatus)
 		switch propStat.PropagationStatus {
 		case "COMPLETE":
-			logger.Debugf("WAIT: Return COMPLETE")
-			return true, nil
+			return activation.Succeeded(propStat.PropagationStatus), nil
 		case "DENIED":
-			logger.Debugf("WAIT: Return DENIED")
-			return false, fmt.Errorf(propStat.Message)
+			return activation.Status{}, errors.New(propStat.Message)
 		case "PENDING":
-			if sleepTimeout <= 0 {
-				logger.Debugf("WAIT: Return TIMED OUT")
-				return false, nil
-			}
-			time.Sleep(sleepInterval)
-			sleepTimeout -= sleepInterval
-			logger.Debugf("WAIT: Sleep Time Remaining [%v]", sleepTimeout/time.Second)
-		default:
-			return false, fmt.Errorf("unknown propagationStatus while waiting for change completion") // don't know how/why we would have broken out.
+			return activation.Pending(propStat.PropagationStatus), nil
 		}
+		return activation.Status{}, fmt.Errorf("unknown propagationStatus while waiting for change completion") // don't know how/why we would have broken out.
+	}
+	status, err := poll(ctx)
+	if err == nil {
+		err = activation.Poller{Name: "domain change propagation", Interval: sleepInterval}.Wait(ctx, status, poll)
+	}
+	if errors.Is(err, activation.ErrTimeout) {
+		logger.Debugf("WAIT: Return TIMED OUT")
+		return false, nil
+	}
+	if err != nil {
+		return false, err
 	}
+	logger.Debugf("WAIT: Return COMPLETE")
+	return true, nil
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/imaging"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/session"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/versionnotes"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/logger"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -126,6 +127,9 @@ func upsertPolicyVideo(ctx context.Context, d *schema.ResourceData, m interface{
 			return diag.FromErr(err)
 		}
 		d.SetId(fmt.Sprintf("%s:%s", policySetID, createPolicyResp.ID))
+		if err = versionnotes.Log(meta.Must(m), "akamai_imaging_policy_video", fmt.Sprintf("policy %s on staging", policyID)); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 	activateOnProduction, err := tf.GetBoolValue("activate_on_production", d)
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
@@ -146,6 +150,9 @@ func upsertPolicyVideo(ctx context.Context, d *schema.ResourceData, m interface{
 			}
 			return diag.FromErr(err)
 		}
+		if err = versionnotes.Log(meta.Must(m), "akamai_imaging_policy_video", fmt.Sprintf("policy %s on production", policyID)); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	return resourcePolicyVideoRead(ctx, d, m)
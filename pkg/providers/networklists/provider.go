@@ -28,7 +28,10 @@ var (
 	inst *Subprovider
 )
 
-var _ subprovider.Subprovider = &Subprovider{}
+var (
+	_ subprovider.Subprovider            = &Subprovider{}
+	_ subprovider.WithDeletionProtection = &Subprovider{}
+)
 
 // NewSubprovider returns new networklists subprovider
 func NewSubprovider(opts ...option) *Subprovider {
@@ -67,6 +70,14 @@ func (p *Subprovider) SDKResources() map[string]*schema.Resource {
 	}
 }
 
+// SDKActiveVersions returns the functions returning the active versions of the networklists resources
+// implemented using terraform-plugin-sdk, which are protected from deletion while active
+func (p *Subprovider) SDKActiveVersions() map[string]subprovider.ActiveVersionsFunc {
+	return map[string]subprovider.ActiveVersionsFunc{
+		"akamai_networklist_network_list": networkListActiveVersions,
+	}
+}
+
 // SDKDataSources returns the networklists data sources implemented using terraform-plugin-sdk
 func (p *Subprovider) SDKDataSources() map[string]*schema.Resource {
 	return map[string]*schema.Resource{
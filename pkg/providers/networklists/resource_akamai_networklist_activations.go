@@ -9,7 +9,9 @@ import (
 	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/networklists"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/activation"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/versionnotes"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -102,11 +104,20 @@ func resourceActivationsCreate(ctx context.Context, d *schema.ResourceData, m in
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return diag.FromErr(err)
 	}
+	if comments, err = versionnotes.Render(meta, "akamai_networklist_activations", networkListID, comments); err != nil {
+		return diag.FromErr(err)
+	}
 	notificationEmails, ok := d.Get("notification_emails").(*schema.Set)
 	if !ok {
 		return diag.Errorf("Activation Read failed")
 	}
 
+	release, err := activation.Acquire(ctx, "network list activation", network)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer release()
+
 	createResponse, diagErr := createActivation(ctx, client, networklists.CreateActivationsRequest{
 		UniqueID:               networkListID,
 		Network:                network,
@@ -128,7 +139,7 @@ func resourceActivationsCreate(ctx context.Context, d *schema.ResourceData, m in
 	}
 
 	if err = pollActivation(ctx, client, lookupResponse.ActivationStatus, lookupResponse.ActivationID); err != nil {
-		return diag.FromErr(err)
+		return activation.Diagnostics(err)
 	}
 
 	return resourceActivationsRead(ctx, d, m)
@@ -150,30 +161,26 @@ func createActivation(ctx context.Context, client networklists.NetworkList, para
 		errMsg = "create deactivation failed"
 	}
 
-	createActivationRetry := CreateActivationRetry
-
-	for {
+	var create *networklists.CreateActivationsResponse
+	poller := activation.Poller{Name: "network list activation creation", Interval: CreateActivationRetry, MaxInterval: 5 * time.Minute}
+	err := poller.Retry(ctx, func(ctx context.Context) (bool, error) {
 		log.Debug("creating activation")
-		create, err := client.CreateActivations(ctx, params)
-
+		var err error
+		create, err = client.CreateActivations(ctx, params)
 		if err == nil {
-			return create, nil
+			return false, nil
 		}
 		log.Debug("%s: retrying: %w", errMsg, err)
 
 		if !isCreateActivationErrorRetryable(err) {
-			return nil, diag.Errorf("%s: %s", errMsg, err)
-		}
-
-		select {
-		case <-time.After(createActivationRetry):
-			createActivationRetry = capDuration(createActivationRetry*2, 5*time.Minute)
-			continue
-
-		case <-ctx.Done():
-			return nil, diag.Errorf("activation context terminated: %s", ctx.Err())
+			return false, fmt.Errorf("%s: %s", errMsg, err)
 		}
+		return true, err
+	})
+	if err != nil {
+		return nil, activation.Diagnostics(err)
 	}
+	return create, nil
 }
 
 func resourceActivationsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -219,11 +226,20 @@ func resourceActivationsUpdate(ctx context.Context, d *schema.ResourceData, m in
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return diag.FromErr(err)
 	}
+	if comments, err = versionnotes.Render(meta, "akamai_networklist_activations", networkListID, comments); err != nil {
+		return diag.FromErr(err)
+	}
 	notificationEmails, err := tf.GetSetValue("notification_emails", d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	release, err := activation.Acquire(ctx, "network list activation", network)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer release()
+
 	createResponse, diagErr := createActivation(ctx, client, networklists.CreateActivationsRequest{
 		UniqueID:               networkListID,
 		Network:                network,
@@ -246,7 +262,7 @@ func resourceActivationsUpdate(ctx context.Context, d *schema.ResourceData, m in
 	}
 
 	if err = pollActivation(ctx, client, lookupResponse.ActivationStatus, lookupResponse.ActivationID); err != nil {
-		return diag.FromErr(err)
+		return activation.Diagnostics(err)
 	}
 	return resourceActivationsRead(ctx, d, m)
 }
@@ -282,33 +298,43 @@ func pollActivation(ctx context.Context, client networklists.NetworkList, activa
 	retriesMax := 5
 	retries5xx := 0
 
-	for activationStatus != string(networklists.StatusActive) {
-		select {
-		case <-time.After(tf.MaxDuration(ActivationPollInterval, ActivationPollMinimum)):
-			act, err := client.GetActivation(ctx, networklists.GetActivationRequest{ActivationID: activationID})
-
-			if err != nil {
-				var target = &networklists.Error{}
-				if !errors.As(err, &target) {
-					return fmt.Errorf("error has unexpected type: %T", err)
-				}
-				if isCreateActivationErrorRetryable(target) {
-					retries5xx = retries5xx + 1
-					if retries5xx > retriesMax {
-						return fmt.Errorf("reached max number of 5xx retries: %d", retries5xx)
-					}
-					continue
+	poller := activation.Poller{
+		Name:     "network list activation",
+		Interval: tf.MaxDuration(ActivationPollInterval, ActivationPollMinimum),
+	}
+	return poller.Wait(ctx, activationStatusOf(activationStatus), func(ctx context.Context) (activation.Status, error) {
+		act, err := client.GetActivation(ctx, networklists.GetActivationRequest{ActivationID: activationID})
+		if err != nil {
+			var target = &networklists.Error{}
+			if !errors.As(err, &target) {
+				return activation.Status{}, fmt.Errorf("error has unexpected type: %T", err)
+			}
+			if isCreateActivationErrorRetryable(target) {
+				retries5xx = retries5xx + 1
+				if retries5xx > retriesMax {
+					return activation.Status{}, fmt.Errorf("reached max number of 5xx retries: %d", retries5xx)
 				}
-				return err
+				return activationStatusOf(activationStatus), nil
 			}
-			retries5xx = 0
-			activationStatus = act.ActivationStatus
-
-		case <-ctx.Done():
-			return fmt.Errorf("activation context terminated: %s", ctx.Err())
+			return activation.Status{}, err
 		}
+		retries5xx = 0
+		activationStatus = act.ActivationStatus
+		return activationStatusOf(activationStatus), nil
+	})
+}
+
+// activationStatusOf returns the status of a network list activation
+func activationStatusOf(status string) activation.Status {
+	switch networklists.StatusValue(status) {
+	case networklists.StatusActive:
+		return activation.Succeeded(status)
+	case networklists.StatusAborted:
+		return activation.Aborted(status, "")
+	case networklists.StatusFailed:
+		return activation.Failed(status, "")
 	}
-	return nil
+	return activation.Pending(status)
 }
 
 func isCreateActivationErrorRetryable(err error) bool {
@@ -323,10 +349,3 @@ func isCreateActivationErrorRetryable(err error) bool {
 	}
 	return true
 }
-
-func capDuration(t time.Duration, tMax time.Duration) time.Duration {
-	if t > tMax {
-		return tMax
-	}
-	return t
-}
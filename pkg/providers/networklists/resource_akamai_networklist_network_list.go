@@ -10,6 +10,7 @@ import (
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/networklists"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/subprovider"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -307,6 +308,33 @@ func resourceNetworkListUpdate(ctx context.Context, d *schema.ResourceData, m in
 	return resourceNetworkListRead(ctx, d, m)
 }
 
+// networkListActiveVersions returns the sync points of the network list active on staging and production
+func networkListActiveVersions(ctx context.Context, d *schema.ResourceData, m interface{}) (subprovider.ActiveVersions, error) {
+	meta := meta.Must(m)
+	client := inst.Client(meta)
+
+	active := func(network string) (string, error) {
+		status, err := client.GetActivations(ctx, networklists.GetActivationsRequest{UniqueID: d.Id(), Network: network})
+		if err != nil {
+			return "", err
+		}
+		if status.ActivationStatus != string(networklists.StatusActive) {
+			return "", nil
+		}
+		return fmt.Sprintf("sync point %d", status.SyncPoint), nil
+	}
+
+	staging, err := active("STAGING")
+	if err != nil {
+		return subprovider.ActiveVersions{}, err
+	}
+	production, err := active("PRODUCTION")
+	if err != nil {
+		return subprovider.ActiveVersions{}, err
+	}
+	return subprovider.ActiveVersions{Staging: staging, Production: production}, nil
+}
+
 func resourceNetworkListDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	meta := meta.Must(m)
 	client := inst.Client(meta)
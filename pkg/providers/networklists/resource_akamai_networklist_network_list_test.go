@@ -1,13 +1,20 @@
 package networklists
 
 import (
+	"context"
 	"encoding/json"
 	"regexp"
 	"testing"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/networklists"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/session"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/testutils"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/subprovider"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -175,3 +182,45 @@ func TestAccAkamaiNetworkListConfigChanged(t *testing.T) {
 	})
 
 }
+
+func TestNetworkListActiveVersions(t *testing.T) {
+	m, err := meta.New(session.Must(session.New()), hclog.NewNullLogger(), "")
+	require.NoError(t, err)
+
+	status := func(network string, activationStatus networklists.StatusValue) (networklists.GetActivationsRequest, *networklists.GetActivationsResponse) {
+		return networklists.GetActivationsRequest{UniqueID: "86093_AGEOLIST", Network: network},
+			&networklists.GetActivationsResponse{UniqueID: "86093_AGEOLIST", ActivationStatus: string(activationStatus), SyncPoint: 5}
+	}
+	tests := map[string]struct {
+		staging, production networklists.StatusValue
+		expected            subprovider.ActiveVersions
+	}{
+		"active on production": {
+			staging:    networklists.StatusInactive,
+			production: networklists.StatusActive,
+			expected:   subprovider.ActiveVersions{Production: "sync point 5"},
+		},
+		"inactive": {
+			staging:    networklists.StatusInactive,
+			production: networklists.StatusInactive,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &networklists.Mock{}
+			stagingReq, stagingResp := status("STAGING", test.staging)
+			productionReq, productionResp := status("PRODUCTION", test.production)
+			client.On("GetActivations", mock.Anything, stagingReq).Return(stagingResp, nil).Once()
+			client.On("GetActivations", mock.Anything, productionReq).Return(productionResp, nil).Once()
+			d := schema.TestResourceDataRaw(t, resourceNetworkList().Schema, nil)
+			d.SetId("86093_AGEOLIST")
+
+			useClient(client, func() {
+				versions, err := networkListActiveVersions(context.Background(), d, m)
+				require.NoError(t, err)
+				assert.Equal(t, test.expected, versions)
+			})
+			client.AssertExpectations(t)
+		})
+	}
+}
@@ -40,6 +40,11 @@ func dataSourcePropertyRulesBuilderRead(_ context.Context, d *schema.ResourceDat
 	logger := meta.Log("PAPI", "dataSourcePropertyRulesBuilderRead")
 	logger.Debug("dataSourcePropertyRulesBuilderRead")
 
+	diags := ruleformats.NewRulesValidator(d).Validate()
+	if diags.HasError() {
+		return diags
+	}
+
 	rules, err := ruleformats.NewBuilder(d).Build()
 	if err != nil {
 		diags := diag.Errorf("building rules: %s", err)
@@ -73,5 +78,5 @@ func dataSourcePropertyRulesBuilderRead(_ context.Context, d *schema.ResourceDat
 	sum := md5.Sum(JSON)
 	hexsum := hex.EncodeToString(sum[:])
 	d.SetId(hexsum)
-	return nil
+	return diags
 }
@@ -0,0 +1,151 @@
+package property
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/papi"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/providers/property/ruleformats"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourcePropertyRulesFormatUpgrade() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataPropertyRulesFormatUpgradeRead,
+		Schema: map[string]*schema.Schema{
+			"rules": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsJSON),
+				Description:      "JSON rule tree in the source rule format, e.g. the rules of the akamai_property_rules data source",
+			},
+			"source_rule_format": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(ruleFormatVersions(), false)),
+				Description:      "Frozen rule format of the rules, e.g. v2023-01-05",
+			},
+			"target_rule_format": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(ruleFormatVersions(), false)),
+				Description:      "Frozen rule format to convert the rules to, e.g. v2024-10-21",
+			},
+			"json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "JSON representation of the rules in the target rule format, without removed behaviors, criteria and options",
+			},
+			"incompatibilities": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Behaviors, criteria and options of the rules which change in the target rule format",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"rule": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Path of rule names, e.g. default/Static Content",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Either behavior or criterion",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the behavior or criterion in the source rule format",
+						},
+						"option": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Path of the option in the source rule format, or empty when the whole behavior or criterion is incompatible",
+						},
+						"kind": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Either removed, renamed or invalid_value. Removed behaviors, criteria and options are left out of the json, renamed ones get their new names, while invalid values are kept",
+						},
+						"message": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Description of the incompatibility",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataPropertyRulesFormatUpgradeRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("PAPI", "dataPropertyRulesFormatUpgradeRead")
+
+	rulesJSON, err := tf.GetStringValue("rules", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	source, err := tf.GetStringValue("source_rule_format", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	target, err := tf.GetStringValue("target_rule_format", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var rules ruleformats.RulesUpdate
+	if err := json.Unmarshal([]byte(rulesJSON), &rules); err != nil {
+		return diag.Errorf("unmarshaling rules: %s", err)
+	}
+	if rules.Rules.Name == "" {
+		return diag.Errorf("rules do not contain a rule tree")
+	}
+	if rules.RuleFormat != "" && ruleformats.RuleVersion(rules.RuleFormat).Version() != source {
+		return diag.Errorf("rules are in rule format %s, not %s", ruleformats.RuleVersion(rules.RuleFormat).Version(), source)
+	}
+
+	logger.Debugf("upgrading rules from rule format %s to %s", source, target)
+	upgraded, incompatibilities, err := ruleformats.Upgrade(rules.Rules, ruleVersion(source), ruleVersion(target))
+	if err != nil {
+		return diag.Errorf("upgrading rules: %s", err)
+	}
+
+	upgradedJSON, err := json.MarshalIndent(ruleformats.RulesUpdate{
+		RuleFormat:  ruleVersion(target).SchemaKey(),
+		RulesUpdate: papi.RulesUpdate{Rules: *upgraded, Comments: rules.Comments},
+	}, "", "  ")
+	if err != nil {
+		return diag.Errorf("marshaling rules to json: %s", err)
+	}
+	if err := d.Set("json", string(upgradedJSON)); err != nil {
+		return diag.Errorf("%v: %s", tf.ErrValueSet, err.Error())
+	}
+
+	attrs := make([]map[string]any, 0, len(incompatibilities))
+	for _, incompatibility := range incompatibilities {
+		attrs = append(attrs, map[string]any{
+			"rule":    incompatibility.Rule,
+			"type":    incompatibility.Type,
+			"name":    incompatibility.Name,
+			"option":  incompatibility.Option,
+			"kind":    string(incompatibility.Kind),
+			"message": incompatibility.Message,
+		})
+	}
+	if err := d.Set("incompatibilities", attrs); err != nil {
+		return diag.Errorf("%v: %s", tf.ErrValueSet, err.Error())
+	}
+
+	sum := md5.Sum(upgradedJSON)
+	d.SetId(hex.EncodeToString(sum[:]))
+	return nil
+}
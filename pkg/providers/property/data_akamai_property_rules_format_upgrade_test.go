@@ -0,0 +1,59 @@
+package property
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/testutils"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestDataPropertyRulesFormatUpgrade(t *testing.T) {
+	tests := map[string]struct {
+		configPath  string
+		checks      []resource.TestCheckFunc
+		expectError *regexp.Regexp
+	}{
+		"upgrade with incompatibilities keeping comments": {
+			configPath: "testdata/TestDSPropertyRulesFormatUpgrade/upgrade.tf",
+			checks: []resource.TestCheckFunc{
+				testCheckResourceAttrJSON("data.akamai_property_rules_format_upgrade.test", "json",
+					testutils.LoadFixtureString(t, "testdata/TestDSPropertyRulesFormatUpgrade/upgraded.json")),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_format_upgrade.test", "incompatibilities.#", "2"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_format_upgrade.test", "incompatibilities.0.rule", "default"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_format_upgrade.test", "incompatibilities.0.type", "behavior"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_format_upgrade.test", "incompatibilities.0.name", "origin"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_format_upgrade.test", "incompatibilities.0.option", "tls13Support"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_format_upgrade.test", "incompatibilities.0.kind", "removed"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_format_upgrade.test", "incompatibilities.0.message",
+					`option "tls13Support" does not exist in rule format v2024-10-21`),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_format_upgrade.test", "incompatibilities.1.rule", "default/Static Content"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_format_upgrade.test", "incompatibilities.1.name", "caching"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_format_upgrade.test", "incompatibilities.1.option", "behavior"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_format_upgrade.test", "incompatibilities.1.kind", "invalid_value"),
+			},
+		},
+		"rules in different rule format": {
+			configPath:  "testdata/TestDSPropertyRulesFormatUpgrade/different_rule_format.tf",
+			expectError: regexp.MustCompile("rules are in rule format v2024-10-21, not v2024-02-12"),
+		},
+		"behavior not in source rule format": {
+			configPath:  "testdata/TestDSPropertyRulesFormatUpgrade/unknown_behavior.tf",
+			expectError: regexp.MustCompile(`behavior "unknownBehavior": does not exist in rule format v2023-01-05`),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			useClient(nil, nil, func() {
+				resource.UnitTest(t, resource.TestCase{
+					ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+					Steps: []resource.TestStep{{
+						Config:      testutils.LoadFixtureString(t, test.configPath),
+						Check:       resource.ComposeAggregateTestCheckFunc(test.checks...),
+						ExpectError: test.expectError,
+					}},
+				})
+			})
+		})
+	}
+}
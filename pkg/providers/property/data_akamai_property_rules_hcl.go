@@ -0,0 +1,120 @@
+package property
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"regexp"
+
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/providers/property/ruleformats"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourcePropertyRulesHCL() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataPropertyRulesHCLRead,
+		Schema: map[string]*schema.Schema{
+			"rules": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsJSON),
+				Description:      "JSON rule tree, e.g. the rules of the akamai_property_rules data source or the json of the akamai_property_rules_builder data source",
+			},
+			"rule_format": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(ruleFormatVersions(), false)),
+				Description:      "Frozen rule format of the rules, e.g. v2024-10-21. Required unless the rules contain their rule format",
+			},
+			"label_prefix": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(regexp.MustCompile("^[a-zA-Z_][a-zA-Z0-9_-]*$"), "must be a valid identifier")),
+				Description:      "Prefix of the labels of the generated data sources, which are followed by the names of their rules",
+			},
+			"hcl": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Configuration of one akamai_property_rules_builder data source per rule, with children referencing the data sources of their child rules",
+			},
+		},
+	}
+}
+
+func dataPropertyRulesHCLRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("PAPI", "dataPropertyRulesHCLRead")
+	logger.Debug("generating rules builder configuration")
+
+	rulesJSON, err := tf.GetStringValue("rules", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var rules ruleformats.RulesUpdate
+	if err := json.Unmarshal([]byte(rulesJSON), &rules); err != nil {
+		return diag.Errorf("unmarshaling rules: %s", err)
+	}
+	if rules.Rules.Name == "" {
+		return diag.Errorf("rules do not contain a rule tree")
+	}
+
+	ruleFormat := ruleformats.RuleVersion(rules.RuleFormat)
+	version, err := tf.GetStringValue("rule_format", d)
+	if err != nil && !errors.Is(err, tf.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	switch {
+	case version == "" && ruleFormat == "":
+		return diag.Errorf(`"rule_format" is required, as the rules do not contain their rule format`)
+	case version != "" && ruleFormat != "" && ruleFormat.Version() != version:
+		return diag.Errorf("rules are in rule format %s, not %s", ruleFormat.Version(), version)
+	case ruleFormat == "":
+		ruleFormat = ruleVersion(version)
+	}
+
+	labelPrefix, err := tf.GetStringValue("label_prefix", d)
+	if err != nil && !errors.Is(err, tf.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+
+	generator, err := ruleformats.NewHCLGenerator(ruleFormat, labelPrefix)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	hcl, err := generator.Generate(rules.Rules)
+	if err != nil {
+		return diag.Errorf("generating rules builder configuration: %s", err)
+	}
+	if err := d.Set("hcl", string(hcl)); err != nil {
+		return diag.Errorf("%v: %s", tf.ErrValueSet, err.Error())
+	}
+
+	sum := md5.Sum(hcl)
+	d.SetId(hex.EncodeToString(sum[:]))
+	return nil
+}
+
+// ruleFormatVersions returns the versions of the rule formats in the registry, e.g. v2024-10-21
+func ruleFormatVersions() []string {
+	var versions []string
+	for _, rf := range ruleformats.RulesFormats() {
+		versions = append(versions, rf.Version())
+	}
+	return versions
+}
+
+// ruleVersion returns the registered rule format of the given version, e.g. rules_v2024_10_21 for v2024-10-21
+func ruleVersion(version string) ruleformats.RuleVersion {
+	for _, rf := range ruleformats.RulesFormats() {
+		if rf.Version() == version {
+			return rf
+		}
+	}
+	return ruleformats.RuleVersion(version)
+}
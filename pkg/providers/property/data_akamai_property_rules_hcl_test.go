@@ -0,0 +1,53 @@
+package property
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/testutils"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestDataPropertyRulesHCL(t *testing.T) {
+	tests := map[string]struct {
+		configPath  string
+		expectedHCL string
+		expectError *regexp.Regexp
+	}{
+		"rules builder json with rule format": {
+			configPath:  "testdata/TestDSPropertyRulesHCL/builder_json.tf",
+			expectedHCL: "testdata/TestDSPropertyRulesHCL/builder_json.hcl",
+		},
+		"rules with rule format and label prefix": {
+			configPath:  "testdata/TestDSPropertyRulesHCL/rule_format.tf",
+			expectedHCL: "testdata/TestDSPropertyRulesHCL/rule_format.hcl",
+		},
+		"missing rule format": {
+			configPath:  "testdata/TestDSPropertyRulesHCL/missing_rule_format.tf",
+			expectError: regexp.MustCompile(`"rule_format" is required, as the rules do not contain their rule format`),
+		},
+		"different rule format": {
+			configPath:  "testdata/TestDSPropertyRulesHCL/different_rule_format.tf",
+			expectError: regexp.MustCompile("rules are in rule format v2024-10-21, not v2024-08-13"),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var check resource.TestCheckFunc
+			if test.expectedHCL != "" {
+				check = resource.TestCheckResourceAttr("data.akamai_property_rules_hcl.test", "hcl",
+					testutils.LoadFixtureString(t, test.expectedHCL))
+			}
+			useClient(nil, nil, func() {
+				resource.UnitTest(t, resource.TestCase{
+					ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+					Steps: []resource.TestStep{{
+						Config:      testutils.LoadFixtureString(t, test.configPath),
+						Check:       check,
+						ExpectError: test.expectError,
+					}},
+				})
+			})
+		})
+	}
+}
@@ -46,45 +46,29 @@ func rulesJSONEqual(oldRulesJSON, newRulesJSON string) (bool, error) {
 // rulesEqual handles comparison between two papi.Rules objects ignoring the order in
 // collection of variables.
 func rulesEqual(oldRules, newRules *papi.Rules) bool {
-	if len(oldRules.Behaviors) != len(newRules.Behaviors) ||
-		len(oldRules.Criteria) != len(newRules.Criteria) ||
-		len(oldRules.Variables) != len(newRules.Variables) ||
-		len(oldRules.Children) != len(newRules.Children) {
-		return false
-	}
+	normalizeRules(oldRules)
+	normalizeRules(newRules)
 
-	if len(oldRules.Children) > 0 {
-		for i := range oldRules.Children {
-			if !rulesEqual(&oldRules.Children[i], &newRules.Children[i]) {
-				return false
-			}
-		}
-	} else {
-		oldRules.Children = nil
-		newRules.Children = nil
-	}
+	return reflect.DeepEqual(oldRules, newRules)
+}
 
-	if len(oldRules.Behaviors) == 0 {
-		oldRules.Behaviors = nil
+// normalizeRules brings the rule tree to the form in which equal rules are deeply equal: empty collections
+// are nil, variables are ordered by name and behavior options with null values are removed
+func normalizeRules(rules *papi.Rules) {
+	for i := range rules.Children {
+		normalizeRules(&rules.Children[i])
 	}
-	if len(newRules.Behaviors) == 0 {
-		newRules.Behaviors = nil
+	if len(rules.Children) == 0 {
+		rules.Children = nil
 	}
-
-	if len(oldRules.Criteria) == 0 {
-		oldRules.Criteria = nil
+	if len(rules.Behaviors) == 0 {
+		rules.Behaviors = nil
 	}
-	if len(newRules.Criteria) == 0 {
-		newRules.Criteria = nil
+	if len(rules.Criteria) == 0 {
+		rules.Criteria = nil
 	}
-
-	oldRules.Variables = orderVariables(oldRules.Variables)
-	newRules.Variables = orderVariables(newRules.Variables)
-
-	removeNilOptions(oldRules)
-	removeNilOptions(newRules)
-
-	return reflect.DeepEqual(oldRules, newRules)
+	rules.Variables = orderVariables(rules.Variables)
+	removeNilOptions(rules)
 }
 
 // PAPI sometimes adds fields (with value null) that are not present in configuration (e.g. exported in cli-terraform)
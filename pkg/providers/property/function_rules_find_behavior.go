@@ -0,0 +1,110 @@
+package property
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/papi"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &rulesFindBehaviorFunction{}
+
+type (
+	rulesFindBehaviorFunction struct{}
+
+	// behaviorMatch is a behavior found in a rule tree
+	behaviorMatch struct {
+		Path    string `tfsdk:"path"`
+		Rule    string `tfsdk:"rule"`
+		Options string `tfsdk:"options"`
+	}
+)
+
+// NewRulesFindBehaviorFunction returns a new rules_find_behavior function
+func NewRulesFindBehaviorFunction() function.Function {
+	return &rulesFindBehaviorFunction{}
+}
+
+// Metadata implements function.Function
+func (f *rulesFindBehaviorFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "rules_find_behavior"
+}
+
+// Definition implements function.Function
+func (f *rulesFindBehaviorFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Finds behaviors with the given name in a property rule tree",
+		Description: "Returns all behaviors with the given name, in the depth-first order of rules, each as an object with " +
+			"the JSON pointer of the behavior in the rule tree (`path`), the name of the rule holding it (`rule`) " +
+			"and the JSON of its options normalized as by rules_normalize (`options`).",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "rules",
+				Description: "The rule tree JSON",
+			},
+			function.StringParameter{
+				Name:        "name",
+				Description: "The name of the behavior, e.g. origin",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.ObjectType{AttrTypes: map[string]attr.Type{
+				"path":    types.StringType,
+				"rule":    types.StringType,
+				"options": types.StringType,
+			}},
+		},
+	}
+}
+
+// Run implements function.Function
+func (f *rulesFindBehaviorFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var rulesJSON, name string
+	if resp.Error = req.Arguments.Get(ctx, &rulesJSON, &name); resp.Error != nil {
+		return
+	}
+
+	rules, funcErr := unmarshalRulesArgument(rulesJSON, 0)
+	if funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+	normalizeRules(&rules.Rules)
+
+	matches, err := findBehaviors(rules.Rules, name, "/rules")
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, matches)
+}
+
+func findBehaviors(rule papi.Rules, name, path string) ([]behaviorMatch, error) {
+	matches := make([]behaviorMatch, 0)
+	for i, behavior := range rule.Behaviors {
+		if behavior.Name != name {
+			continue
+		}
+		options, err := json.Marshal(behavior.Options)
+		if err != nil {
+			return nil, fmt.Errorf("encoding options of behavior %s failed: %s", name, err)
+		}
+		matches = append(matches, behaviorMatch{
+			Path:    fmt.Sprintf("%s/behaviors/%d", path, i),
+			Rule:    rule.Name,
+			Options: string(options),
+		})
+	}
+	for i, child := range rule.Children {
+		childMatches, err := findBehaviors(child, name, fmt.Sprintf("%s/children/%d", path, i))
+		if err != nil {
+			return nil, err
+		}
+		matches = append(matches, childMatches...)
+	}
+	return matches, nil
+}
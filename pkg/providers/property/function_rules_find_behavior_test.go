@@ -0,0 +1,59 @@
+package property
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRulesFindBehaviorFunction(t *testing.T) {
+	rules := `{
+		"rules": {
+			"name": "default",
+			"behaviors": [{"name": "origin", "options": {"hostname": "origin.example.com", "mtls": null}}],
+			"children": [
+				{"name": "Images", "behaviors": [{"name": "caching", "options": {}}]},
+				{"name": "API", "behaviors": [{"name": "caching", "options": {}}, {"name": "origin", "options": {"hostname": "api.example.com"}}]}
+			]
+		}
+	}`
+	matchType := types.ObjectType{AttrTypes: map[string]attr.Type{
+		"path":    types.StringType,
+		"rule":    types.StringType,
+		"options": types.StringType,
+	}}
+	match := func(path, rule, options string) attr.Value {
+		return types.ObjectValueMust(matchType.AttrTypes, map[string]attr.Value{
+			"path":    types.StringValue(path),
+			"rule":    types.StringValue(rule),
+			"options": types.StringValue(options),
+		})
+	}
+
+	tests := map[string]struct {
+		name     string
+		expected []attr.Value
+	}{
+		"behaviors in the depth-first order": {
+			name: "origin",
+			expected: []attr.Value{
+				match("/rules/behaviors/0", "default", `{"hostname":"origin.example.com"}`),
+				match("/rules/children/1/behaviors/1", "API", `{"hostname":"api.example.com"}`),
+			},
+		},
+		"no behavior": {
+			name:     "gzipResponse",
+			expected: []attr.Value{},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, funcErr := runFunction(t, NewRulesFindBehaviorFunction(), types.ListUnknown(matchType), rules, test.name)
+			require.Nil(t, funcErr)
+			assert.Equal(t, types.ListValueMust(matchType, test.expected), result)
+		})
+	}
+}
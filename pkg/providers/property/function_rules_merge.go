@@ -0,0 +1,150 @@
+package property
+
+import (
+	"context"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/papi"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &rulesMergeFunction{}
+
+type rulesMergeFunction struct{}
+
+// NewRulesMergeFunction returns a new rules_merge function
+func NewRulesMergeFunction() function.Function {
+	return &rulesMergeFunction{}
+}
+
+// Metadata implements function.Function
+func (f *rulesMergeFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "rules_merge"
+}
+
+// Definition implements function.Function
+func (f *rulesMergeFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Merges an overlay rule tree into a base rule tree",
+		Description: "Returns the base rule tree with the overlay applied, normalized as by rules_normalize. " +
+			"Rules are matched by name, recursively. A behavior or criterion of the overlay replaces the base one with the same name, " +
+			"and a variable replaces the base one with the same name. Behaviors, criteria, variables and child rules without a match " +
+			"are appended. Other fields of a rule, such as comments or criteriaMustSatisfy, are taken from the overlay when set.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "base",
+				Description: "The base rule tree JSON",
+			},
+			function.StringParameter{
+				Name:        "overlay",
+				Description: "The rule tree JSON applied on the base, whose top rule is merged into the top rule of the base regardless of its name",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run implements function.Function
+func (f *rulesMergeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var baseJSON, overlayJSON string
+	if resp.Error = req.Arguments.Get(ctx, &baseJSON, &overlayJSON); resp.Error != nil {
+		return
+	}
+
+	base, funcErr := unmarshalRulesArgument(baseJSON, 0)
+	if funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+	overlay, funcErr := unmarshalRulesArgument(overlayJSON, 1)
+	if funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+
+	if overlay.Comments != "" {
+		base.Comments = overlay.Comments
+	}
+	overlay.Rules.Name = base.Rules.Name
+	mergeRules(&base.Rules, overlay.Rules)
+
+	merged, funcErr := marshalNormalizedRules(base)
+	if funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, merged)
+}
+
+// mergeRules applies the overlay rule on the base rule with the same name
+func mergeRules(base *papi.Rules, overlay papi.Rules) {
+	if overlay.AdvancedOverride != "" {
+		base.AdvancedOverride = overlay.AdvancedOverride
+	}
+	if overlay.Comments != "" {
+		base.Comments = overlay.Comments
+	}
+	if overlay.CriteriaLocked {
+		base.CriteriaLocked = true
+	}
+	if overlay.CustomOverride != nil {
+		base.CustomOverride = overlay.CustomOverride
+	}
+	if overlay.Options.IsSecure {
+		base.Options.IsSecure = true
+	}
+	if overlay.UUID != "" {
+		base.UUID = overlay.UUID
+	}
+	if overlay.TemplateUuid != "" {
+		base.TemplateUuid = overlay.TemplateUuid
+	}
+	if overlay.TemplateLink != "" {
+		base.TemplateLink = overlay.TemplateLink
+	}
+	if overlay.CriteriaMustSatisfy != "" {
+		base.CriteriaMustSatisfy = overlay.CriteriaMustSatisfy
+	}
+
+	base.Behaviors = mergeRuleBehaviors(base.Behaviors, overlay.Behaviors)
+	base.Criteria = mergeRuleBehaviors(base.Criteria, overlay.Criteria)
+
+variables:
+	for _, variable := range overlay.Variables {
+		for i := range base.Variables {
+			if base.Variables[i].Name == variable.Name {
+				base.Variables[i] = variable
+				continue variables
+			}
+		}
+		base.Variables = append(base.Variables, variable)
+	}
+
+children:
+	for _, child := range overlay.Children {
+		for i := range base.Children {
+			if base.Children[i].Name == child.Name {
+				mergeRules(&base.Children[i], child)
+				continue children
+			}
+		}
+		base.Children = append(base.Children, child)
+	}
+}
+
+// mergeRuleBehaviors replaces base behaviors or criteria with overlay ones of the same name, keeping their position.
+// When a name is repeated, overlay entries replace base entries with that name in order.
+func mergeRuleBehaviors(base, overlay []papi.RuleBehavior) []papi.RuleBehavior {
+	replaced := make([]bool, len(base))
+behaviors:
+	for _, behavior := range overlay {
+		for i := range base {
+			if !replaced[i] && base[i].Name == behavior.Name {
+				base[i], replaced[i] = behavior, true
+				continue behaviors
+			}
+		}
+		base = append(base, behavior)
+		replaced = append(replaced, true)
+	}
+	return base
+}
@@ -0,0 +1,85 @@
+package property
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRulesMergeFunction(t *testing.T) {
+	base := `{
+		"rules": {
+			"name": "default",
+			"comments": "base",
+			"behaviors": [
+				{"name": "origin", "options": {"hostname": "origin.example.com"}},
+				{"name": "cpCode", "options": {"value": {"id": 1}}}
+			],
+			"variables": [{"name": "PMUSER_A", "value": "a", "description": "", "hidden": false, "sensitive": false}],
+			"children": [
+				{"name": "Performance", "behaviors": [{"name": "http2", "options": {}}], "criteriaMustSatisfy": "all"}
+			]
+		}
+	}`
+
+	tests := map[string]struct {
+		overlay  string
+		expected string
+		errMsg   string
+		errArg   int64
+	}{
+		"behaviors, variables and children are merged by name": {
+			overlay: `{
+				"comments": "overlay",
+				"rules": {
+					"name": "other",
+					"behaviors": [
+						{"name": "cpCode", "options": {"value": {"id": 2}}},
+						{"name": "caching", "options": {"behavior": "NO_STORE"}}
+					],
+					"variables": [
+						{"name": "PMUSER_A", "value": "x", "description": "", "hidden": false, "sensitive": false},
+						{"name": "PMUSER_B", "value": "b", "description": "", "hidden": false, "sensitive": false}
+					],
+					"children": [
+						{"name": "Performance", "behaviors": [{"name": "prefetch", "options": {"enabled": true}}], "criteriaMustSatisfy": "any"},
+						{"name": "Offload", "comments": "new"}
+					]
+				}
+			}`,
+			expected: `{"comments":"overlay","rules":{` +
+				`"behaviors":[` +
+				`{"name":"origin","options":{"hostname":"origin.example.com"}},` +
+				`{"name":"cpCode","options":{"value":{"id":2}}},` +
+				`{"name":"caching","options":{"behavior":"NO_STORE"}}],` +
+				`"children":[` +
+				`{"behaviors":[{"name":"http2","options":{}},{"name":"prefetch","options":{"enabled":true}}],"name":"Performance","options":{},"criteriaMustSatisfy":"any"},` +
+				`{"comments":"new","name":"Offload","options":{}}],` +
+				`"comments":"base","name":"default","options":{},` +
+				`"variables":[` +
+				`{"description":"","hidden":false,"name":"PMUSER_A","sensitive":false,"value":"x"},` +
+				`{"description":"","hidden":false,"name":"PMUSER_B","sensitive":false,"value":"b"}]}}`,
+		},
+		"invalid overlay": {
+			overlay: `[]`,
+			errMsg:  "invalid rule tree JSON",
+			errArg:  1,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, funcErr := runFunction(t, NewRulesMergeFunction(), types.StringUnknown(), base, test.overlay)
+			if test.errMsg != "" {
+				require.NotNil(t, funcErr)
+				assert.Contains(t, funcErr.Error(), test.errMsg)
+				require.NotNil(t, funcErr.FunctionArgument)
+				assert.Equal(t, test.errArg, *funcErr.FunctionArgument)
+				return
+			}
+			require.Nil(t, funcErr)
+			assert.Equal(t, types.StringValue(test.expected), result)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package property
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/papi"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &rulesNormalizeFunction{}
+
+type rulesNormalizeFunction struct{}
+
+// NewRulesNormalizeFunction returns a new rules_normalize function
+func NewRulesNormalizeFunction() function.Function {
+	return &rulesNormalizeFunction{}
+}
+
+// Metadata implements function.Function
+func (f *rulesNormalizeFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "rules_normalize"
+}
+
+// Definition implements function.Function
+func (f *rulesNormalizeFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Normalizes a property rule tree",
+		Description: "Returns the rule tree JSON in the form the provider compares rules in: empty collections and behavior options " +
+			"with null values are removed, variables are ordered by name, and keys are sorted. " +
+			"Rule trees which the provider considers equal are normalized to the same string.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "rules",
+				Description: "The rule tree JSON, an object with the `rules` key as accepted by the `rules` attribute of `akamai_property`",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run implements function.Function
+func (f *rulesNormalizeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var rulesJSON string
+	if resp.Error = req.Arguments.Get(ctx, &rulesJSON); resp.Error != nil {
+		return
+	}
+
+	rules, funcErr := unmarshalRulesArgument(rulesJSON, 0)
+	if funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+
+	normalized, funcErr := marshalNormalizedRules(rules)
+	if funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, normalized)
+}
+
+// unmarshalRulesArgument decodes the rule tree JSON passed as the argument at the given position
+func unmarshalRulesArgument(rulesJSON string, position int64) (*papi.RulesUpdate, *function.FuncError) {
+	var rules papi.RulesUpdate
+	if err := json.Unmarshal([]byte(rulesJSON), &rules); err != nil {
+		return nil, function.NewArgumentFuncError(position, fmt.Sprintf("invalid rule tree JSON: %s", err))
+	}
+	if rules.Rules.Name == "" {
+		return nil, function.NewArgumentFuncError(position, "rule tree JSON has to be an object with the 'rules' key holding a named rule")
+	}
+	return &rules, nil
+}
+
+// marshalNormalizedRules normalizes the rule tree the same way rules are compared for diffs and encodes it
+func marshalNormalizedRules(rules *papi.RulesUpdate) (string, *function.FuncError) {
+	normalizeRules(&rules.Rules)
+	encoded, err := json.Marshal(rules)
+	if err != nil {
+		return "", function.NewFuncError(fmt.Sprintf("encoding rule tree failed: %s", err))
+	}
+	return string(encoded), nil
+}
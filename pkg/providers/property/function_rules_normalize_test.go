@@ -0,0 +1,95 @@
+package property
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runFunction runs the function with string arguments and returns its result of the given type
+func runFunction(t *testing.T, f function.Function, result attr.Value, args ...string) (attr.Value, *function.FuncError) {
+	values := make([]attr.Value, 0, len(args))
+	for _, arg := range args {
+		values = append(values, types.StringValue(arg))
+	}
+
+	var definition function.DefinitionResponse
+	f.Definition(context.Background(), function.DefinitionRequest{}, &definition)
+	require.Len(t, definition.Definition.Parameters, len(args))
+
+	resp := function.RunResponse{Result: function.NewResultData(result)}
+	f.Run(context.Background(), function.RunRequest{Arguments: function.NewArgumentsData(values)}, &resp)
+	return resp.Result.Value(), resp.Error
+}
+
+func TestRulesNormalizeFunction(t *testing.T) {
+	tests := map[string]struct {
+		rules    string
+		expected string
+		errMsg   string
+	}{
+		"normalized like rules are compared": {
+			rules: `{
+				"rules": {
+					"name": "default",
+					"behaviors": [{"name": "origin", "options": {"hostname": "example.com", "mtls": null}}],
+					"children": [{"name": "child", "behaviors": [], "criteria": [], "children": []}],
+					"variables": [
+						{"name": "PMUSER_B", "value": "b", "description": "", "hidden": false, "sensitive": false},
+						{"name": "PMUSER_A", "value": "a", "description": "", "hidden": false, "sensitive": false}
+					]
+				},
+				"comments": "initial"
+			}`,
+			expected: `{"comments":"initial","rules":{` +
+				`"behaviors":[{"name":"origin","options":{"hostname":"example.com"}}],` +
+				`"children":[{"name":"child","options":{}}],` +
+				`"name":"default","options":{},` +
+				`"variables":[` +
+				`{"description":"","hidden":false,"name":"PMUSER_A","sensitive":false,"value":"a"},` +
+				`{"description":"","hidden":false,"name":"PMUSER_B","sensitive":false,"value":"b"}]}}`,
+		},
+		"invalid JSON": {
+			rules:  `{"rules":`,
+			errMsg: "invalid rule tree JSON",
+		},
+		"missing rules": {
+			rules:  `{"name": "default"}`,
+			errMsg: "has to be an object with the 'rules' key",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, funcErr := runFunction(t, NewRulesNormalizeFunction(), types.StringUnknown(), test.rules)
+			if test.errMsg != "" {
+				require.NotNil(t, funcErr)
+				assert.Contains(t, funcErr.Error(), test.errMsg)
+				require.NotNil(t, funcErr.FunctionArgument)
+				assert.Equal(t, int64(0), *funcErr.FunctionArgument)
+				return
+			}
+			require.Nil(t, funcErr)
+			assert.Equal(t, types.StringValue(test.expected), result)
+		})
+	}
+}
+
+func TestRulesNormalizeFunctionEqualRules(t *testing.T) {
+	a, funcErr := runFunction(t, NewRulesNormalizeFunction(), types.StringUnknown(),
+		`{"rules": {"name": "default", "variables": [{"name": "B"}, {"name": "A"}], "behaviors": [{"name": "cpCode", "options": {"id": 1, "x": null}}]}}`)
+	require.Nil(t, funcErr)
+	b, funcErr := runFunction(t, NewRulesNormalizeFunction(), types.StringUnknown(),
+		`{"rules": {"behaviors": [{"options": {"id": 1}, "name": "cpCode"}], "children": [], "name": "default", "variables": [{"name": "A"}, {"name": "B"}]}}`)
+	require.Nil(t, funcErr)
+	assert.Equal(t, a, b)
+
+	equal, err := rulesJSONEqual(a.(types.String).ValueString(),
+		`{"rules": {"name": "default", "variables": [{"name": "B"}, {"name": "A"}], "behaviors": [{"name": "cpCode", "options": {"id": 1}}]}}`)
+	require.NoError(t, err)
+	assert.True(t, equal)
+}
@@ -100,32 +100,4 @@ var (
 
 	// ErrPropertyInclude is returned when operation on property include fails
 	ErrPropertyInclude = errors.New("property include")
-
-	// DiagErrActivationTimeout returned on activation poll timeout
-	DiagErrActivationTimeout = diag.Diagnostic{
-		Severity: diag.Error,
-		Summary:  "Timeout waiting for activation status",
-		Detail: `
-The activation creation request has been started successfully, however the operation timeout was 
-exceeded while waiting for the remote resource to update. You may retry the operation to continue 
-to wait for the final status.
-
-It is recommended that the timeout for activation resources be set to greater than 90 minutes.
-See: https://www.terraform.io/docs/configuration/resources.html#operation-timeouts
-`,
-	}
-
-	// DiagErrActivationCanceled is returned on activation poll cancel
-	DiagErrActivationCanceled = diag.Diagnostic{
-		Severity: diag.Error,
-		Summary:  "Operation canceled while waiting for activation status",
-		Detail: `
-The activation creation request has been started successfully, however the a cancellation was received
-while waiting for the remote resource to update. You may retry the operation to continue to wait for 
-the final status.
-
-It is recommended that the timeout for activation resources be set to greater than 90 minutes.
-See: https://www.terraform.io/docs/configuration/resources.html#operation-timeouts
-`,
-	}
 )
@@ -13,6 +13,7 @@ import (
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/subprovider"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -23,7 +24,9 @@ type (
 )
 
 var (
-	_ subprovider.Subprovider = &Subprovider{}
+	_ subprovider.Subprovider            = &Subprovider{}
+	_ subprovider.WithFunctions          = &Subprovider{}
+	_ subprovider.WithDeletionProtection = &Subprovider{}
 )
 
 var (
@@ -76,25 +79,27 @@ func (p *Subprovider) SDKResources() map[string]*schema.Resource {
 // SDKDataSources returns the property data sources implemented using terraform-plugin-sdk
 func (p *Subprovider) SDKDataSources() map[string]*schema.Resource {
 	return map[string]*schema.Resource{
-		"akamai_contract":                    dataSourcePropertyContract(),
-		"akamai_contracts":                   dataSourceContracts(),
-		"akamai_cp_code":                     dataSourceCPCode(),
-		"akamai_group":                       dataSourcePropertyGroup(),
-		"akamai_groups":                      dataSourcePropertyMultipleGroups(),
-		"akamai_properties":                  dataSourceProperties(),
-		"akamai_properties_search":           dataSourcePropertiesSearch(),
-		"akamai_property":                    dataSourceProperty(),
-		"akamai_property_activation":         dataSourcePropertyActivation(),
-		"akamai_property_hostnames":          dataSourcePropertyHostnames(),
-		"akamai_property_include_activation": dataSourcePropertyIncludeActivation(),
-		"akamai_property_include_parents":    dataSourcePropertyIncludeParents(),
-		"akamai_property_include_rules":      dataSourcePropertyIncludeRules(),
-		"akamai_property_includes":           dataSourcePropertyIncludes(),
-		"akamai_property_products":           dataSourcePropertyProducts(),
-		"akamai_property_rule_formats":       dataSourcePropertyRuleFormats(),
-		"akamai_property_rules":              dataSourcePropertyRules(),
-		"akamai_property_rules_builder":      dataSourcePropertyRulesBuilder(),
-		"akamai_property_rules_template":     dataSourcePropertyRulesTemplate(),
+		"akamai_contract":                      dataSourcePropertyContract(),
+		"akamai_contracts":                     dataSourceContracts(),
+		"akamai_cp_code":                       dataSourceCPCode(),
+		"akamai_group":                         dataSourcePropertyGroup(),
+		"akamai_groups":                        dataSourcePropertyMultipleGroups(),
+		"akamai_properties":                    dataSourceProperties(),
+		"akamai_properties_search":             dataSourcePropertiesSearch(),
+		"akamai_property":                      dataSourceProperty(),
+		"akamai_property_activation":           dataSourcePropertyActivation(),
+		"akamai_property_hostnames":            dataSourcePropertyHostnames(),
+		"akamai_property_include_activation":   dataSourcePropertyIncludeActivation(),
+		"akamai_property_include_parents":      dataSourcePropertyIncludeParents(),
+		"akamai_property_include_rules":        dataSourcePropertyIncludeRules(),
+		"akamai_property_includes":             dataSourcePropertyIncludes(),
+		"akamai_property_products":             dataSourcePropertyProducts(),
+		"akamai_property_rule_formats":         dataSourcePropertyRuleFormats(),
+		"akamai_property_rules":                dataSourcePropertyRules(),
+		"akamai_property_rules_builder":        dataSourcePropertyRulesBuilder(),
+		"akamai_property_rules_format_upgrade": dataSourcePropertyRulesFormatUpgrade(),
+		"akamai_property_rules_hcl":            dataSourcePropertyRulesHCL(),
+		"akamai_property_rules_template":       dataSourcePropertyRulesTemplate(),
 	}
 }
 
@@ -112,6 +117,23 @@ func (p *Subprovider) FrameworkDataSources() []func() datasource.DataSource {
 	}
 }
 
+// SDKActiveVersions returns the functions returning the active versions of the property resources
+// implemented using terraform-plugin-sdk, which are protected from deletion while active
+func (p *Subprovider) SDKActiveVersions() map[string]subprovider.ActiveVersionsFunc {
+	return map[string]subprovider.ActiveVersionsFunc{
+		"akamai_property": propertyActiveVersions,
+	}
+}
+
+// FrameworkFunctions returns the property provider functions implemented using terraform-plugin-framework
+func (p *Subprovider) FrameworkFunctions() []func() function.Function {
+	return []func() function.Function{
+		NewRulesNormalizeFunction,
+		NewRulesMergeFunction,
+		NewRulesFindBehaviorFunction,
+	}
+}
+
 // compactJSON converts a JSON-encoded byte slice to a compact form (so our JSON fixtures can be readable)
 func compactJSON(encoded []byte) string {
 	buf := bytes.Buffer{}
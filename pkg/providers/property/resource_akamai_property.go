@@ -14,7 +14,9 @@ import (
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/session"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/str"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/versionnotes"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/subprovider"
 	"github.com/apex/log"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -115,6 +117,11 @@ func resourceProperty() *schema.Resource {
 				DiffSuppressFunc: diffSuppressPropertyRules,
 				StateFunc:        rulesStateFunc,
 			},
+			"rules_diff": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Human-readable description of the changes of rules in the plan, e.g. added rules or changed behavior options, one change per line",
+			},
 			"version_notes": {
 				Type:             schema.TypeString,
 				Optional:         true,
@@ -269,6 +276,7 @@ func hashHostname(v any) int {
 // propertyRulesCustomDiff compares Rules.Criteria and Rules.Children fields from terraform state
 // and from a new configuration. If some of these fields are empty lists in the new configuration and
 // are nil in the terraform state, then this function returns no difference for these fields.
+// When the rules differ, the changes are described in rules_diff.
 func propertyRulesCustomDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
 	o, n := diff.GetChange("rules")
 	oldValue, newValue := o.(string), n.(string)
@@ -286,6 +294,9 @@ func propertyRulesCustomDiff(_ context.Context, diff *schema.ResourceDiff, _ int
 		if err = diff.SetNew("rules", rules); err != nil {
 			return fmt.Errorf("cannot set a new diff value for 'rules' %s", err)
 		}
+		if err = diff.SetNew("rules_diff", ""); err != nil {
+			return fmt.Errorf("cannot set a new diff value for 'rules_diff' %s", err)
+		}
 		return nil
 	}
 
@@ -307,8 +318,9 @@ func propertyRulesCustomDiff(_ context.Context, diff *schema.ResourceDiff, _ int
 		return nil
 	}
 
+	// with a version notes template, the comments of the rules are rendered into the notes of the version
 	versionNotes, _ := tf.NewRawConfig(diff).GetOk("version_notes")
-	if versionNotes != nil {
+	if versionNotes != nil || versionnotes.Enabled() {
 		newRulesUpdate.Comments = oldRulesUpdate.Comments
 	}
 
@@ -320,6 +332,9 @@ func propertyRulesCustomDiff(_ context.Context, diff *schema.ResourceDiff, _ int
 	if err = diff.SetNew("rules", string(rules)); err != nil {
 		return fmt.Errorf("cannot set a new diff value for 'rules' %s", err)
 	}
+	if err = diff.SetNew("rules_diff", rulesDiff(oldRulesUpdate, newRulesUpdate)); err != nil {
+		return fmt.Errorf("cannot set a new diff value for 'rules_diff' %s", err)
+	}
 	return nil
 }
 
@@ -404,7 +419,7 @@ func canTriggerNewPropertyVersion(rc tf.ResourceChangeFetcher, rd tf.ResourceDat
 	}
 
 	versionNotes, _ := rd.GetOk("version_notes")
-	if versionNotes == nil && oldRules.Comments != newRules.Comments {
+	if versionNotes == nil && !versionnotes.Enabled() && oldRules.Comments != newRules.Comments {
 		return true, nil
 	}
 
@@ -520,6 +535,10 @@ func resourcePropertyCreate(ctx context.Context, d *schema.ResourceData, m inter
 			d.Partial(true)
 			return diag.FromErr(err)
 		}
+		if err = renderVersionNotes(meta, d, "akamai_property", propertyName, &rulesUpdate); err != nil {
+			d.Partial(true)
+			return diag.FromErr(err)
+		}
 
 		if err := updatePropertyRules(ctx, client, property, rulesUpdate, ruleFormat); err != nil {
 			d.Partial(true)
@@ -651,7 +670,8 @@ func resourcePropertyRead(ctx context.Context, d *schema.ResourceData, m interfa
 		"rule_format":        ruleFormat,
 		"rule_errors":        papiErrorsToList(ruleErrors),
 		"read_version":       readVersionID,
-		"version_notes":      res.Version.Note,
+		"version_notes":      versionnotes.State(d.Get("version_notes").(string), res.Version.Note),
+		"rules_diff":         "",
 	}
 	if res.Version.ProductID != "" {
 		attrs["product_id"] = res.Version.ProductID
@@ -766,6 +786,7 @@ func resourcePropertyUpdate(ctx context.Context, d *schema.ResourceData, m inter
 	}
 
 	// if read_version is not the latest version or not editable then create a new version from it before proceeding
+	var versionCreated bool
 	if (propertyVersion != property.LatestVersion) || (resp.Version.ProductionStatus != papi.VersionStatusInactive || resp.Version.StagingStatus != papi.VersionStatusInactive) {
 		// The latest version has been activated on either production or staging, so we need to create a new version to apply changes on
 		versionID, err := createPropertyVersion(ctx, client, property, propertyVersion)
@@ -774,6 +795,7 @@ func resourcePropertyUpdate(ctx context.Context, d *schema.ResourceData, m inter
 			return diag.FromErr(err)
 		}
 		property.LatestVersion = versionID
+		versionCreated = true
 		if err = d.Set("read_version", 0); err != nil {
 			return diag.FromErr(err)
 		}
@@ -794,8 +816,9 @@ func resourcePropertyUpdate(ctx context.Context, d *schema.ResourceData, m inter
 		}
 	}
 
-	if shouldUpdateRuleTree(d) {
-		if err := updateRuleTree(ctx, client, property, d); err != nil {
+	// the rule tree of a new version is also updated to stamp the version notes rendered from the template
+	if shouldUpdateRuleTree(d) || versionCreated && versionnotes.Enabled() {
+		if err := updateRuleTree(ctx, meta.Must(m), client, property, d); err != nil {
 			return diag.FromErr(err)
 		}
 	}
@@ -803,7 +826,7 @@ func resourcePropertyUpdate(ctx context.Context, d *schema.ResourceData, m inter
 	return resourcePropertyRead(ctx, d, m)
 }
 
-func updateRuleTree(ctx context.Context, client papi.PAPI, property papi.Property,
+func updateRuleTree(ctx context.Context, m meta.Meta, client papi.PAPI, property papi.Property,
 	d *schema.ResourceData) error {
 	ruleFormat, err := tf.GetStringValue("rule_format", d)
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
@@ -825,6 +848,10 @@ func updateRuleTree(ctx context.Context, client papi.PAPI, property papi.Propert
 		d.Partial(true)
 		return err
 	}
+	if err = renderVersionNotes(m, d, "akamai_property", property.PropertyName, &rulesUpdate); err != nil {
+		d.Partial(true)
+		return err
+	}
 
 	if err := updatePropertyRules(ctx, client, property, rulesUpdate, ruleFormat); err != nil {
 		d.Partial(true)
@@ -864,6 +891,32 @@ func resourcePropertyDelete(ctx context.Context, d *schema.ResourceData, m inter
 	return nil
 }
 
+// propertyActiveVersions returns the versions of the property active on staging and production,
+// none for properties maintained by the 'akamai_property_bootstrap' resource, which are not removed by this one
+func propertyActiveVersions(ctx context.Context, d *schema.ResourceData, m interface{}) (subprovider.ActiveVersions, error) {
+	ctx = log.NewContext(ctx, meta.Must(m).Log("PAPI", "propertyActiveVersions"))
+	client := Client(meta.Must(m))
+
+	if propertyID, _ := d.Get("property_id").(string); propertyID != "" {
+		return subprovider.ActiveVersions{}, nil
+	}
+	contractID := str.AddPrefix(d.Get("contract_id").(string), "ctr_")
+	groupID := str.AddPrefix(d.Get("group_id").(string), "grp_")
+	property, err := fetchLatestProperty(ctx, client, d.Id(), groupID, contractID)
+	if err != nil {
+		return subprovider.ActiveVersions{}, err
+	}
+
+	var versions subprovider.ActiveVersions
+	if property.StagingVersion != nil {
+		versions.Staging = fmt.Sprintf("version %d", *property.StagingVersion)
+	}
+	if property.ProductionVersion != nil {
+		versions.Production = fmt.Sprintf("version %d", *property.ProductionVersion)
+	}
+	return versions, nil
+}
+
 func resourcePropertyImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	ctx = log.NewContext(ctx, meta.Must(m).Log("PAPI", "resourcePropertyImport"))
 
@@ -1315,6 +1368,33 @@ func newRulesUpdate(rulesJSON, comments string) (papi.RulesUpdate, error) {
 	return rules, nil
 }
 
+// renderVersionNotes sets the comments of the rules, which become the notes of the version, to the notes rendered
+// from the version notes template, if any. The version notes or the comments of the rules of the configuration
+// are the notes passed to the template, as the comments of the planned rules are those of the previous version.
+func renderVersionNotes(m meta.Meta, d tf.RawConfigGetter, resource, name string, rules *papi.RulesUpdate) error {
+	if !versionnotes.Enabled() {
+		return nil
+	}
+
+	var notes string
+	rawConfig := tf.NewRawConfig(d)
+	if versionNotes, ok := rawConfig.GetOk("version_notes"); ok {
+		notes, _ = versionNotes.(string)
+	} else if rulesJSON, ok := rawConfig.GetOk("rules"); ok {
+		var configured papi.RulesUpdate
+		if rulesJSON, ok := rulesJSON.(string); ok && json.Unmarshal([]byte(rulesJSON), &configured) == nil {
+			notes = configured.Comments
+		}
+	}
+
+	rendered, err := versionnotes.Render(m, resource, name, notes)
+	if err != nil {
+		return err
+	}
+	rules.Comments = rendered
+	return nil
+}
+
 func updatePropertyRules(ctx context.Context, client papi.PAPI, property papi.Property, rules papi.RulesUpdate, ruleFormat string) error {
 	logger := log.FromContext(ctx)
 
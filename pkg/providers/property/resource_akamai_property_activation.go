@@ -12,10 +12,12 @@ import (
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/papi"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/activation"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/date"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/str"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/timeouts"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/versionnotes"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
 	"github.com/apex/log"
 	"github.com/hashicorp/go-hclog"
@@ -60,6 +62,13 @@ var (
 
 	// CreateActivationRetry poll wait time code waits between retries for activation creation
 	CreateActivationRetry = 10 * time.Second
+
+	// ActivationRollbackTimeout is the time allowed for rolling back a failed activation, counted from the failure,
+	// as the timeout of the resource operation may already be exceeded
+	ActivationRollbackTimeout = time.Minute * 90
+
+	// rollbackPollInterval is the interval for polling an activation which could not be canceled, until it ends
+	rollbackPollInterval = ActivationPollMinimum
 )
 
 var akamaiPropertyActivationSchema = map[string]*schema.Schema{
@@ -124,6 +133,13 @@ var akamaiPropertyActivationSchema = map[string]*schema.Schema{
 		Description: "Provides an audit record when activating on a production network",
 		Elem:        complianceRecordSchema,
 	},
+	"rollback_on_failure": {
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+		Description: "Re-activates the version active on the network before, when the activation fails, is aborted or times out. " +
+			"Fast fallback is used when available. Default is false",
+	},
 	"timeouts": {
 		Type:        schema.TypeList,
 		Optional:    true,
@@ -225,6 +241,11 @@ func resourcePropertyActivationCreate(ctx context.Context, d *schema.ResourceDat
 		return diag.FromErr(err)
 	}
 
+	rollback, err := newActivationRollback(ctx, client, d, propertyID, network, version)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	// we create a new property activation in case of no previous activation, or deleted activation
 	if activation == nil || activation.ActivationType == papi.ActivationTypeDeactivate || activation.PropertyVersion != version {
 		notifySet, err := tf.GetSetValue("contact", d)
@@ -240,6 +261,9 @@ func resourcePropertyActivationCreate(ctx context.Context, d *schema.ResourceDat
 		if err != nil && !errors.Is(err, tf.ErrNotFound) {
 			return diag.FromErr(err)
 		}
+		if note, err = versionnotes.Render(meta, "akamai_property_activation", propertyID, note); err != nil {
+			return diag.FromErr(err)
+		}
 
 		createActivationRequest := papi.CreateActivationRequest{
 			PropertyID: propertyID,
@@ -253,6 +277,12 @@ func resourcePropertyActivationCreate(ctx context.Context, d *schema.ResourceDat
 			},
 		}
 
+		release, diagErr := acquireActivationSlot(ctx, "property activation", network)
+		if diagErr != nil {
+			return diagErr
+		}
+		defer release()
+
 		logger.Debug("creating activation")
 		activationID, diagErr := createActivation(ctx, client, addPropertyComplianceRecord(complianceRecord, createActivationRequest))
 		if diagErr != nil {
@@ -275,9 +305,10 @@ func resourcePropertyActivationCreate(ctx context.Context, d *schema.ResourceDat
 		}
 	}
 
+	activationID := activation.ActivationID
 	activation, diagErr := pollActivation(ctx, client, activation, propertyID)
 	if diagErr != nil {
-		return diagErr
+		return append(diagErr, rollback.rollbackFailed(ctx, client, d, activationID)...)
 	}
 
 	attrs := map[string]interface{}{
@@ -359,6 +390,9 @@ func resourcePropertyActivationDelete(ctx context.Context, d *schema.ResourceDat
 		if err != nil && !errors.Is(err, tf.ErrNotFound) {
 			return diag.FromErr(err)
 		}
+		if note, err = versionnotes.Render(meta, "akamai_property_activation", propertyID, note); err != nil {
+			return diag.FromErr(err)
+		}
 
 		deleteActivationRequest := papi.CreateActivationRequest{
 			PropertyID: propertyID,
@@ -372,6 +406,12 @@ func resourcePropertyActivationDelete(ctx context.Context, d *schema.ResourceDat
 			},
 		}
 
+		release, diagErr := acquireActivationSlot(ctx, "property deactivation", network)
+		if diagErr != nil {
+			return diagErr
+		}
+		defer release()
+
 		deleteActivationID, diagErr := createActivation(ctx, client, addPropertyComplianceRecord(complianceRecord, deleteActivationRequest))
 		if diagErr != nil {
 			return diagErr
@@ -399,32 +439,8 @@ func resourcePropertyActivationDelete(ctx context.Context, d *schema.ResourceDat
 		}
 	}
 
-	// deactivations also use status Active for when they are fully processed
-	for activation.Status != papi.ActivationStatusActive {
-		if activation.Status == papi.ActivationStatusAborted {
-			return diag.FromErr(fmt.Errorf("deactivation request aborted"))
-		}
-		if activation.Status == papi.ActivationStatusFailed {
-			return diag.FromErr(fmt.Errorf("deactivation request failed in downstream system"))
-		}
-		select {
-		case <-time.After(tf.MaxDuration(ActivationPollInterval, ActivationPollMinimum)):
-			act, err := client.GetActivation(ctx, papi.GetActivationRequest{
-				ActivationID: activation.ActivationID,
-				PropertyID:   propertyID,
-			})
-			if err != nil {
-				return diag.FromErr(err)
-			}
-			activation = act.Activation
-
-			if err = setErrorsAndWarnings(d, flattenErrorArray(act.Errors), flattenErrorArray(act.Warnings)); err != nil {
-				return diag.FromErr(err)
-			}
-
-		case <-ctx.Done():
-			return diag.FromErr(fmt.Errorf("activation context terminated: %w", ctx.Err()))
-		}
+	if diags := pollDeactivation(ctx, client, d, activation, propertyID); diags != nil {
+		return diags
 	}
 
 	d.SetId("")
@@ -493,7 +509,7 @@ func resourcePropertyActivationRead(ctx context.Context, d *schema.ResourceData,
 		"version":       activation.PropertyVersion,
 		"network":       network,
 		"activation_id": activation.ActivationID,
-		"note":          activation.Note,
+		"note":          versionnotes.State(d.Get("note").(string), activation.Note),
 		"contact":       activation.NotifyEmails,
 	}
 
@@ -599,6 +615,9 @@ func resourcePropertyActivationUpdate(ctx context.Context, d *schema.ResourceDat
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return diag.FromErr(err)
 	}
+	if note, err = versionnotes.Render(meta, "akamai_property_activation", propertyID, note); err != nil {
+		return diag.FromErr(err)
+	}
 
 	// check to see if this tree has any issues
 	rules, err := client.GetRuleTree(ctx, papi.GetRuleTreeRequest{
@@ -635,6 +654,11 @@ func resourcePropertyActivationUpdate(ctx context.Context, d *schema.ResourceDat
 		return diag.FromErr(err)
 	}
 
+	rollback, err := newActivationRollback(ctx, client, d, propertyID, network, version)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	if propertyActivation == nil || versionStatus == papi.VersionStatusDeactivated {
 		notifySet, err := tf.GetSetValue("contact", d)
 		if err != nil {
@@ -657,6 +681,12 @@ func resourcePropertyActivationUpdate(ctx context.Context, d *schema.ResourceDat
 			},
 		}
 
+		release, diagErr := acquireActivationSlot(ctx, "property activation", network)
+		if diagErr != nil {
+			return diagErr
+		}
+		defer release()
+
 		activationID, diagErr := createActivation(ctx, client, addPropertyComplianceRecord(complianceRecord, createActivationRequest))
 		if diagErr != nil {
 			return diagErr
@@ -678,9 +708,10 @@ func resourcePropertyActivationUpdate(ctx context.Context, d *schema.ResourceDat
 		}
 	}
 
+	activationID := propertyActivation.ActivationID
 	propertyActivation, diagErr := pollActivation(ctx, client, propertyActivation, propertyID)
 	if diagErr != nil {
-		return diagErr
+		return append(diagErr, rollback.rollbackFailed(ctx, client, d, activationID)...)
 	}
 
 	attrs := map[string]interface{}{
@@ -925,52 +956,303 @@ func networkAlias(d *schema.ResourceData) (papi.ActivationNetwork, error) {
 	return papi.ActivationNetwork(alias), nil
 }
 
-func pollActivation(ctx context.Context, client papi.PAPI, activation *papi.Activation, propertyID string) (*papi.Activation, diag.Diagnostics) {
+func pollActivation(ctx context.Context, client papi.PAPI, act *papi.Activation, propertyID string) (*papi.Activation, diag.Diagnostics) {
 
 	retriesMax := 5
 	retries5xx := 0
 
-	for activation.Status != papi.ActivationStatusActive {
-		if activation.Status == papi.ActivationStatusAborted {
-			return nil, diag.FromErr(fmt.Errorf("activation request aborted"))
+	err := activationPoller("property activation").Wait(ctx, activationStatus(act.Status), func(ctx context.Context) (activation.Status, error) {
+		resp, err := client.GetActivation(ctx, papi.GetActivationRequest{
+			ActivationID: act.ActivationID,
+			PropertyID:   propertyID,
+		})
+		if err != nil {
+			var target = &papi.Error{}
+			if !errors.As(err, &target) {
+				return activation.Status{}, fmt.Errorf("error has unexpected type: %T", err)
+			}
+			if target.StatusCode >= 500 {
+				retries5xx = retries5xx + 1
+				if retries5xx > retriesMax {
+					return activation.Status{}, fmt.Errorf("reached max number of 5xx retries: %d", retries5xx)
+				}
+				return activationStatus(act.Status), nil
+			}
+
+			return activation.Status{}, err
 		}
-		if activation.Status == papi.ActivationStatusFailed {
-			return nil, diag.FromErr(fmt.Errorf("activation request failed in downstream system"))
+		retries5xx = 0
+		act = resp.Activation
+		return activationStatus(act.Status), nil
+	})
+	if err != nil {
+		return nil, activation.Diagnostics(err)
+	}
+	return act, nil
+}
+
+// pollDeactivation waits for the deactivation, setting its errors and warnings
+func pollDeactivation(ctx context.Context, client papi.PAPI, d *schema.ResourceData, act *papi.Activation, propertyID string) diag.Diagnostics {
+	err := activationPoller("property deactivation").Wait(ctx, activationStatus(act.Status), func(ctx context.Context) (activation.Status, error) {
+		resp, err := client.GetActivation(ctx, papi.GetActivationRequest{
+			ActivationID: act.ActivationID,
+			PropertyID:   propertyID,
+		})
+		if err != nil {
+			return activation.Status{}, err
 		}
-		select {
-		case <-time.After(tf.MaxDuration(ActivationPollInterval, ActivationPollMinimum)):
-			act, err := client.GetActivation(ctx, papi.GetActivationRequest{
-				ActivationID: activation.ActivationID,
-				PropertyID:   propertyID,
-			})
-			if err != nil {
-				var target = &papi.Error{}
-				if !errors.As(err, &target) {
-					return nil, diag.Errorf("error has unexpected type: %T", err)
-				}
-				if target.StatusCode >= 500 {
-					retries5xx = retries5xx + 1
-					if retries5xx > retriesMax {
-						return nil, diag.Errorf("reached max number of 5xx retries: %d", retries5xx)
-					}
-					continue
-				}
+		act = resp.Activation
 
-				return nil, diag.FromErr(err)
-			}
-			retries5xx = 0
-			activation = act.Activation
-
-		case <-ctx.Done():
-			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-				return nil, diag.Diagnostics{DiagErrActivationTimeout}
-			} else if errors.Is(ctx.Err(), context.Canceled) {
-				return nil, diag.Diagnostics{DiagErrActivationCanceled}
-			}
-			return nil, diag.FromErr(fmt.Errorf("activation context terminated: %w", ctx.Err()))
+		if err = setErrorsAndWarnings(d, flattenErrorArray(resp.Errors), flattenErrorArray(resp.Warnings)); err != nil {
+			return activation.Status{}, err
 		}
+		return activationStatus(act.Status), nil
+	})
+	if err != nil {
+		return activation.Diagnostics(err)
 	}
-	return activation, nil
+	return nil
+}
+
+// activationRollback re-activates the version which was active on the network before the activation of another version,
+// when that activation fails, is aborted or times out
+type activationRollback struct {
+	propertyID       string
+	network          papi.ActivationNetwork
+	version          int
+	previous         *papi.Activation
+	notify           []string
+	complianceRecord []interface{}
+	rolledBack       bool
+}
+
+// newActivationRollback records the version active on the network before the given version is activated,
+// if rollback_on_failure is enabled, and returns nil otherwise
+func newActivationRollback(ctx context.Context, client papi.PAPI, d *schema.ResourceData, propertyID string,
+	network papi.ActivationNetwork, version int) (*activationRollback, error) {
+	// Schema guarantees these types
+	if !d.Get("rollback_on_failure").(bool) {
+		return nil, nil
+	}
+
+	activations, err := client.GetActivations(ctx, papi.GetActivationsRequest{PropertyID: propertyID})
+	if err != nil {
+		return nil, err
+	}
+	previous, err := findLatestActive(activations.Activations.Items, network)
+	if err != nil && !errors.Is(err, errNoActiveVersionFound) {
+		return nil, err
+	}
+	if previous != nil && previous.PropertyVersion == version {
+		previous = nil
+	}
+
+	notifySet, err := tf.GetSetValue("contact", d)
+	if err != nil {
+		return nil, err
+	}
+	var notify []string
+	for _, contact := range notifySet.List() {
+		notify = append(notify, cast.ToString(contact))
+	}
+	complianceRecord, err := tf.GetListValue("compliance_record", d)
+	if err != nil && !errors.Is(err, tf.ErrNotFound) {
+		return nil, err
+	}
+
+	return &activationRollback{
+		propertyID:       propertyID,
+		network:          network,
+		version:          version,
+		previous:         previous,
+		notify:           notify,
+		complianceRecord: complianceRecord,
+	}, nil
+}
+
+// rollbackFailed rolls back the activation of the given ID which failed, and marks the update as partial
+// when the previous version is active again, so that the state keeps it instead of the version which failed.
+func (r *activationRollback) rollbackFailed(ctx context.Context, client papi.PAPI, d *schema.ResourceData, activationID string) diag.Diagnostics {
+	diags := r.rollback(ctx, client, activationID)
+	if r != nil && r.rolledBack {
+		d.Partial(true)
+	}
+	return diags
+}
+
+// rollback returns to the previously active version after waiting for the activation of the given ID failed.
+// Failed and aborted activations are followed by the activation of the previous version, using fast fallback when PAPI offers it.
+// Activations which are still pending after the timeout are canceled, which PAPI allows only before they are deployed.
+// When the cancellation is rejected, e.g. in the ZONE_1 status, the activation is awaited and the previous version is activated
+// after it ends. The returned diagnostics report the result, or are nil when nothing was rolled back, e.g. because the activation
+// succeeded or the operation was canceled.
+func (r *activationRollback) rollback(ctx context.Context, client papi.PAPI, activationID string) diag.Diagnostics {
+	if r == nil || errors.Is(ctx.Err(), context.Canceled) {
+		return nil
+	}
+	log := hclog.FromContext(ctx)
+	timedOut := errors.Is(ctx.Err(), context.DeadlineExceeded)
+	var previousVersion int
+	if r.previous != nil {
+		previousVersion = r.previous.PropertyVersion
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), ActivationRollbackTimeout)
+	defer cancel()
+
+	resp, err := client.GetActivation(ctx, papi.GetActivationRequest{
+		ActivationID: activationID,
+		PropertyID:   r.propertyID,
+	})
+	if err != nil {
+		return r.failed(previousVersion, diag.FromErr(err))
+	}
+	failed := resp.Activation
+
+	switch failed.Status {
+	case papi.ActivationStatusFailed, papi.ActivationStatusAborted:
+	case papi.ActivationStatusNew, papi.ActivationStatusPending, papi.ActivationStatusZone1, papi.ActivationStatusZone2, papi.ActivationStatusZone3:
+		if !timedOut {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	if r.previous == nil {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "Activation not rolled back",
+			Detail: fmt.Sprintf("The activation of version %d on %s ended with status %s, but no other version of the property was active before.",
+				r.version, r.network, failed.Status),
+		}}
+	}
+
+	var outcome string
+	if failed.Status != papi.ActivationStatusFailed && failed.Status != papi.ActivationStatusAborted {
+		pendingStatus := failed.Status
+		log.Debug("canceling pending activation", "activationID", activationID)
+		_, err := client.CancelActivation(ctx, papi.CancelActivationRequest{
+			PropertyID:   r.propertyID,
+			ActivationID: activationID,
+		})
+		if err == nil {
+			r.rolledBack = true
+			return diag.Diagnostics{{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Rolled back to version %d", previousVersion),
+				Detail: fmt.Sprintf("The activation of version %d on %s was canceled as it was still %s after the timeout, so version %d remains active.",
+					r.version, r.network, pendingStatus, previousVersion),
+			}}
+		}
+
+		log.Debug("activation could not be canceled, waiting for it to end", "activationID", activationID, "error", err)
+		if failed, err = waitActivationEnd(ctx, client, failed, r.propertyID); err != nil {
+			return r.failed(previousVersion, activation.Diagnostics(err))
+		}
+		outcome = fmt.Sprintf("was still %s after the timeout and could not be canceled, it ended with status %s", pendingStatus, failed.Status)
+	} else {
+		outcome = fmt.Sprintf("ended with status %s", failed.Status)
+	}
+
+	fastFallback := failed.FallbackInfo != nil && failed.FallbackInfo.CanFastFallback &&
+		failed.FallbackInfo.FallbackVersion == previousVersion
+	request := papi.CreateActivationRequest{
+		PropertyID: r.propertyID,
+		Activation: papi.Activation{
+			ActivationType:         papi.ActivationTypeActivate,
+			Network:                r.network,
+			PropertyVersion:        previousVersion,
+			NotifyEmails:           r.notify,
+			AcknowledgeAllWarnings: true,
+			UseFastFallback:        fastFallback,
+			Note:                   fmt.Sprintf("Rollback after the activation of version %d ended with status %s", r.version, failed.Status),
+		},
+	}
+
+	log.Debug("rolling back activation", "version", previousVersion, "fastFallback", fastFallback)
+	rollbackID, diags := createActivation(ctx, client, addPropertyComplianceRecord(r.complianceRecord, request))
+	if diags != nil {
+		return r.failed(previousVersion, diags)
+	}
+	act, err := client.GetActivation(ctx, papi.GetActivationRequest{
+		ActivationID: rollbackID,
+		PropertyID:   r.propertyID,
+	})
+	if err != nil {
+		return r.failed(previousVersion, diag.FromErr(err))
+	}
+	if _, diags = pollActivation(ctx, client, act.Activation, r.propertyID); diags != nil {
+		return r.failed(previousVersion, diags)
+	}
+
+	detail := fmt.Sprintf("The activation of version %d on %s %s, so version %d which was active before was activated again",
+		r.version, r.network, outcome, previousVersion)
+	if fastFallback {
+		detail += " using fast fallback"
+	}
+	r.rolledBack = true
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("Rolled back to version %d", previousVersion),
+		Detail:   detail + ".",
+	}}
+}
+
+// waitActivationEnd waits until the activation is no longer pending and returns it, whatever status it ended with
+func waitActivationEnd(ctx context.Context, client papi.PAPI, act *papi.Activation, propertyID string) (*papi.Activation, error) {
+	ended := func(status papi.ActivationStatus) activation.Status {
+		if s := activationStatus(status); s.State != activation.StatePending {
+			return activation.Succeeded(s.Value)
+		}
+		return activation.Pending(string(status))
+	}
+
+	err := activation.Poller{Name: "property activation", Interval: rollbackPollInterval}.Wait(ctx, ended(act.Status), func(ctx context.Context) (activation.Status, error) {
+		resp, err := client.GetActivation(ctx, papi.GetActivationRequest{
+			ActivationID: act.ActivationID,
+			PropertyID:   propertyID,
+		})
+		if err != nil {
+			return activation.Status{}, err
+		}
+		act = resp.Activation
+		return ended(act.Status), nil
+	})
+	return act, err
+}
+
+// failed reports the diagnostics of a failed rollback as errors
+func (r *activationRollback) failed(version int, diags diag.Diagnostics) diag.Diagnostics {
+	failed := make(diag.Diagnostics, 0, len(diags))
+	for _, d := range diags {
+		d.Severity = diag.Error
+		d.Summary = fmt.Sprintf("rolling back to version %d: %s", version, d.Summary)
+		failed = append(failed, d)
+	}
+	return failed
+}
+
+// activationPoller returns the poller of property and include activations
+func activationPoller(name string) activation.Poller {
+	return activation.Poller{
+		Name:     name,
+		Interval: tf.MaxDuration(ActivationPollInterval, ActivationPollMinimum),
+	}
+}
+
+// activationStatus returns the status of a property or include activation,
+// deactivations also use status Active for when they are fully processed
+func activationStatus(status papi.ActivationStatus) activation.Status {
+	switch status {
+	case papi.ActivationStatusActive:
+		return activation.Succeeded(string(status))
+	case papi.ActivationStatusAborted:
+		return activation.Aborted(string(status), "")
+	case papi.ActivationStatusFailed:
+		return activation.Failed(string(status), "request failed in downstream system")
+	}
+	return activation.Pending(string(status))
 }
 
 func suppressNoteFieldForPropertyActivation(_, oldValue, newValue string, d *schema.ResourceData) bool {
@@ -983,26 +1265,26 @@ func suppressNoteFieldForPropertyActivation(_, oldValue, newValue string, d *sch
 func createActivation(ctx context.Context, client papi.PAPI, request papi.CreateActivationRequest) (string, diag.Diagnostics) {
 	log := hclog.FromContext(ctx)
 
-	errMsg := "create failed"
+	errMsg, name := "create failed", "property activation creation"
 	switch request.Activation.ActivationType {
 	case papi.ActivationTypeActivate:
 		errMsg = "create activation failed"
 	case papi.ActivationTypeDeactivate:
-		errMsg = "create deactivation failed"
+		errMsg, name = "create deactivation failed", "property deactivation creation"
 	}
 
-	createActivationRetry := CreateActivationRetry
-
-	for {
+	var activationID string
+	err := createActivationRetrier(name).Retry(ctx, func(ctx context.Context) (bool, error) {
 		log.Debug("creating activation")
 		create, err := client.CreateActivation(ctx, request)
 		if err == nil {
-			return create.ActivationID, nil
+			activationID = create.ActivationID
+			return false, nil
 		}
 		log.Debug("%s: retrying: %w", errMsg, err)
 
 		if !isCreateActivationErrorRetryable(err) {
-			return "", diag.Errorf("%s: %s", errMsg, err)
+			return false, fmt.Errorf("%s: %s", errMsg, err)
 		}
 
 		if actID, ok := isActivationPendingOrActive(ctx, client, expectedActivation{
@@ -1011,30 +1293,34 @@ func createActivation(ctx context.Context, client papi.PAPI, request papi.Create
 			Network:    request.Activation.Network,
 			Type:       request.Activation.ActivationType,
 		}); ok {
-			return actID, nil
+			activationID = actID
+			return false, nil
 		}
+		return true, err
+	})
+	if err != nil {
+		return "", activation.Diagnostics(err)
+	}
+	return activationID, nil
+}
 
-		select {
-		case <-time.After(createActivationRetry):
-			createActivationRetry = capDuration(createActivationRetry*2, 5*time.Minute)
-			continue
-
-		case <-ctx.Done():
-			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-				return "", diag.Diagnostics{DiagErrActivationTimeout}
-			} else if errors.Is(ctx.Err(), context.Canceled) {
-				return "", diag.Diagnostics{DiagErrActivationCanceled}
-			}
-			return "", diag.FromErr(fmt.Errorf("activation context terminated: %w", ctx.Err()))
-		}
+// acquireActivationSlot waits until a property or include activation can be submitted on the network
+// within the limits of concurrent activations. The returned function releases the slot.
+func acquireActivationSlot(ctx context.Context, name string, network papi.ActivationNetwork) (func(), diag.Diagnostics) {
+	release, err := activation.Acquire(ctx, name, string(network))
+	if err != nil {
+		return nil, diag.FromErr(err)
 	}
+	return release, nil
 }
 
-func capDuration(t time.Duration, tMax time.Duration) time.Duration {
-	if t > tMax {
-		return tMax
+// createActivationRetrier returns the poller retrying creation of property and include activations
+func createActivationRetrier(name string) activation.Poller {
+	return activation.Poller{
+		Name:        name,
+		Interval:    CreateActivationRetry,
+		MaxInterval: 5 * time.Minute,
 	}
-	return t
 }
 
 func isCreateActivationErrorRetryable(err error) bool {
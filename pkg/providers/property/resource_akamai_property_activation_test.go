@@ -434,7 +434,7 @@ func TestResourcePAPIPropertyActivation(t *testing.T) {
 			steps: []resource.TestStep{
 				{
 					Config:      testutils.LoadFixtureString(t, "./testdata/TestPropertyActivation/no_contact/resource_property_activation.tf"),
-					ExpectError: regexp.MustCompile("Missing required argument"),
+					ExpectError: regexp.MustCompile("\"contact\" is required"),
 				},
 			},
 		},
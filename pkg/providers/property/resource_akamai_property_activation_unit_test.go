@@ -10,7 +10,9 @@ import (
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/papi"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/date"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -851,3 +853,280 @@ func TestCreateActivation(t *testing.T) {
 		assert.Equal(t, "atv_123", actID)
 	})
 }
+
+func TestNewActivationRollback(t *testing.T) {
+	activations := &papi.GetActivationsResponse{
+		Activations: papi.ActivationsItems{Items: []*papi.Activation{
+			{ActivationID: "atv_1", ActivationType: papi.ActivationTypeActivate, PropertyVersion: 1, Network: papi.ActivationNetworkProduction,
+				Status: papi.ActivationStatusActive, UpdateDate: "2023-11-28T13:20:21Z"},
+			{ActivationID: "atv_2", ActivationType: papi.ActivationTypeActivate, PropertyVersion: 2, Network: papi.ActivationNetworkStaging,
+				Status: papi.ActivationStatusActive, UpdateDate: "2023-11-28T13:24:29Z"},
+		}},
+	}
+
+	tests := map[string]struct {
+		data             map[string]interface{}
+		version          int
+		init             func(*papi.Mock)
+		expectedRollback bool
+		expectedPrevious *papi.Activation
+	}{
+		"disabled": {
+			data:    map[string]interface{}{"contact": []interface{}{"user@example.com"}},
+			version: 3,
+		},
+		"version active before": {
+			data:    map[string]interface{}{"contact": []interface{}{"user@example.com"}, "rollback_on_failure": true},
+			version: 3,
+			init: func(m *papi.Mock) {
+				m.On("GetActivations", mock.Anything, papi.GetActivationsRequest{PropertyID: "prp_1"}).Return(activations, nil).Once()
+			},
+			expectedRollback: true,
+			expectedPrevious: activations.Activations.Items[0],
+		},
+		"same version active before": {
+			data:    map[string]interface{}{"contact": []interface{}{"user@example.com"}, "rollback_on_failure": true},
+			version: 1,
+			init: func(m *papi.Mock) {
+				m.On("GetActivations", mock.Anything, papi.GetActivationsRequest{PropertyID: "prp_1"}).Return(activations, nil).Once()
+			},
+			expectedRollback: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &papi.Mock{}
+			if test.init != nil {
+				test.init(client)
+			}
+			d := schema.TestResourceDataRaw(t, akamaiPropertyActivationSchema, test.data)
+
+			rollback, err := newActivationRollback(context.Background(), client, d, "prp_1", papi.ActivationNetworkProduction, test.version)
+			require.NoError(t, err)
+			if !test.expectedRollback {
+				assert.Nil(t, rollback)
+				return
+			}
+			require.NotNil(t, rollback)
+			assert.Equal(t, test.expectedPrevious, rollback.previous)
+			assert.Equal(t, []string{"user@example.com"}, rollback.notify)
+			client.AssertExpectations(t)
+		})
+	}
+}
+
+func TestActivationRollback(t *testing.T) {
+	rollbackRequest := func(fastFallback bool) papi.CreateActivationRequest {
+		return papi.CreateActivationRequest{
+			PropertyID: "prp_1",
+			Activation: papi.Activation{
+				ActivationType:         papi.ActivationTypeActivate,
+				Network:                papi.ActivationNetworkProduction,
+				PropertyVersion:        1,
+				NotifyEmails:           []string{"user@example.com"},
+				AcknowledgeAllWarnings: true,
+				UseFastFallback:        fastFallback,
+				Note:                   "Rollback after the activation of version 2 ended with status FAILED",
+			},
+		}
+	}
+	getActivation := func(m *papi.Mock, activation *papi.Activation) {
+		m.On("GetActivation", mock.Anything, papi.GetActivationRequest{PropertyID: "prp_1", ActivationID: activation.ActivationID}).
+			Return(&papi.GetActivationResponse{Activation: activation}, nil).Once()
+	}
+	rolledBack := &papi.Activation{ActivationID: "atv_3", PropertyVersion: 1, Status: papi.ActivationStatusActive}
+	previous := &papi.Activation{ActivationID: "atv_1", PropertyVersion: 1, Status: papi.ActivationStatusActive}
+	pollInterval := rollbackPollInterval
+	rollbackPollInterval = time.Millisecond
+	defer func() { rollbackPollInterval = pollInterval }()
+	expired, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := map[string]struct {
+		ctx                context.Context
+		previous           *papi.Activation
+		init               func(*papi.Mock)
+		expectedDiags      diag.Diagnostics
+		expectedRolledBack bool
+	}{
+		"failed activation rolled back using fast fallback": {
+			previous: previous,
+			init: func(m *papi.Mock) {
+				getActivation(m, &papi.Activation{ActivationID: "atv_2", PropertyVersion: 2, Status: papi.ActivationStatusFailed,
+					FallbackInfo: &papi.ActivationFallbackInfo{CanFastFallback: true, FallbackVersion: 1}})
+				m.On("CreateActivation", mock.Anything, rollbackRequest(true)).Return(&papi.CreateActivationResponse{ActivationID: "atv_3"}, nil).Once()
+				getActivation(m, rolledBack)
+			},
+			expectedDiags: diag.Diagnostics{{
+				Severity: diag.Warning,
+				Summary:  "Rolled back to version 1",
+				Detail:   "The activation of version 2 on PRODUCTION ended with status FAILED, so version 1 which was active before was activated again using fast fallback.",
+			}},
+			expectedRolledBack: true,
+		},
+		"failed activation rolled back without fast fallback": {
+			previous: previous,
+			init: func(m *papi.Mock) {
+				getActivation(m, &papi.Activation{ActivationID: "atv_2", PropertyVersion: 2, Status: papi.ActivationStatusFailed})
+				m.On("CreateActivation", mock.Anything, rollbackRequest(false)).Return(&papi.CreateActivationResponse{ActivationID: "atv_3"}, nil).Once()
+				getActivation(m, rolledBack)
+			},
+			expectedDiags: diag.Diagnostics{{
+				Severity: diag.Warning,
+				Summary:  "Rolled back to version 1",
+				Detail:   "The activation of version 2 on PRODUCTION ended with status FAILED, so version 1 which was active before was activated again.",
+			}},
+			expectedRolledBack: true,
+		},
+		"pending activation canceled after timeout": {
+			ctx:      expired,
+			previous: previous,
+			init: func(m *papi.Mock) {
+				getActivation(m, &papi.Activation{ActivationID: "atv_2", PropertyVersion: 2, Status: papi.ActivationStatusPending})
+				m.On("CancelActivation", mock.Anything, papi.CancelActivationRequest{PropertyID: "prp_1", ActivationID: "atv_2"}).
+					Return(&papi.CancelActivationResponse{}, nil).Once()
+			},
+			expectedDiags: diag.Diagnostics{{
+				Severity: diag.Warning,
+				Summary:  "Rolled back to version 1",
+				Detail:   "The activation of version 2 on PRODUCTION was canceled as it was still PENDING after the timeout, so version 1 remains active.",
+			}},
+			expectedRolledBack: true,
+		},
+		"activation which cannot be canceled after timeout rolled back when it ends": {
+			ctx:      expired,
+			previous: previous,
+			init: func(m *papi.Mock) {
+				getActivation(m, &papi.Activation{ActivationID: "atv_2", PropertyVersion: 2, Status: papi.ActivationStatusZone1})
+				m.On("CancelActivation", mock.Anything, papi.CancelActivationRequest{PropertyID: "prp_1", ActivationID: "atv_2"}).
+					Return(nil, errors.New("activation cannot be canceled")).Once()
+				getActivation(m, &papi.Activation{ActivationID: "atv_2", PropertyVersion: 2, Status: papi.ActivationStatusZone2})
+				getActivation(m, &papi.Activation{ActivationID: "atv_2", PropertyVersion: 2, Status: papi.ActivationStatusActive,
+					FallbackInfo: &papi.ActivationFallbackInfo{CanFastFallback: true, FallbackVersion: 1}})
+				request := rollbackRequest(true)
+				request.Activation.Note = "Rollback after the activation of version 2 ended with status ACTIVE"
+				m.On("CreateActivation", mock.Anything, request).Return(&papi.CreateActivationResponse{ActivationID: "atv_3"}, nil).Once()
+				getActivation(m, rolledBack)
+			},
+			expectedDiags: diag.Diagnostics{{
+				Severity: diag.Warning,
+				Summary:  "Rolled back to version 1",
+				Detail: "The activation of version 2 on PRODUCTION was still ZONE_1 after the timeout and could not be canceled, it ended with status ACTIVE, " +
+					"so version 1 which was active before was activated again using fast fallback.",
+			}},
+			expectedRolledBack: true,
+		},
+		"rollback fails": {
+			previous: previous,
+			init: func(m *papi.Mock) {
+				getActivation(m, &papi.Activation{ActivationID: "atv_2", PropertyVersion: 2, Status: papi.ActivationStatusAborted})
+				request := rollbackRequest(false)
+				request.Activation.Note = "Rollback after the activation of version 2 ended with status ABORTED"
+				m.On("CreateActivation", mock.Anything, request).Return(nil, errors.New("invalid activation")).Once()
+			},
+			expectedDiags: diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  "rolling back to version 1: create activation failed: invalid activation",
+			}},
+		},
+		"no version active before": {
+			init: func(m *papi.Mock) {
+				getActivation(m, &papi.Activation{ActivationID: "atv_2", PropertyVersion: 2, Status: papi.ActivationStatusFailed})
+			},
+			expectedDiags: diag.Diagnostics{{
+				Severity: diag.Warning,
+				Summary:  "Activation not rolled back",
+				Detail:   "The activation of version 2 on PRODUCTION ended with status FAILED, but no other version of the property was active before.",
+			}},
+		},
+		"activation did not fail": {
+			previous: previous,
+			init: func(m *papi.Mock) {
+				getActivation(m, &papi.Activation{ActivationID: "atv_2", PropertyVersion: 2, Status: papi.ActivationStatusPending})
+			},
+		},
+		"operation canceled": {
+			ctx:      canceled,
+			previous: previous,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &papi.Mock{}
+			if test.init != nil {
+				test.init(client)
+			}
+			ctx := test.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			rollback := &activationRollback{
+				propertyID: "prp_1",
+				network:    papi.ActivationNetworkProduction,
+				version:    2,
+				previous:   test.previous,
+				notify:     []string{"user@example.com"},
+			}
+
+			diags := rollback.rollback(ctx, client, "atv_2")
+			assert.Equal(t, test.expectedDiags, diags)
+			assert.Equal(t, test.expectedRolledBack, rollback.rolledBack)
+			client.AssertExpectations(t)
+		})
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		var rollback *activationRollback
+		assert.Nil(t, rollback.rollback(context.Background(), &papi.Mock{}, "atv_2"))
+	})
+}
+
+func TestActivationRollbackFailed(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID:         "prp_1:PRODUCTION",
+		Attributes: map[string]string{"property": "prp_1", "network": "PRODUCTION", "version": "1", "activation_id": "atv_1"},
+	}
+	tests := map[string]struct {
+		init            func(*papi.Mock)
+		expectedVersion string
+	}{
+		"previous version kept in state when rolled back": {
+			init: func(m *papi.Mock) {
+				m.On("CreateActivation", mock.Anything, mock.Anything).Return(&papi.CreateActivationResponse{ActivationID: "atv_3"}, nil).Once()
+				m.On("GetActivation", mock.Anything, papi.GetActivationRequest{PropertyID: "prp_1", ActivationID: "atv_3"}).
+					Return(&papi.GetActivationResponse{Activation: &papi.Activation{ActivationID: "atv_3", PropertyVersion: 1, Status: papi.ActivationStatusActive}}, nil).Once()
+			},
+			expectedVersion: "1",
+		},
+		"new version kept in state when not rolled back": {
+			init: func(m *papi.Mock) {
+				m.On("CreateActivation", mock.Anything, mock.Anything).Return(nil, errors.New("invalid activation")).Once()
+			},
+			expectedVersion: "2",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &papi.Mock{}
+			client.On("GetActivation", mock.Anything, papi.GetActivationRequest{PropertyID: "prp_1", ActivationID: "atv_2"}).
+				Return(&papi.GetActivationResponse{Activation: &papi.Activation{ActivationID: "atv_2", PropertyVersion: 2, Status: papi.ActivationStatusFailed}}, nil).Once()
+			test.init(client)
+
+			d := resourcePropertyActivation().Data(state)
+			require.NoError(t, d.Set("version", 2))
+			rollback := &activationRollback{
+				propertyID: "prp_1",
+				network:    papi.ActivationNetworkProduction,
+				version:    2,
+				previous:   &papi.Activation{ActivationID: "atv_1", PropertyVersion: 1, Status: papi.ActivationStatusActive},
+			}
+
+			diags := rollback.rollbackFailed(context.Background(), client, d, "atv_2")
+			require.Len(t, diags, 1)
+			assert.Equal(t, test.expectedVersion, d.State().Attributes["version"])
+			client.AssertExpectations(t)
+		})
+	}
+}
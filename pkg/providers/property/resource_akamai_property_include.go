@@ -14,6 +14,7 @@ import (
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/session"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/str"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/versionnotes"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/logger"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -156,6 +157,10 @@ func propertyIncludeRulesCustomDiff(_ context.Context, diff *schema.ResourceDiff
 	}
 
 	normalizeFields(&oldRulesUpdate, &newRulesUpdate)
+	// with a version notes template, the comments of the rules are rendered into the notes of the version
+	if versionnotes.Enabled() {
+		newRulesUpdate.Comments = oldRulesUpdate.Comments
+	}
 	if rulesEqual(&oldRulesUpdate.Rules, &newRulesUpdate.Rules) && oldRulesUpdate.Comments == newRulesUpdate.Comments {
 		return nil
 	}
@@ -227,7 +232,7 @@ func resourcePropertyIncludeCreate(ctx context.Context, rd *schema.ResourceData,
 	rd.SetId(createIncludeResp.IncludeID)
 
 	postCreateVersion := 1
-	if err = updateRules(ctx, client, rd, postCreateVersion); err != nil {
+	if err = updateRules(ctx, meta, client, rd, postCreateVersion); err != nil {
 		return diag.Errorf("%s update: %s", ErrPropertyInclude, err)
 	}
 
@@ -399,7 +404,7 @@ func resourcePropertyIncludeUpdate(ctx context.Context, rd *schema.ResourceData,
 		version = createVersionResp.Version
 	}
 
-	if err = updateRules(ctx, client, rd, version); err != nil {
+	if err = updateRules(ctx, meta, client, rd, version); err != nil {
 		return diag.Errorf("%s update: %s", ErrPropertyInclude, err)
 	}
 
@@ -479,7 +484,7 @@ func resourcePropertyIncludeImport(_ context.Context, rd *schema.ResourceData, m
 	return []*schema.ResourceData{rd}, nil
 }
 
-func updateRules(ctx context.Context, client papi.PAPI, rd *schema.ResourceData, version int) error {
+func updateRules(ctx context.Context, m meta.Meta, client papi.PAPI, rd *schema.ResourceData, version int) error {
 	rulesJSON, err := tf.GetStringValue("rules", rd)
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return err
@@ -509,6 +514,9 @@ func updateRules(ctx context.Context, client papi.PAPI, rd *schema.ResourceData,
 	if err = json.Unmarshal([]byte(rulesJSON), &rules); err != nil {
 		return fmt.Errorf("unmarshalling rules failed: %s", err)
 	}
+	if err = renderVersionNotes(m, rd, "akamai_property_include", rd.Get("name").(string), &rules); err != nil {
+		return err
+	}
 
 	header := buildRuleFormatHeader(ruleFormat)
 	ctx = session.ContextWithOptions(ctx, session.WithContextHeaders(header))
@@ -13,9 +13,11 @@ import (
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/papi"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/activation"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/str"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/timeouts"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/versionnotes"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/logger"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
 	"github.com/hashicorp/go-hclog"
@@ -161,7 +163,7 @@ func resourcePropertyIncludeActivationCreate(ctx context.Context, d *schema.Reso
 
 	logger.Debug("Create property include activation")
 
-	err := resourcePropertyIncludeActivationUpsert(ctx, d, client)
+	err := resourcePropertyIncludeActivationUpsert(ctx, d, meta, client)
 	if err != nil {
 		return err
 	}
@@ -207,7 +209,7 @@ func resourcePropertyIncludeActivationRead(ctx context.Context, d *schema.Resour
 	attrs["version"] = activation.Activation.IncludeVersion
 	attrs["network"] = activation.Activation.Network
 	attrs["notify_emails"] = activation.Activation.NotifyEmails
-	attrs["note"] = activation.Activation.Note
+	attrs["note"] = versionnotes.State(d.Get("note").(string), activation.Activation.Note)
 	attrs["validations"] = string(validations)
 
 	if len(strings.TrimSpace(activation.Activation.Note)) == 0 {
@@ -238,7 +240,7 @@ func resourcePropertyIncludeActivationUpdate(ctx context.Context, d *schema.Reso
 		return diag.FromErr(fmt.Errorf("attributes such as 'notify_emails', 'auto_acknowledge_rule_warnings', cannot be updated after resource creation without 'version' attribute modification"))
 	}
 
-	err := resourcePropertyIncludeActivationUpsert(ctx, d, client)
+	err := resourcePropertyIncludeActivationUpsert(ctx, d, meta, client)
 	if err != nil {
 		return err
 	}
@@ -253,7 +255,7 @@ func resourcePropertyIncludeActivationDelete(ctx context.Context, d *schema.Reso
 	logger.Debug("Deactivating property include")
 
 	activationResourceData := propertyIncludeActivationData{}
-	if err := activationResourceData.populateFromResource(d); err != nil {
+	if err := activationResourceData.populateFromResource(meta, d); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -272,8 +274,14 @@ func resourcePropertyIncludeActivationDelete(ctx context.Context, d *schema.Reso
 		return nil
 	}
 
+	release, diagErr := acquireActivationSlot(ctx, "include deactivation", papi.ActivationNetwork(activationResourceData.network))
+	if diagErr != nil {
+		return diagErr
+	}
+	defer release()
+
 	logger.Debug("creating new deactivation")
-	diagErr := createNewDeactivation(ctx, client, activationResourceData)
+	diagErr = createNewDeactivation(ctx, client, activationResourceData)
 	if diagErr != nil {
 		return diagErr
 	}
@@ -308,11 +316,11 @@ func resourcePropertyIncludeActivationImport(_ context.Context, d *schema.Resour
 	return []*schema.ResourceData{d}, nil
 }
 
-func resourcePropertyIncludeActivationUpsert(ctx context.Context, d *schema.ResourceData, client papi.PAPI) diag.Diagnostics {
+func resourcePropertyIncludeActivationUpsert(ctx context.Context, d *schema.ResourceData, m meta.Meta, client papi.PAPI) diag.Diagnostics {
 	logger := logger.Get("resourcePropertyIncludeActivationUpsert")
 
 	activationResourceData := propertyIncludeActivationData{}
-	if err := activationResourceData.populateFromResource(d); err != nil {
+	if err := activationResourceData.populateFromResource(m, d); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -333,8 +341,14 @@ func resourcePropertyIncludeActivationUpsert(ctx context.Context, d *schema.Reso
 		return nil
 	}
 
+	release, diagErr := acquireActivationSlot(ctx, "include activation", papi.ActivationNetwork(activationResourceData.network))
+	if diagErr != nil {
+		return diagErr
+	}
+	defer release()
+
 	logger.Debug("creating new activation")
-	diagErr := createNewActivation(ctx, client, activationResourceData)
+	diagErr = createNewActivation(ctx, client, activationResourceData)
 	if diagErr != nil {
 		return diagErr
 	}
@@ -360,7 +374,7 @@ type propertyIncludeActivationData struct {
 	complianceRecord []any
 }
 
-func (p *propertyIncludeActivationData) populateFromResource(d *schema.ResourceData) error {
+func (p *propertyIncludeActivationData) populateFromResource(m meta.Meta, d *schema.ResourceData) error {
 	includeID, err := tf.GetStringValue("include_id", d)
 	if err != nil {
 		return err
@@ -394,6 +408,9 @@ func (p *propertyIncludeActivationData) populateFromResource(d *schema.ResourceD
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return err
 	}
+	if p.note, err = versionnotes.Render(m, "akamai_property_include_activation", p.includeID, p.note); err != nil {
+		return err
+	}
 	p.acknowledgement, err = tf.GetBoolValue("auto_acknowledge_rule_warnings", d)
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return err
@@ -497,20 +514,16 @@ func createNewActivation(ctx context.Context, client papi.PAPI, activationResour
 	}
 
 	activateIncludeRequest = papi.ActivateIncludeRequest(addComplianceRecord(activationResourceData.complianceRecord, papi.ActivateOrDeactivateIncludeRequest(activateIncludeRequest)))
-	createActivationRetry := CreateActivationRetry
-
 	var actID string
-	var ok bool
-	for {
-
+	err := createActivationRetrier("include activation creation").Retry(ctx, func(ctx context.Context) (bool, error) {
 		logger.Debug("sending include activation request")
 		activationResponse, err := client.ActivateInclude(ctx, activateIncludeRequest)
 		if err == nil {
 			actID = activationResponse.ActivationID
-			break
+			return false, nil
 		}
 		if !isCreateActivationErrorRetryable(err) {
-			return diag.Errorf("%s: %s", "create activation failed", err)
+			return false, fmt.Errorf("%s: %s", "create activation failed", err)
 		}
 
 		expected := expectedIncludeActivation{
@@ -521,23 +534,14 @@ func createNewActivation(ctx context.Context, client papi.PAPI, activationResour
 			Network:    activationResourceData.network,
 			Type:       papi.ActivationTypeActivate,
 		}
+		var ok bool
 		if actID, ok = isIncludeActivationPendingOrActive(ctx, client, expected); ok {
-			break
-		}
-
-		select {
-		case <-time.After(createActivationRetry):
-			createActivationRetry = capDuration(createActivationRetry*2, 5*time.Minute)
-			continue
-
-		case <-ctx.Done():
-			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-				return diag.Diagnostics{DiagErrActivationTimeout}
-			} else if errors.Is(ctx.Err(), context.Canceled) {
-				return diag.Diagnostics{DiagErrActivationCanceled}
-			}
-			return diag.FromErr(fmt.Errorf("activation context terminated: %w", ctx.Err()))
+			return false, nil
 		}
+		return true, err
+	})
+	if err != nil {
+		return activation.Diagnostics(err)
 	}
 
 	logger.Debug("waiting for activation creation")
@@ -562,19 +566,15 @@ func createNewDeactivation(ctx context.Context, client papi.PAPI, activationReso
 
 	deactivateIncludeRequest = papi.DeactivateIncludeRequest(addComplianceRecord(activationResourceData.complianceRecord, papi.ActivateOrDeactivateIncludeRequest(deactivateIncludeRequest)))
 
-	createActivationRetry := CreateActivationRetry
-
 	var actID string
-	var ok bool
-	for {
-
+	err := createActivationRetrier("include deactivation creation").Retry(ctx, func(ctx context.Context) (bool, error) {
 		deactivation, err := client.DeactivateInclude(ctx, deactivateIncludeRequest)
 		if err == nil {
 			actID = deactivation.ActivationID
-			break
+			return false, nil
 		}
 		if !isCreateActivationErrorRetryable(err) {
-			return diag.Errorf("%s: %s", "create activation failed", err)
+			return false, fmt.Errorf("%s: %s", "create activation failed", err)
 		}
 		expected := expectedIncludeActivation{
 			IncludeID:  activationResourceData.includeID,
@@ -584,23 +584,14 @@ func createNewDeactivation(ctx context.Context, client papi.PAPI, activationReso
 			Network:    activationResourceData.network,
 			Type:       papi.ActivationTypeDeactivate,
 		}
+		var ok bool
 		if actID, ok = isIncludeActivationPendingOrActive(ctx, client, expected); ok {
-			break
-		}
-
-		select {
-		case <-time.After(createActivationRetry):
-			createActivationRetry = capDuration(createActivationRetry*2, 5*time.Minute)
-			continue
-
-		case <-ctx.Done():
-			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-				return diag.Diagnostics{DiagErrActivationTimeout}
-			} else if errors.Is(ctx.Err(), context.Canceled) {
-				return diag.Diagnostics{DiagErrActivationCanceled}
-			}
-			return diag.FromErr(fmt.Errorf("activation context terminated: %w", ctx.Err()))
+			return false, nil
 		}
+		return true, err
+	})
+	if err != nil {
+		return activation.Diagnostics(err)
 	}
 
 	logger.Info("waiting for creation of include deactivation")
@@ -675,29 +666,28 @@ func getLatestActiveIncludeActivationResponseInNetwork(ctx context.Context, clie
 }
 
 func waitForActivationCreation(ctx context.Context, client papi.PAPI, includeID, activationID string) (*papi.GetIncludeActivationResponse, error) {
-	for {
-		activation, err := client.GetIncludeActivation(ctx, papi.GetIncludeActivationRequest{
+	var act *papi.GetIncludeActivationResponse
+	poller := activation.Poller{Name: "include activation", Interval: getActivationInterval}
+	err := poller.Retry(ctx, func(ctx context.Context) (bool, error) {
+		var err error
+		act, err = client.GetIncludeActivation(ctx, papi.GetIncludeActivationRequest{
 			IncludeID:    includeID,
 			ActivationID: activationID,
 		})
 		if err == nil {
-			return activation, nil
+			return false, nil
 		}
 
 		if errors.Is(err, papi.ErrMissingComplianceRecord) {
-			return nil, fmt.Errorf("for 'PRODUCTION' network, 'compliance_record' must be specified: %s", err)
-		}
-		if !errors.Is(err, papi.ErrNotFound) {
-			// return in case we get unexpected error
-			return nil, err
-		}
-
-		select {
-		case <-time.After(getActivationInterval):
-			// wait some time and check again
-			continue
+			return false, fmt.Errorf("for 'PRODUCTION' network, 'compliance_record' must be specified: %s", err)
 		}
+		// wait some time and check again, unless we get unexpected error
+		return errors.Is(err, papi.ErrNotFound), err
+	})
+	if err != nil {
+		return nil, err
 	}
+	return act, nil
 }
 
 func waitForActivationCondition(ctx context.Context,
@@ -707,50 +697,45 @@ func waitForActivationCondition(ctx context.Context,
 ) (*papi.GetIncludeActivationResponse, diag.Diagnostics) {
 	retriesMax := 5
 	retries5xx := 0
-	for {
-		activation, err := client.GetIncludeActivation(ctx, papi.GetIncludeActivationRequest{
+	var act *papi.GetIncludeActivationResponse
+	poll := func(ctx context.Context) (activation.Status, error) {
+		resp, err := client.GetIncludeActivation(ctx, papi.GetIncludeActivationRequest{
 			IncludeID:    includeID,
 			ActivationID: activationID,
 		})
 		if err != nil {
 			var target = &papi.Error{}
 			if !errors.As(err, &target) {
-				return nil, diag.Errorf("error has unexpected type: %T", err)
+				return activation.Status{}, fmt.Errorf("error has unexpected type: %T", err)
 			}
 			if target.StatusCode >= 500 {
 				retries5xx = retries5xx + 1
 				if retries5xx > retriesMax {
-					return nil, diag.Errorf("reached max number of 5xx retries: %d", retries5xx)
+					return activation.Status{}, fmt.Errorf("reached max number of 5xx retries: %d", retries5xx)
 				}
-				continue
+				return activation.Pending(""), nil
 			}
 
-			return nil, diag.FromErr(err)
+			return activation.Status{}, err
 		}
 		retries5xx = 0
 
-		actStatus := activation.Activation.Status
+		act = resp
+		actStatus := resp.Activation.Status
 		if cond(actStatus) {
-			return activation, nil
-		}
-
-		select {
-		case <-time.After(capDuration(activationPollInterval, ActivationPollMinimum)):
-			continue
-		case <-ctx.Done():
-			return nil, diag.FromErr(terminateProcess(ctx, string(actStatus)))
+			return activation.Succeeded(string(actStatus)), nil
 		}
+		return activation.Pending(string(actStatus)), nil
 	}
-}
 
-func terminateProcess(ctx context.Context, actStatus string) error {
-	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-		return fmt.Errorf("timeout waiting for activation status: current status: %s", actStatus)
+	status, err := poll(ctx)
+	if err == nil {
+		err = activation.Poller{Name: "include activation", Interval: activationPollInterval}.Wait(ctx, status, poll)
 	}
-	if errors.Is(ctx.Err(), context.Canceled) {
-		return fmt.Errorf("operation canceled while waiting for activation status, current status: %s", actStatus)
+	if err != nil {
+		return nil, activation.Diagnostics(err)
 	}
-	return fmt.Errorf("activation context terminated: %w", ctx.Err())
+	return act, nil
 }
 
 func addComplianceRecord(complianceRecord []interface{}, activateIncludeRequest papi.ActivateOrDeactivateIncludeRequest) papi.ActivateOrDeactivateIncludeRequest {
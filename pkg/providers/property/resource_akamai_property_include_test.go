@@ -1007,12 +1007,12 @@ func TestResourcePropertyInclude(t *testing.T) {
 					ExpectError: regexp.MustCompile(`The argument "name" is required, but no definition was found`),
 				},
 				{
-					Config:      testutils.LoadFixtureString(t, "%s/validation_required_errors.tf", workdir),
-					ExpectError: regexp.MustCompile(`The argument "group_id" is required, but no definition was found`),
+					Config:      testutils.LoadFixtureString(t, "%s/missing_group_id.tf", workdir),
+					ExpectError: regexp.MustCompile(`"group_id" is required: set it or the "default_group_id" provider argument`),
 				},
 				{
-					Config:      testutils.LoadFixtureString(t, "%s/validation_required_errors.tf", workdir),
-					ExpectError: regexp.MustCompile(`The argument "contract_id" is required, but no definition was found`),
+					Config:      testutils.LoadFixtureString(t, "%s/missing_contract_id.tf", workdir),
+					ExpectError: regexp.MustCompile(`"contract_id" is required: set it or the "default_contract_id" provider argument`),
 				},
 				{
 					Config:      testutils.LoadFixtureString(t, "%s/validation_required_errors.tf", workdir),
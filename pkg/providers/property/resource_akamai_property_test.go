@@ -1,6 +1,7 @@
 package property
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -13,17 +14,23 @@ import (
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/iam"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/papi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/session"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/ptr"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/test"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/testutils"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/subprovider"
 	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
 	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -1031,6 +1038,91 @@ func TestPropertyLifecycle(t *testing.T) {
 		})
 	})
 
+	t.Run("Lifecycle: rules_diff is cleared after apply", func(t *testing.T) {
+		papiMock := &papi.Mock{}
+		mp := &mockProperty{
+			papiMock:         papiMock,
+			mockPropertyData: basicData,
+		}
+		caching := func(ttl string) []papi.RuleBehavior {
+			return []papi.RuleBehavior{
+				{
+					Name: "caching",
+					Options: papi.RuleOptionsMap{
+						"behavior":       "MAX_AGE",
+						"mustRevalidate": false,
+						"ttl":            ttl,
+					},
+				},
+			}
+		}
+		mp.ruleTree = mockRuleTreeData{rules: papi.Rules{Name: "default", Behaviors: caching("12d")}}
+		// create
+		mockResourcePropertyFullCreate(mp)
+		// read x2
+		mockResourcePropertyRead(mp, 2)
+		// read x1 before update
+		mockResourcePropertyRead(mp)
+		// update ttl in rule tree from 12d to 13d
+		mp.ruleTree.rules.Behaviors = caching("13d")
+		mp.mockGetPropertyVersion()
+		mp.mockUpdateRuleTree()
+		// read x2
+		mockResourcePropertyRead(mp, 2)
+		// read x1 before update
+		mockResourcePropertyRead(mp)
+		// update hostnames only
+		mp.mockGetPropertyVersion()
+		mp.hostnames = updatedHostname
+		mp.mockUpdatePropertyVersionHostnames()
+		// read x2
+		mockResourcePropertyRead(mp, 2)
+		// delete
+		mp.mockRemoveProperty()
+
+		useClient(papiMock, nil, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config: testutils.LoadFixtureString(t, "testdata/TestResProperty/Lifecycle/rules custom diff/step0.tf"),
+						Check: defaultChecker.
+							CheckEqual("rules", `{"rules":{"behaviors":[{"name":"caching","options":{"behavior":"MAX_AGE","mustRevalidate":false,"ttl":"12d"}}],"name":"default","options":{}}}`).
+							CheckEqual("rules_diff", "").
+							Build(),
+					},
+					{
+						Config: testutils.LoadFixtureString(t, "testdata/TestResProperty/Lifecycle/rules custom diff/step1.tf"),
+						ConfigPlanChecks: resource.ConfigPlanChecks{
+							PreApply: []plancheck.PlanCheck{
+								plancheck.ExpectKnownValue("akamai_property.test", tfjsonpath.New("rules_diff"),
+									knownvalue.StringExact(`~ default: option "ttl" of behavior "caching" changed from "12d" to "13d"`)),
+							},
+						},
+						Check: defaultChecker.
+							CheckEqual("rules", `{"rules":{"behaviors":[{"name":"caching","options":{"behavior":"MAX_AGE","mustRevalidate":false,"ttl":"13d"}}],"name":"default","options":{}}}`).
+							CheckEqual("rules_diff", "").
+							Build(),
+					},
+					{
+						Config: testutils.LoadFixtureString(t, "testdata/TestResProperty/Lifecycle/rules custom diff/step2.tf"),
+						ConfigPlanChecks: resource.ConfigPlanChecks{
+							PreApply: []plancheck.PlanCheck{
+								plancheck.ExpectKnownValue("akamai_property.test", tfjsonpath.New("rules_diff"), knownvalue.StringExact("")),
+							},
+						},
+						Check: defaultChecker.
+							CheckEqual("hostnames.0.cname_to", "to2.test.domain").
+							CheckEqual("rules_diff", "").
+							Build(),
+					},
+				},
+			})
+		})
+
+		papiMock.AssertExpectations(t)
+	})
+
 	t.Run("Lifecycle: new version changed on server", func(t *testing.T) {
 		papiMock := &papi.Mock{}
 		mp := &mockProperty{
@@ -2014,8 +2106,8 @@ func TestSchemaConfiguration(t *testing.T) {
 	}
 
 	t.Run("Schema Configuration Error: name not given", assertConfigError(t, "name not given", `"name" is required`))
-	t.Run("Schema Configuration Error: contract_id not given", assertConfigError(t, "contract_id not given", `Missing required argument`))
-	t.Run("Schema Configuration Error: group_id not given", assertConfigError(t, "group_id not given", `Missing required argument`))
+	t.Run("Schema Configuration Error: contract_id not given", assertConfigError(t, "contract_id not given", `"contract_id" is required`))
+	t.Run("Schema Configuration Error: group_id not given", assertConfigError(t, "group_id not given", `"group_id" is required`))
 	t.Run("Schema Configuration Error: product_id not given", assertConfigError(t, "product_id not given", `Missing required argument`))
 	t.Run("Schema Configuration Error: invalid json rules", assertConfigError(t, "invalid json rules", `rules are not valid JSON`))
 	t.Run("Schema Configuration Error: invalid name given", assertConfigError(t, "invalid name given", `a name must only contain letters, numbers, and these characters: . _ -`))
@@ -2283,3 +2375,52 @@ func TestValidatePropertyName(t *testing.T) {
 		})
 	}
 }
+
+func TestPropertyActiveVersions(t *testing.T) {
+	m, err := meta.New(session.Must(session.New()), hclog.NewNullLogger(), "")
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		propertyID string
+		init       func(*papi.Mock)
+		expected   subprovider.ActiveVersions
+	}{
+		"active": {
+			init: func(client *papi.Mock) {
+				client.On("GetProperty", mock.Anything, papi.GetPropertyRequest{PropertyID: "prp_1", ContractID: "ctr_1", GroupID: "grp_2"}).
+					Return(&papi.GetPropertyResponse{Property: &papi.Property{StagingVersion: ptr.To(4), ProductionVersion: ptr.To(3)}}, nil).Once()
+			},
+			expected: subprovider.ActiveVersions{Staging: "version 4", Production: "version 3"},
+		},
+		"inactive": {
+			init: func(client *papi.Mock) {
+				client.On("GetProperty", mock.Anything, papi.GetPropertyRequest{PropertyID: "prp_1", ContractID: "ctr_1", GroupID: "grp_2"}).
+					Return(&papi.GetPropertyResponse{Property: &papi.Property{}}, nil).Once()
+			},
+		},
+		"maintained by akamai_property_bootstrap": {
+			propertyID: "prp_1",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &papi.Mock{}
+			if test.init != nil {
+				test.init(client)
+			}
+			d := schema.TestResourceDataRaw(t, resourceProperty().Schema, map[string]interface{}{
+				"contract_id": "1",
+				"group_id":    "2",
+				"property_id": test.propertyID,
+			})
+			d.SetId("prp_1")
+
+			useClient(client, nil, func() {
+				versions, err := propertyActiveVersions(context.Background(), d, m)
+				require.NoError(t, err)
+				assert.Equal(t, test.expected, versions)
+			})
+			client.AssertExpectations(t)
+		})
+	}
+}
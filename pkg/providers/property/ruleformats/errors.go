@@ -35,6 +35,10 @@ var (
 	ErrOnlyForDefault = errors.New("cannot be used outside 'default' rule")
 	// ErrNotForDefault is used when some fields cannot be used in "default" rules in data source
 	ErrNotForDefault = errors.New("cannot be used in 'default' rule")
+	// ErrUnknownRuleFormat is used when the rule format is not in the registry
+	ErrUnknownRuleFormat = errors.New("unknown rule format")
+	// ErrNotInRuleFormat is used when a behavior, criterion or option does not exist in the rule format
+	ErrNotInRuleFormat = errors.New("does not exist in rule format")
 )
 
 // Error returns NotFoundError as a string.
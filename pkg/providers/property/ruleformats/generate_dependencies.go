@@ -0,0 +1,155 @@
+//go:build ignore
+
+// This program generates the option dependencies of each registered rule format from the descriptions in its schemas,
+// e.g. "If `type` is `CUSTOM_MAP`, this specifies the map string" makes the option apply only when type is CUSTOM_MAP.
+// Run it with go generate after adding or regenerating a rule format.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/providers/property/ruleformats"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/iancoleman/strcase"
+)
+
+type dependency struct {
+	option    string
+	dependsOn string
+	values    []string
+}
+
+const values = "`[A-Z0-9_]+`(?:(?:, |,? or )`[A-Z0-9_]+`)*"
+
+var (
+	// firstCondition matches the condition a description starts with, e.g. "When the `actionType` is `REDIRECT`"
+	firstCondition = regexp.MustCompile("^(?:If|When) [^`,]*?`([A-Za-z0-9]+)` is (?:set to )?(" + values + ")")
+	// nextCondition matches the conditions which follow the first one, e.g. " and `destinationPathSuffixStatus` is set to `SUFFIX`"
+	nextCondition = regexp.MustCompile("^ and [^`,]*?`([A-Za-z0-9]+)` is (?:set to )?(" + values + ")")
+	value         = regexp.MustCompile("`([A-Z0-9_]+)`")
+)
+
+func main() {
+	schemas := ruleformats.Schemas()
+	for _, version := range ruleformats.RulesFormats() {
+		rules := schemas[version.SchemaKey()].Elem.(*schema.Resource).Schema
+		nameMappings := ruleformats.NameMappings(version.SchemaKey())
+
+		dependencies := map[string][]dependency{}
+		for _, itemType := range []string{"behavior", "criterion"} {
+			for name, item := range rules[itemType].Elem.(*schema.Resource).Schema {
+				if deps := optionDependencies(item.Elem.(*schema.Resource).Schema, nameMappings); len(deps) > 0 {
+					dependencies[name] = deps
+				}
+			}
+		}
+
+		file := strings.ReplaceAll(version.SchemaKey(), "rules_", "rule_format_") + "_dependencies.gen.go"
+		if err := os.WriteFile(file, source(version.SchemaKey(), dependencies), 0644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// optionDependencies returns the dependencies of the options which are described as applying only when
+// other options of the same behavior or criterion have one of their allowed values
+func optionDependencies(options map[string]*schema.Schema, nameMappings map[string]string) []dependency {
+	keys := make(map[string]string, len(options))
+	for key := range options {
+		name := strcase.ToLowerCamel(key)
+		if mapped, ok := nameMappings[name]; ok {
+			name = mapped
+		}
+		keys[name] = key
+	}
+
+	var dependencies []dependency
+	for key, option := range options {
+		description := option.Description
+		match := firstCondition.FindStringSubmatch(description)
+		for match != nil {
+			dependsOn, ok := keys[match[1]]
+			values := allowedValues(options[dependsOn], value.FindAllStringSubmatch(match[2], -1))
+			if ok && dependsOn != key && values != nil && !contradictsName(key, options[dependsOn], dependsOn, values) {
+				dependencies = append(dependencies, dependency{option: key, dependsOn: dependsOn, values: values})
+			}
+			description = description[len(match[0]):]
+			match = nextCondition.FindStringSubmatch(description)
+		}
+	}
+	sort.Slice(dependencies, func(i, j int) bool {
+		if dependencies[i].option != dependencies[j].option {
+			return dependencies[i].option < dependencies[j].option
+		}
+		return dependencies[i].dependsOn < dependencies[j].dependsOn
+	})
+	return dependencies
+}
+
+// allowedValues returns the values if the option is an enum which allows all of them, or nil otherwise
+func allowedValues(option *schema.Schema, matches [][]string) []string {
+	if option == nil || option.Type != schema.TypeString || option.ValidateDiagFunc == nil ||
+		!option.ValidateDiagFunc("", cty.Path{}).HasError() {
+		return nil
+	}
+	values := make([]string, 0, len(matches))
+	for _, match := range matches {
+		if diags := option.ValidateDiagFunc(match[1], cty.Path{}); diags.HasError() {
+			return nil
+		}
+		values = append(values, match[1])
+	}
+	return values
+}
+
+// contradictsName reports whether the name of an option is the name of the option it depends on followed by another value,
+// e.g. destination_path_other which is described as applying when destination_path is PREFIX_REQUEST rather than OTHER
+func contradictsName(key string, dependsOn *schema.Schema, dependsOnKey string, values []string) bool {
+	suffix, ok := strings.CutPrefix(key, dependsOnKey+"_")
+	if !ok {
+		return false
+	}
+	value := strings.ToUpper(suffix)
+	for _, v := range values {
+		if v == value {
+			return false
+		}
+	}
+	return !dependsOn.ValidateDiagFunc(value, cty.Path{}).HasError()
+}
+
+func source(version string, dependencies map[string][]dependency) []byte {
+	names := make([]string, 0, len(dependencies))
+	for name := range dependencies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b bytes.Buffer
+	b.WriteString("// Code generated by generate_dependencies.go; DO NOT EDIT.\n\n")
+	b.WriteString("package ruleformats\n\n")
+	b.WriteString("func init() {\n")
+	fmt.Fprintf(&b, "schemasRegistry.registerOptionDependencies(%q, map[string][]optionDependency{\n", version)
+	for _, name := range names {
+		fmt.Fprintf(&b, "%q: {\n", name)
+		for _, dep := range dependencies[name] {
+			fmt.Fprintf(&b, "{option: %q, dependsOn: %q, values: %#v},\n", dep.option, dep.dependsOn, dep.values)
+		}
+		b.WriteString("},\n")
+	}
+	b.WriteString("})\n}\n")
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	return src
+}
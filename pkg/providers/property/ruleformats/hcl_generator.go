@@ -0,0 +1,342 @@
+package ruleformats
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/papi"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/iancoleman/strcase"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// HCLGenerator converts papi.Rules into the configuration of akamai_property_rules_builder data sources,
+// which is the reverse of RulesBuilder.
+type HCLGenerator struct {
+	ruleFormat  RuleFormat
+	labelPrefix string
+	behaviors   map[string]string
+	criteria    map[string]string
+	labels      map[string]bool
+}
+
+const rulesBuilderDataSource = "akamai_property_rules_builder"
+
+var invalidLabelChars = regexp.MustCompile("[^a-z0-9_]+")
+
+// NewHCLGenerator returns a HCLGenerator writing rules in the given rule format, e.g. rules_v2024_10_21.
+// Data sources are labeled with labelPrefix followed by the lowercase names of their rules, with underscores
+// replacing other characters than letters and digits.
+func NewHCLGenerator(ruleFormat RuleVersion, labelPrefix string) (*HCLGenerator, error) {
+	rf, ok := schemasRegistry.ruleFormat(ruleFormat.SchemaKey())
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownRuleFormat, ruleFormat.Version())
+	}
+
+	g := &HCLGenerator{
+		ruleFormat:  rf,
+		labelPrefix: labelPrefix,
+	}
+	g.behaviors = jsonNames(rf.behaviorsSchemas, rf.nameMappings)
+	g.criteria = jsonNames(rf.criteriaSchemas, rf.nameMappings)
+	return g, nil
+}
+
+// Generate returns the formatted configuration of one data source per rule of the tree, starting with the given rule.
+// Children of a rule are referenced by the json attribute of their data sources.
+func (g *HCLGenerator) Generate(rules papi.Rules) ([]byte, error) {
+	g.labels = map[string]bool{}
+
+	f := hclwrite.NewEmptyFile()
+	if _, err := g.rule(f.Body(), rules, "rules", true); err != nil {
+		return nil, err
+	}
+	return hclwrite.Format(f.Bytes()), nil
+}
+
+func (g *HCLGenerator) rule(file *hclwrite.Body, rule papi.Rules, path string, isDefault bool) (string, error) {
+	label := g.label(rule.Name)
+	if len(file.Blocks()) > 0 {
+		file.AppendNewline()
+	}
+	body := file.AppendNewBlock("data", []string{rulesBuilderDataSource, label}).Body().
+		AppendNewBlock(g.ruleFormat.version, nil).Body()
+
+	body.SetAttributeValue("name", cty.StringVal(rule.Name))
+	if isDefault {
+		body.SetAttributeValue("is_secure", cty.BoolVal(rule.Options.IsSecure))
+	}
+	if !isDefault && rule.CriteriaMustSatisfy != "" {
+		body.SetAttributeValue("criteria_must_satisfy", cty.StringVal(string(rule.CriteriaMustSatisfy)))
+	}
+	setNonEmptyString(body, "comments", rule.Comments)
+	setNonEmptyString(body, "uuid", rule.UUID)
+	setNonEmptyString(body, "template_uuid", rule.TemplateUuid)
+	setNonEmptyString(body, "template_link", rule.TemplateLink)
+	if !isDefault && rule.CriteriaLocked {
+		body.SetAttributeValue("criteria_locked", cty.True)
+	}
+	if isDefault {
+		setNonEmptyString(body, "advanced_override", rule.AdvancedOverride)
+		if rule.CustomOverride != nil {
+			override := body.AppendNewBlock("custom_override", nil).Body()
+			override.SetAttributeValue("name", cty.StringVal(rule.CustomOverride.Name))
+			override.SetAttributeValue("override_id", cty.StringVal(rule.CustomOverride.OverrideID))
+		}
+		for _, variable := range rule.Variables {
+			g.variable(body.AppendNewBlock("variable", nil).Body(), variable)
+		}
+	}
+
+	if err := g.ruleItems(body, "criterion", rule.Criteria, g.ruleFormat.criteriaSchemas, g.criteria, path+".criteria"); err != nil {
+		return "", err
+	}
+	if err := g.ruleItems(body, "behavior", rule.Behaviors, g.ruleFormat.behaviorsSchemas, g.behaviors, path+".behaviors"); err != nil {
+		return "", err
+	}
+
+	children := make([]string, 0, len(rule.Children))
+	for i, child := range rule.Children {
+		childLabel, err := g.rule(file, child, fmt.Sprintf("%s.children[%d]", path, i), false)
+		if err != nil {
+			return "", err
+		}
+		children = append(children, childLabel)
+	}
+	if len(children) > 0 {
+		body.SetAttributeRaw("children", childrenTokens(children))
+	}
+
+	return label, nil
+}
+
+func (g *HCLGenerator) variable(body *hclwrite.Body, variable papi.RuleVariable) {
+	body.SetAttributeValue("name", cty.StringVal(variable.Name))
+	body.SetAttributeValue("value", cty.StringVal(stringOrEmpty(variable.Value)))
+	body.SetAttributeValue("description", cty.StringVal(stringOrEmpty(variable.Description)))
+	body.SetAttributeValue("hidden", cty.BoolVal(variable.Hidden))
+	body.SetAttributeValue("sensitive", cty.BoolVal(variable.Sensitive))
+}
+
+func (g *HCLGenerator) ruleItems(body *hclwrite.Body, blockType string, items []papi.RuleBehavior, schemas map[string]*schema.Schema, names map[string]string, path string) error {
+	for i, item := range items {
+		key, ok := names[item.Name]
+		if !ok {
+			return fmt.Errorf("%s[%d]: %s %q: %w %s", path, i, blockType, item.Name, ErrNotInRuleFormat, g.version())
+		}
+		itemBody := body.AppendNewBlock(blockType, nil).Body().AppendNewBlock(key, nil).Body()
+		if item.Locked {
+			itemBody.SetAttributeValue("locked", cty.True)
+		}
+		setNonEmptyString(itemBody, "uuid", item.UUID)
+		setNonEmptyString(itemBody, "template_uuid", item.TemplateUuid)
+
+		itemSchema := schemas[key].Elem.(*schema.Resource).Schema
+		if err := g.options(itemBody, itemSchema, item.Options, item.Name, fmt.Sprintf("%s[%d].%s", path, i, item.Name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// options writes the options of a behavior or criterion, or of their nested objects, attributes first.
+// Objects which the rule format flattens are written as a single block.
+// mappingKey is the prefix of the type mapping keys of the options, the same one RulesBuilder uses.
+func (g *HCLGenerator) options(body *hclwrite.Body, schemas map[string]*schema.Schema, options map[string]any, mappingKey, path string) error {
+	names := jsonNames(schemas, g.ruleFormat.nameMappings)
+
+	values := make(map[string]any, len(options))
+	var attributes, blocks []string
+	for name, value := range options {
+		if value == nil {
+			continue
+		}
+		key, ok := names[name]
+		if !ok {
+			return fmt.Errorf("%s: option %q: %w %s", path, name, ErrNotInRuleFormat, g.version())
+		}
+		values[key] = value
+		if _, ok := schemas[key].Elem.(*schema.Resource); ok {
+			blocks = append(blocks, key)
+		} else {
+			attributes = append(attributes, key)
+		}
+	}
+	sort.Strings(attributes)
+	sort.Strings(blocks)
+
+	for _, key := range attributes {
+		value, err := optionValue(schemas[key], unmapValue(g.ruleFormat.typeMappings, mappingKey+"."+g.jsonName(key), values[key]))
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", path, g.jsonName(key), err)
+		}
+		body.SetAttributeValue(key, value)
+	}
+	for _, key := range blocks {
+		var objects []any
+		switch value := values[key].(type) {
+		case map[string]any:
+			objects = []any{value}
+		case []any:
+			objects = value
+		default:
+			return fmt.Errorf("%s.%s: %w", path, g.jsonName(key), &TypeAssertionError{want: "object or list of objects", got: typeof(value)})
+		}
+
+		elem := schemas[key].Elem.(*schema.Resource).Schema
+		for i, object := range objects {
+			objectPath := fmt.Sprintf("%s.%s[%d]", path, g.jsonName(key), i)
+			nested, ok := object.(map[string]any)
+			if !ok {
+				return fmt.Errorf("%s: %w", objectPath, &TypeAssertionError{want: "object", got: typeof(object)})
+			}
+			if err := g.options(body.AppendNewBlock(key, nil).Body(), elem, nested, mappingKey+"."+g.jsonName(key), objectPath); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// unmapValue reverses the type mappings of a rule format for the option under optionKey,
+// e.g. the number 408 of adScalerCircuitBreaker.returnErrorResponseCodeBased becomes the string "408".
+// Values without a mapping are returned unchanged.
+func unmapValue(typeMappings map[string]any, optionKey string, value any) any {
+	json, ok := number(value)
+	if !ok {
+		return value
+	}
+	prefix := optionKey + "."
+	for key, mapped := range typeMappings {
+		if strings.HasPrefix(key, prefix) && fmt.Sprint(mapped) == json {
+			return strings.TrimPrefix(key, prefix)
+		}
+	}
+	return value
+}
+
+// optionValue converts the JSON value of an option to the type of its schema.
+// Booleans of string options are written as strings. Numbers must be unmapped first, see unmapValue.
+func optionValue(s *schema.Schema, value any) (cty.Value, error) {
+	switch s.Type {
+	case schema.TypeBool:
+		if b, ok := value.(bool); ok {
+			return cty.BoolVal(b), nil
+		}
+	case schema.TypeInt, schema.TypeFloat:
+		if n, ok := number(value); ok {
+			return cty.ParseNumberVal(n)
+		}
+	case schema.TypeString:
+		switch v := value.(type) {
+		case string:
+			return cty.StringVal(v), nil
+		case bool:
+			return cty.StringVal(strconv.FormatBool(v)), nil
+		}
+	case schema.TypeList, schema.TypeSet:
+		elem, ok := s.Elem.(*schema.Schema)
+		list, isList := value.([]any)
+		if !ok || !isList {
+			break
+		}
+		if len(list) == 0 {
+			return cty.ListValEmpty(cty.DynamicPseudoType), nil
+		}
+		values := make([]cty.Value, 0, len(list))
+		for _, v := range list {
+			converted, err := optionValue(elem, v)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			values = append(values, converted)
+		}
+		return cty.TupleVal(values), nil
+	}
+	return cty.NilVal, &TypeAssertionError{want: s.Type.String(), got: typeof(value)}
+}
+
+func number(value any) (string, bool) {
+	switch v := value.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case int:
+		return strconv.Itoa(v), true
+	}
+	return "", false
+}
+
+func (g *HCLGenerator) jsonName(key string) string {
+	return jsonName(key, g.ruleFormat.nameMappings)
+}
+
+// jsonName returns the name of a behavior, criterion or option in JSON, the same way RulesBuilder does
+func jsonName(key string, nameMappings map[string]string) string {
+	name := strcase.ToLowerCamel(key)
+	if mapped, ok := nameMappings[name]; ok {
+		return mapped
+	}
+	return name
+}
+
+// label returns an unused data source label for the rule of the given name
+func (g *HCLGenerator) label(name string) string {
+	base := strings.Trim(invalidLabelChars.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if base == "" {
+		base = "rule"
+	}
+	base = g.labelPrefix + base
+	if !hclsyntax.ValidIdentifier(base) {
+		base = "rule_" + base
+	}
+
+	label := base
+	for n := 2; g.labels[label]; n++ {
+		label = fmt.Sprintf("%s_%d", base, n)
+	}
+	g.labels[label] = true
+	return label
+}
+
+func (g *HCLGenerator) version() string {
+	return RuleVersion(g.ruleFormat.version).Version()
+}
+
+func childrenTokens(labels []string) hclwrite.Tokens {
+	tokens := hclwrite.Tokens{
+		{Type: hclsyntax.TokenOBrack, Bytes: []byte("[")},
+		{Type: hclsyntax.TokenNewline, Bytes: []byte("\n")},
+	}
+	for _, label := range labels {
+		tokens = append(tokens, hclwrite.TokensForTraversal(hcl.Traversal{
+			hcl.TraverseRoot{Name: "data"},
+			hcl.TraverseAttr{Name: rulesBuilderDataSource},
+			hcl.TraverseAttr{Name: label},
+			hcl.TraverseAttr{Name: "json"},
+		})...)
+		tokens = append(tokens,
+			&hclwrite.Token{Type: hclsyntax.TokenComma, Bytes: []byte(",")},
+			&hclwrite.Token{Type: hclsyntax.TokenNewline, Bytes: []byte("\n")},
+		)
+	}
+	return append(tokens, &hclwrite.Token{Type: hclsyntax.TokenCBrack, Bytes: []byte("]")})
+}
+
+func setNonEmptyString(body *hclwrite.Body, name, value string) {
+	if value != "" {
+		body.SetAttributeValue(name, cty.StringVal(value))
+	}
+}
+
+func stringOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,100 @@
+package ruleformats
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/papi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHCLGenerator(t *testing.T) {
+	rulesJSON, err := os.ReadFile("testdata/TestHCLGenerator/rules.json")
+	require.NoError(t, err)
+	var rules papi.RulesUpdate
+	require.NoError(t, json.Unmarshal(rulesJSON, &rules))
+
+	tests := map[string]struct {
+		ruleFormat  RuleVersion
+		labelPrefix string
+		rules       papi.Rules
+		expected    string
+		expectedErr string
+	}{
+		"rule tree": {
+			ruleFormat: "rules_v2024_10_21",
+			rules:      rules.Rules,
+			expected:   "testdata/TestHCLGenerator/rules.tf",
+		},
+		"label prefix": {
+			ruleFormat:  "rules_v2024_10_21",
+			labelPrefix: "example_",
+			rules:       papi.Rules{Name: "default", Children: []papi.Rules{{Name: "2nd rule"}}},
+			expected:    "testdata/TestHCLGenerator/label_prefix.tf",
+		},
+		"type mapped option": {
+			ruleFormat: "rules_v2024_10_21",
+			rules: papi.Rules{Name: "default", Behaviors: []papi.RuleBehavior{{
+				Name:    "adScalerCircuitBreaker",
+				Options: papi.RuleOptionsMap{"returnErrorResponseCodeBased": float64(408)},
+			}}},
+			expected: "testdata/TestHCLGenerator/type_mapping.tf",
+		},
+		"unknown rule format": {
+			ruleFormat:  "rules_v2020_01_01",
+			rules:       rules.Rules,
+			expectedErr: "unknown rule format: v2020-01-01",
+		},
+		"unknown behavior": {
+			ruleFormat: "rules_v2024_10_21",
+			rules: papi.Rules{Name: "default", Children: []papi.Rules{{
+				Name:      "child",
+				Behaviors: []papi.RuleBehavior{{Name: "caching"}, {Name: "unknownBehavior"}},
+			}}},
+			expectedErr: `rules.children[0].behaviors[1]: behavior "unknownBehavior": does not exist in rule format v2024-10-21`,
+		},
+		"unknown option": {
+			ruleFormat: "rules_v2024_10_21",
+			rules: papi.Rules{Name: "default", Behaviors: []papi.RuleBehavior{{
+				Name:    "caching",
+				Options: papi.RuleOptionsMap{"unknownOption": true},
+			}}},
+			expectedErr: `rules.behaviors[0].caching: option "unknownOption": does not exist in rule format v2024-10-21`,
+		},
+		"invalid option type": {
+			ruleFormat: "rules_v2024_10_21",
+			rules: papi.Rules{Name: "default", Behaviors: []papi.RuleBehavior{{
+				Name:    "caching",
+				Options: papi.RuleOptionsMap{"mustRevalidate": "yes"},
+			}}},
+			expectedErr: `rules.behaviors[0].caching.mustRevalidate: type assertion failed, want 'TypeBool', got 'string'`,
+		},
+		"number without type mapping": {
+			ruleFormat: "rules_v2024_10_21",
+			rules: papi.Rules{Name: "default", Behaviors: []papi.RuleBehavior{{
+				Name:    "adScalerCircuitBreaker",
+				Options: papi.RuleOptionsMap{"returnErrorResponseCodeBased": float64(503)},
+			}}},
+			expectedErr: `rules.behaviors[0].adScalerCircuitBreaker.returnErrorResponseCodeBased: type assertion failed, want 'TypeString', got 'float64'`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			g, err := NewHCLGenerator(test.ruleFormat, test.labelPrefix)
+			var hcl []byte
+			if err == nil {
+				hcl, err = g.Generate(test.rules)
+			}
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			expected, err := os.ReadFile(test.expected)
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), string(hcl))
+		})
+	}
+}
@@ -6,7 +6,8 @@ import (
 
 type (
 	registry struct {
-		rules []RuleFormat
+		rules              []RuleFormat
+		optionDependencies map[string]map[string][]optionDependency
 	}
 )
 
@@ -52,6 +53,19 @@ func (r *registry) register(rf RuleFormat) {
 	r.rules = append(r.rules, rf)
 }
 
+// registerOptionDependencies registers the option dependencies of the behaviors and criteria of a rule format,
+// which are generated separately from its schemas
+func (r *registry) registerOptionDependencies(ruleFormat string, dependencies map[string][]optionDependency) {
+	if r.optionDependencies == nil {
+		r.optionDependencies = map[string]map[string][]optionDependency{}
+	}
+	r.optionDependencies[ruleFormat] = dependencies
+}
+
+func (r *registry) dependencies(ruleFormat string) map[string][]optionDependency {
+	return r.optionDependencies[ruleFormat]
+}
+
 func (r *registry) rulesFormats() []RuleVersion {
 	var rulesFormats []RuleVersion
 
@@ -71,6 +85,15 @@ func (r *registry) typeMappings(ruleFormat string) map[string]any {
 	return nil
 }
 
+func (r *registry) ruleFormat(ruleFormat string) (RuleFormat, bool) {
+	for _, r := range r.rules {
+		if r.version == ruleFormat {
+			return r, true
+		}
+	}
+	return RuleFormat{}, false
+}
+
 func (r *registry) nameMappings(ruleFormat string) map[string]string {
 	for _, r := range r.rules {
 		if r.version == ruleFormat {
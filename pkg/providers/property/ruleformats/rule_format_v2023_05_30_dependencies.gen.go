@@ -0,0 +1,69 @@
+// Code generated by generate_dependencies.go; DO NOT EDIT.
+
+package ruleformats
+
+func init() {
+	schemasRegistry.registerOptionDependencies("rules_v2023_05_30", map[string][]optionDependency{
+		"fail_action": {
+			{option: "net_storage_hostname", dependsOn: "action_type", values: []string{"RECREATED_NS"}},
+			{option: "net_storage_path", dependsOn: "action_type", values: []string{"RECREATED_NS"}},
+			{option: "protocol", dependsOn: "action_type", values: []string{"REDIRECT"}},
+			{option: "redirect_hostname", dependsOn: "action_type", values: []string{"REDIRECT"}},
+			{option: "redirect_hostname", dependsOn: "redirect_hostname_type", values: []string{"ALTERNATE"}},
+		},
+		"large_file_optimization": {
+			{option: "use_versioning", dependsOn: "enable_partial_object_caching", values: []string{"PARTIAL_OBJECT_CACHING"}},
+		},
+		"modify_incoming_request_header": {
+			{option: "standard_add_header_name", dependsOn: "action", values: []string{"ADD"}},
+			{option: "standard_delete_header_name", dependsOn: "action", values: []string{"DELETE"}},
+			{option: "standard_modify_header_name", dependsOn: "action", values: []string{"MODIFY"}},
+			{option: "standard_pass_header_name", dependsOn: "action", values: []string{"PASS"}},
+		},
+		"modify_incoming_response_header": {
+			{option: "standard_add_header_name", dependsOn: "action", values: []string{"ADD"}},
+			{option: "standard_delete_header_name", dependsOn: "action", values: []string{"DELETE"}},
+			{option: "standard_modify_header_name", dependsOn: "action", values: []string{"MODIFY"}},
+			{option: "standard_pass_header_name", dependsOn: "action", values: []string{"PASS"}},
+		},
+		"modify_outgoing_request_header": {
+			{option: "standard_add_header_name", dependsOn: "action", values: []string{"ADD"}},
+			{option: "standard_delete_header_name", dependsOn: "action", values: []string{"DELETE"}},
+			{option: "standard_modify_header_name", dependsOn: "action", values: []string{"MODIFY", "REGEX"}},
+		},
+		"modify_outgoing_response_header": {
+			{option: "standard_add_header_name", dependsOn: "action", values: []string{"ADD"}},
+			{option: "standard_delete_header_name", dependsOn: "action", values: []string{"DELETE"}},
+			{option: "standard_modify_header_name", dependsOn: "action", values: []string{"MODIFY", "REGEX"}},
+		},
+		"redirect": {
+			{option: "destination_path_prefix", dependsOn: "destination_path", values: []string{"PREFIX_REQUEST"}},
+			{option: "destination_path_suffix", dependsOn: "destination_path", values: []string{"PREFIX_REQUEST"}},
+			{option: "destination_path_suffix", dependsOn: "destination_path_suffix_status", values: []string{"SUFFIX"}},
+			{option: "destination_path_suffix_status", dependsOn: "destination_path", values: []string{"PREFIX_REQUEST"}},
+		},
+		"response_cookie": {
+			{option: "duration", dependsOn: "expires", values: []string{"DURATION"}},
+			{option: "expiration_date", dependsOn: "expires", values: []string{"FIXED_DATE"}},
+			{option: "value", dependsOn: "type", values: []string{"FIXED"}},
+		},
+		"rewrite_url": {
+			{option: "match", dependsOn: "behavior", values: []string{"REMOVE", "REPLACE"}},
+			{option: "match_regex", dependsOn: "behavior", values: []string{"REGEX_REPLACE"}},
+			{option: "target_path", dependsOn: "behavior", values: []string{"REPLACE"}},
+			{option: "target_path_prepend", dependsOn: "behavior", values: []string{"PREPEND"}},
+			{option: "target_regex", dependsOn: "behavior", values: []string{"REGEX_REPLACE"}},
+			{option: "target_url", dependsOn: "behavior", values: []string{"REWRITE"}},
+		},
+		"simulate_error_code": {
+			{option: "timeout", dependsOn: "error_type", values: []string{"ERR_CONNECT_TIMEOUT", "ERR_DNS_TIMEOUT", "ERR_SUREROUTE_DNS_FAIL", "ERR_READ_TIMEOUT"}},
+		},
+		"sure_route": {
+			{option: "custom_map", dependsOn: "type", values: []string{"CUSTOM_MAP"}},
+			{option: "to_host", dependsOn: "to_host_status", values: []string{"OTHER"}},
+		},
+		"verify_token_authorization": {
+			{option: "location_id", dependsOn: "location", values: []string{"CLIENT_REQUEST_HEADER"}},
+		},
+	})
+}
@@ -1,6 +1,8 @@
 // Package ruleformats contains logic required for akamai_property_rules_builder data source.
 package ruleformats
 
+//go:generate go run generate_dependencies.go
+
 import (
 	"strings"
 
@@ -0,0 +1,220 @@
+package ruleformats
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/papi"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type (
+	// optionDependency describes an option which applies only when another option
+	// of the same behavior or criterion has one of the given values
+	optionDependency struct {
+		option    string
+		dependsOn string
+		values    []string
+	}
+
+	// RulesValidator validates the behaviors and criteria of the rules builder configuration beyond the schema of their options.
+	RulesValidator struct {
+		schemaReader *RulesSchemaReader
+		diags        diag.Diagnostics
+	}
+)
+
+var userVariableReference = regexp.MustCompile(`{{user\.([A-Za-z0-9_]+)}}`)
+
+// NewRulesValidator returns a new RulesValidator of the rules builder configuration in the given schema.ResourceData.
+func NewRulesValidator(d *schema.ResourceData) *RulesValidator {
+	return &RulesValidator{schemaReader: NewRulesSchemaReader(d)}
+}
+
+// Validate returns warnings of options which are set while they do not apply, depending on other options
+// of the same behavior or criterion as generated from the schemas of the rule format, and of references to user variables
+// in the default rule and its children which are not declared in its variables.
+func (v *RulesValidator) Validate() diag.Diagnostics {
+	v.diags = nil
+	references := map[string][]cty.Path{}
+
+	v.ruleItems("behavior", v.schemaReader.behaviorsKey(), references)
+	v.ruleItems("criterion", v.schemaReader.criteriaKey(), references)
+
+	if name, _ := v.schemaReader.getString(v.schemaReader.nameKey()); name == defaultRule {
+		v.childReferences(references)
+		v.variables(references)
+	}
+	return v.diags
+}
+
+// ruleItems validates the dependencies of options of the behaviors or criteria and collects their variable references
+func (v *RulesValidator) ruleItems(itemType, key string, references map[string][]cty.Path) {
+	rawVal, ok := v.schemaReader.data.GetOk(key)
+	if !ok {
+		return
+	}
+	list, _ := rawVal.([]any)
+	for i, val := range list {
+		itemsMap, _ := val.(map[string]any)
+		for name, items := range itemsMap {
+			itemList, _ := items.([]any)
+			if len(itemList) == 0 {
+				continue
+			}
+			options, _ := itemList[0].(map[string]any)
+			path := cty.GetAttrPath(v.schemaReader.ruleFormatKey).IndexInt(0).GetAttr(itemType).IndexInt(i).GetAttr(name).IndexInt(0)
+
+			v.dependencies(itemType, name, options, path)
+			for _, option := range sortedKeys(options) {
+				collectReferences(name, option, options[option], path.GetAttr(option), references)
+			}
+		}
+	}
+}
+
+func (v *RulesValidator) dependencies(itemType, name string, options map[string]any, path cty.Path) {
+	for _, dep := range schemasRegistry.dependencies(v.schemaReader.ruleFormatKey)[name] {
+		value := options[dep.option]
+		dependsOn, ok := options[dep.dependsOn].(string)
+		if !ok || dependsOn == "" || !isSet(value) {
+			continue
+		}
+		applies := false
+		for _, allowed := range dep.values {
+			applies = applies || allowed == dependsOn
+		}
+		if !applies {
+			v.diags = append(v.diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary: fmt.Sprintf("option %q of %s %q applies only when %q is one of %s, not %q",
+					dep.option, itemType, name, dep.dependsOn, strings.Join(quote(dep.values), ", "), dependsOn),
+				Detail:        fmt.Sprintf("The value %v is not used and can be removed.", valueString(value)),
+				AttributePath: path.GetAttr(dep.option),
+			})
+		}
+	}
+}
+
+// childReferences collects the variable references of the child rules in JSON
+func (v *RulesValidator) childReferences(references map[string][]cty.Path) {
+	children, err := v.schemaReader.GetChildrenList()
+	if err != nil {
+		return
+	}
+	for i, childJSON := range children {
+		var child RulesUpdate
+		if err := json.Unmarshal([]byte(childJSON), &child); err != nil {
+			continue
+		}
+		path := cty.GetAttrPath(v.schemaReader.ruleFormatKey).IndexInt(0).GetAttr("children").IndexInt(i)
+		collectRuleReferences(child.Rules, path, references)
+	}
+}
+
+// variables reports references to variables which are not declared in the default rule as warnings,
+// as the rules of an include may refer to the variables of the properties using the include
+func (v *RulesValidator) variables(references map[string][]cty.Path) {
+	declared := map[string]bool{}
+	variables, _ := v.schemaReader.GetVariablesList()
+	for _, variable := range variables {
+		if name, ok := variable["name"].(string); ok {
+			declared[name] = true
+		}
+	}
+
+	names := make([]string, 0, len(references))
+	for name := range references {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if declared[name] {
+			continue
+		}
+		for _, path := range references[name] {
+			v.diags = append(v.diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("variable %q is not declared", name),
+				Detail: fmt.Sprintf("Add a variable block named %q to the default rule, or correct the reference. "+
+					"Ignore this warning if the rules are used by an include, whose variables are declared by the properties using it.", name),
+				AttributePath: path,
+			})
+		}
+	}
+}
+
+func collectRuleReferences(rule papi.Rules, path cty.Path, references map[string][]cty.Path) {
+	for _, items := range [][]papi.RuleBehavior{rule.Criteria, rule.Behaviors} {
+		for _, item := range items {
+			for _, option := range sortedKeys(item.Options) {
+				collectReferences(item.Name, option, item.Options[option], path, references)
+			}
+		}
+	}
+	for _, child := range rule.Children {
+		collectRuleReferences(child, path, references)
+	}
+}
+
+// collectReferences collects the names of user variables referenced by the value of an option, either as {{user.NAME}}
+// or as the variable name of the setVariable behavior and the matchVariable criterion
+func collectReferences(itemName, option string, value any, path cty.Path, references map[string][]cty.Path) {
+	switch v := value.(type) {
+	case string:
+		if option == "variable_name" || option == "variableName" {
+			switch itemName {
+			case "set_variable", "setVariable", "match_variable", "matchVariable":
+				if v != "" {
+					references[v] = append(references[v], path)
+				}
+				return
+			}
+		}
+		for _, match := range userVariableReference.FindAllStringSubmatch(v, -1) {
+			references[match[1]] = append(references[match[1]], path)
+		}
+	case []any:
+		for _, elem := range v {
+			collectReferences(itemName, "", elem, path, references)
+		}
+	case map[string]any:
+		for _, nested := range sortedKeys(v) {
+			collectReferences(itemName, "", v[nested], path, references)
+		}
+	}
+}
+
+func isSet(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case string:
+		return v != ""
+	case []any:
+		return len(v) > 0
+	}
+	return true
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func quote(values []string) []string {
+	quoted := make([]string, 0, len(values))
+	for _, v := range values {
+		quoted = append(quoted, fmt.Sprintf("%q", v))
+	}
+	return quoted
+}
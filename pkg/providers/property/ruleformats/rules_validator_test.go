@@ -0,0 +1,143 @@
+package ruleformats
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testConfig map[string]any
+
+func (c testConfig) GetOk(key string) (any, bool) {
+	v, ok := c[key]
+	return v, ok
+}
+
+func TestRulesValidator(t *testing.T) {
+	const key = "rules_v2024_10_21"
+	behaviorPath := func(i int, name string) cty.Path {
+		return cty.GetAttrPath(key).IndexInt(0).GetAttr("behavior").IndexInt(i).GetAttr(name).IndexInt(0)
+	}
+	behavior := func(name string, options map[string]any) map[string]any {
+		return map[string]any{name: []any{options}}
+	}
+
+	tests := map[string]struct {
+		ruleFormat    string
+		config        testConfig
+		expectedDiags diag.Diagnostics
+	}{
+		"valid options and declared variables": {
+			config: testConfig{
+				key + ".0.name": "default",
+				key + ".0.behavior": []any{
+					behavior("caching", map[string]any{"behavior": "MAX_AGE", "ttl": "1d", "must_revalidate": false}),
+					behavior("set_variable", map[string]any{"variable_name": "PMUSER_FOO", "value": "{{user.PMUSER_BAR}}"}),
+				},
+				key + ".0.variable": []any{
+					map[string]any{"name": "PMUSER_FOO"},
+					map[string]any{"name": "PMUSER_BAR"},
+				},
+				key + ".0.children": []any{`{"rules":{"name":"child","behaviors":[{"name":"origin","options":{"hostname":"{{user.PMUSER_FOO}}"}}]}}`},
+			},
+		},
+		"option which does not apply": {
+			config: testConfig{
+				key + ".0.name": "child",
+				key + ".0.behavior": []any{
+					behavior("sure_route", map[string]any{"type": "PERFORMANCE", "custom_map": "example.akamai.net", "to_host_status": "OTHER", "to_host": "example.com"}),
+					behavior("rewrite_url", map[string]any{"behavior": "REPLACE", "match": "/a/", "target_path": "/b/", "target_url": ""}),
+				},
+			},
+			expectedDiags: diag.Diagnostics{{
+				Severity:      diag.Warning,
+				Summary:       `option "custom_map" of behavior "sure_route" applies only when "type" is one of "CUSTOM_MAP", not "PERFORMANCE"`,
+				Detail:        `The value "example.akamai.net" is not used and can be removed.`,
+				AttributePath: behaviorPath(0, "sure_route").GetAttr("custom_map"),
+			}},
+		},
+		"dependencies of the given rule format": {
+			ruleFormat: "rules_v2023_01_05",
+			config: testConfig{
+				"rules_v2023_01_05.0.name": "child",
+				"rules_v2023_01_05.0.behavior": []any{
+					behavior("input_validation", map[string]any{"validate_on_origin_with": "RESPONSE_CODE", "validate_on_origin_header_name": "X-Valid"}),
+				},
+			},
+			expectedDiags: diag.Diagnostics{{
+				Severity:      diag.Warning,
+				Summary:       `option "validate_on_origin_header_name" of behavior "input_validation" applies only when "validate_on_origin_with" is one of "RESPONSE_CODE_AND_HEADER", not "RESPONSE_CODE"`,
+				Detail:        `The value "X-Valid" is not used and can be removed.`,
+				AttributePath: cty.GetAttrPath("rules_v2023_01_05").IndexInt(0).GetAttr("behavior").IndexInt(0).GetAttr("input_validation").IndexInt(0).GetAttr("validate_on_origin_header_name"),
+			}},
+		},
+		"undeclared variables": {
+			config: testConfig{
+				key + ".0.name": "default",
+				key + ".0.behavior": []any{
+					behavior("origin", map[string]any{"origin_type": "CUSTOMER", "hostname": "{{user.PMUSER_ORIGIN}}"}),
+				},
+				key + ".0.variable": []any{map[string]any{"name": "PMUSER_FOO"}},
+				key + ".0.children": []any{`{"rules":{"name":"child","criteria":[{"name":"matchVariable","options":{"variableName":"PMUSER_BAR"}}]}}`},
+			},
+			expectedDiags: diag.Diagnostics{
+				{
+					Severity: diag.Warning,
+					Summary:  `variable "PMUSER_BAR" is not declared`,
+					Detail: `Add a variable block named "PMUSER_BAR" to the default rule, or correct the reference. ` +
+						`Ignore this warning if the rules are used by an include, whose variables are declared by the properties using it.`,
+					AttributePath: cty.GetAttrPath(key).IndexInt(0).GetAttr("children").IndexInt(0),
+				},
+				{
+					Severity: diag.Warning,
+					Summary:  `variable "PMUSER_ORIGIN" is not declared`,
+					Detail: `Add a variable block named "PMUSER_ORIGIN" to the default rule, or correct the reference. ` +
+						`Ignore this warning if the rules are used by an include, whose variables are declared by the properties using it.`,
+					AttributePath: behaviorPath(0, "origin").GetAttr("hostname"),
+				},
+			},
+		},
+		"variables are not checked in child rules": {
+			config: testConfig{
+				key + ".0.name": "child",
+				key + ".0.behavior": []any{
+					behavior("origin", map[string]any{"origin_type": "CUSTOMER", "hostname": "{{user.PMUSER_ORIGIN}}"}),
+				},
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ruleFormat := key
+			if test.ruleFormat != "" {
+				ruleFormat = test.ruleFormat
+			}
+			validator := RulesValidator{schemaReader: &RulesSchemaReader{data: test.config, ruleFormatKey: ruleFormat}}
+			assert.Equal(t, test.expectedDiags, validator.Validate())
+		})
+	}
+}
+
+func TestOptionDependenciesExist(t *testing.T) {
+	for _, ruleFormat := range schemasRegistry.rules {
+		dependencies := schemasRegistry.dependencies(ruleFormat.version)
+		require.NotEmpty(t, dependencies, ruleFormat.version)
+
+		for name, deps := range dependencies {
+			item, ok := ruleFormat.behaviorsSchemas[name]
+			if !ok {
+				item, ok = ruleFormat.criteriaSchemas[name]
+			}
+			require.True(t, ok, "%s: behavior or criterion %q does not exist", ruleFormat.version, name)
+			options := item.Elem.(*schema.Resource).Schema
+			for _, dep := range deps {
+				assert.Contains(t, options, dep.option, "%s: %s.%s", ruleFormat.version, name, dep.option)
+				assert.Contains(t, options, dep.dependsOn, "%s: %s.%s", ruleFormat.version, name, dep.dependsOn)
+			}
+		}
+	}
+}
@@ -0,0 +1,15 @@
+data "akamai_property_rules_builder" "example_default" {
+  rules_v2024_10_21 {
+    name      = "default"
+    is_secure = false
+    children = [
+      data.akamai_property_rules_builder.example_2nd_rule.json,
+    ]
+  }
+}
+
+data "akamai_property_rules_builder" "example_2nd_rule" {
+  rules_v2024_10_21 {
+    name = "2nd rule"
+  }
+}
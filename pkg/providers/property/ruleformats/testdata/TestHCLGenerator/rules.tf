@@ -0,0 +1,85 @@
+data "akamai_property_rules_builder" "default" {
+  rules_v2024_10_21 {
+    name      = "default"
+    is_secure = true
+    comments  = "Managed by $${team}"
+    variable {
+      name        = "PMUSER_ORIGIN"
+      value       = "origin.example.com"
+      description = ""
+      hidden      = false
+      sensitive   = false
+    }
+    behavior {
+      origin {
+        hostname    = "{{user.PMUSER_ORIGIN}}"
+        http_port   = 80
+        origin_type = "CUSTOMER"
+        custom_certificates {
+          can_be_ca = false
+          issuer_rdns {
+            cn = "DigiCert TLS RSA SHA256 2020 CA1"
+          }
+        }
+      }
+    }
+    behavior {
+      ad_scaler_circuit_breaker {
+        return_error_response_code_based = "502"
+      }
+    }
+    behavior {
+      cp_code {
+        locked = true
+        value {
+          id       = 1048126
+          products = ["Fresca"]
+        }
+      }
+    }
+    children = [
+      data.akamai_property_rules_builder.static_content.json,
+      data.akamai_property_rules_builder.static_content_2.json,
+    ]
+  }
+}
+
+data "akamai_property_rules_builder" "static_content" {
+  rules_v2024_10_21 {
+    name                  = "Static Content"
+    criteria_must_satisfy = "any"
+    criterion {
+      file_extension {
+        match_operator = "IS_ONE_OF"
+        values         = ["css", "js"]
+      }
+    }
+    behavior {
+      caching {
+        behavior = "MAX_AGE"
+        ttl      = "1d"
+      }
+    }
+    children = [
+      data.akamai_property_rules_builder.images.json,
+    ]
+  }
+}
+
+data "akamai_property_rules_builder" "images" {
+  rules_v2024_10_21 {
+    name = "Images"
+    behavior {
+      prefetchable {
+        enabled = true
+      }
+    }
+  }
+}
+
+data "akamai_property_rules_builder" "static_content_2" {
+  rules_v2024_10_21 {
+    name                  = "static content"
+    criteria_must_satisfy = "all"
+  }
+}
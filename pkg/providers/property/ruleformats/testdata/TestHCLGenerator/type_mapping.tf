@@ -0,0 +1,11 @@
+data "akamai_property_rules_builder" "default" {
+  rules_v2024_10_21 {
+    name      = "default"
+    is_secure = false
+    behavior {
+      ad_scaler_circuit_breaker {
+        return_error_response_code_based = "408"
+      }
+    }
+  }
+}
@@ -0,0 +1,278 @@
+package ruleformats
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/papi"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type (
+	// Incompatibility is a behavior, criterion or option of a rule tree which changes between rule formats.
+	Incompatibility struct {
+		// Rule is the path of rule names, e.g. default/Static Content
+		Rule string
+		// Type is either behavior or criterion
+		Type string
+		// Name is the name of the behavior or criterion in the source rule format
+		Name string
+		// Option is the path of the option in the source rule format, e.g. customCertificates.issuerRDNs.CN,
+		// or empty when the whole behavior or criterion is incompatible
+		Option string
+		// Kind is the kind of the incompatibility
+		Kind IncompatibilityKind
+		// Message describes the incompatibility
+		Message string
+	}
+
+	// IncompatibilityKind is the kind of Incompatibility
+	IncompatibilityKind string
+
+	upgrader struct {
+		from, to          RuleFormat
+		incompatibilities []Incompatibility
+	}
+)
+
+const (
+	// IncompatibilityRemoved means that the behavior, criterion or option does not exist in the target rule format
+	// and is removed from the rule tree
+	IncompatibilityRemoved IncompatibilityKind = "removed"
+	// IncompatibilityRenamed means that the name of the behavior, criterion or option differs in case in the target rule format
+	// and is renamed in the rule tree
+	IncompatibilityRenamed IncompatibilityKind = "renamed"
+	// IncompatibilityInvalidValue means that the value of the option has a different type or is not allowed in the target rule format,
+	// e.g. because of changed enum values. The value is kept in the rule tree
+	IncompatibilityInvalidValue IncompatibilityKind = "invalid_value"
+)
+
+// Upgrade converts the rule tree from one rule format to another and returns the incompatibilities of its behaviors,
+// criteria and options, in the order of the rule tree. Removed behaviors, criteria and options are left out
+// of the returned rule tree and renamed ones get their new names, while options with incompatible values are kept as they are.
+func Upgrade(rules papi.Rules, from, to RuleVersion) (*papi.Rules, []Incompatibility, error) {
+	source, ok := schemasRegistry.ruleFormat(from.SchemaKey())
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %s", ErrUnknownRuleFormat, from.Version())
+	}
+	target, ok := schemasRegistry.ruleFormat(to.SchemaKey())
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %s", ErrUnknownRuleFormat, to.Version())
+	}
+
+	u := upgrader{from: source, to: target}
+	upgraded, err := u.rule(rules, rules.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &upgraded, u.incompatibilities, nil
+}
+
+func (u *upgrader) rule(rule papi.Rules, path string) (papi.Rules, error) {
+	var err error
+	if rule.Criteria, err = u.ruleItems(path, "criterion", rule.Criteria, u.from.criteriaSchemas, u.to.criteriaSchemas); err != nil {
+		return papi.Rules{}, err
+	}
+	if rule.Behaviors, err = u.ruleItems(path, "behavior", rule.Behaviors, u.from.behaviorsSchemas, u.to.behaviorsSchemas); err != nil {
+		return papi.Rules{}, err
+	}
+
+	children := rule.Children
+	rule.Children = make([]papi.Rules, 0, len(children))
+	for _, child := range children {
+		upgraded, err := u.rule(child, path+"/"+child.Name)
+		if err != nil {
+			return papi.Rules{}, err
+		}
+		rule.Children = append(rule.Children, upgraded)
+	}
+	if len(rule.Children) == 0 {
+		rule.Children = nil
+	}
+	return rule, nil
+}
+
+func (u *upgrader) ruleItems(path, itemType string, items []papi.RuleBehavior, from, to map[string]*schema.Schema) ([]papi.RuleBehavior, error) {
+	if items == nil {
+		return nil, nil
+	}
+	sourceNames, targetNames := jsonNames(from, u.from.nameMappings), jsonNames(to, u.to.nameMappings)
+
+	upgraded := make([]papi.RuleBehavior, 0, len(items))
+	for _, item := range items {
+		sourceKey, ok := sourceNames[item.Name]
+		if !ok {
+			return nil, fmt.Errorf("%s: %s %q: %w %s", path, itemType, item.Name, ErrNotInRuleFormat, RuleVersion(u.from.version).Version())
+		}
+		add := func(kind IncompatibilityKind, option, format string, args ...any) {
+			u.incompatibilities = append(u.incompatibilities, Incompatibility{
+				Rule:    path,
+				Type:    itemType,
+				Name:    item.Name,
+				Option:  option,
+				Kind:    kind,
+				Message: fmt.Sprintf(format, args...),
+			})
+		}
+
+		name, ok := u.name(item.Name, sourceNames, targetNames)
+		if !ok {
+			add(IncompatibilityRemoved, "", "%s %q does not exist in rule format %s", itemType, item.Name, u.version())
+			continue
+		}
+		if name != item.Name {
+			add(IncompatibilityRenamed, "", "%s %q is renamed to %q in rule format %s", itemType, item.Name, name, u.version())
+		}
+
+		options, err := u.options(item.Options, name, "", from[sourceKey].Elem.(*schema.Resource).Schema,
+			to[targetNames[name]].Elem.(*schema.Resource).Schema, add)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s %q: %w", path, itemType, item.Name, err)
+		}
+		item.Name = name
+		item.Options = options
+		upgraded = append(upgraded, item)
+	}
+	return upgraded, nil
+}
+
+// options converts the options of a behavior or criterion, or of their nested objects, reporting incompatibilities in their order by name
+func (u *upgrader) options(options map[string]any, item, path string, from, to map[string]*schema.Schema,
+	add func(kind IncompatibilityKind, option, format string, args ...any)) (map[string]any, error) {
+	if options == nil {
+		return nil, nil
+	}
+	sourceNames, targetNames := jsonNames(from, u.from.nameMappings), jsonNames(to, u.to.nameMappings)
+
+	names := make([]string, 0, len(options))
+	for name := range options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	upgraded := make(map[string]any, len(options))
+	for _, optionName := range names {
+		value, optionPath := options[optionName], path+optionName
+		if _, ok := sourceNames[optionName]; !ok {
+			return nil, fmt.Errorf("option %q: %w %s", optionPath, ErrNotInRuleFormat, RuleVersion(u.from.version).Version())
+		}
+		name, ok := u.name(optionName, sourceNames, targetNames)
+		if !ok {
+			add(IncompatibilityRemoved, optionPath, "option %q does not exist in rule format %s", optionPath, u.version())
+			continue
+		}
+		if name != optionName {
+			add(IncompatibilityRenamed, optionPath, "option %q is renamed to %q in rule format %s", optionPath, path+name, u.version())
+		}
+		key := targetNames[name]
+		upgraded[name] = value
+		if value == nil {
+			continue
+		}
+
+		elem, isObject := to[key].Elem.(*schema.Resource)
+		sourceElem, wasObject := from[sourceNames[optionName]].Elem.(*schema.Resource)
+		if isObject && wasObject {
+			converted, err := u.objects(value, item, optionPath+".", sourceElem.Schema, elem.Schema, add)
+			if err != nil {
+				return nil, err
+			}
+			upgraded[name] = converted
+			continue
+		}
+		if message, ok := invalidValue(to[key], key, unmapValue(u.to.typeMappings, item+"."+path+name, value)); !ok {
+			add(IncompatibilityInvalidValue, optionPath, "value %s of option %q is not valid in rule format %s: %s",
+				valueString(value), optionPath, u.version(), message)
+		}
+	}
+	return upgraded, nil
+}
+
+// objects converts the options of the object or list of objects which is the value of an option
+func (u *upgrader) objects(value any, item, path string, from, to map[string]*schema.Schema,
+	add func(kind IncompatibilityKind, option, format string, args ...any)) (any, error) {
+	switch v := value.(type) {
+	case map[string]any:
+		return u.options(v, item, path, from, to, add)
+	case []any:
+		converted := make([]any, 0, len(v))
+		for _, object := range v {
+			nested, ok := object.(map[string]any)
+			if !ok {
+				converted = append(converted, object)
+				continue
+			}
+			options, err := u.options(nested, item, path, from, to, add)
+			if err != nil {
+				return nil, err
+			}
+			converted = append(converted, options)
+		}
+		return converted, nil
+	}
+	return value, nil
+}
+
+// name returns the name in the target rule format of the behavior, criterion or option of the given name,
+// which is either the same or differs in case only
+func (u *upgrader) name(name string, sourceNames, targetNames map[string]string) (string, bool) {
+	if _, ok := targetNames[name]; ok {
+		return name, true
+	}
+	for targetName := range targetNames {
+		if _, ok := sourceNames[targetName]; !ok && strings.EqualFold(targetName, name) {
+			return targetName, true
+		}
+	}
+	return "", false
+}
+
+func (u *upgrader) version() string {
+	return RuleVersion(u.to.version).Version()
+}
+
+// invalidValue validates the value of an option with the schema of the target rule format
+func invalidValue(s *schema.Schema, key string, value any) (string, bool) {
+	converted, err := optionValue(s, value)
+	if err != nil {
+		return err.Error(), false
+	}
+	if s.ValidateDiagFunc == nil || !converted.Type().IsPrimitiveType() {
+		return "", true
+	}
+
+	var v any
+	switch s.Type {
+	case schema.TypeBool:
+		v = converted.True()
+	case schema.TypeInt:
+		i, _ := converted.AsBigFloat().Int64()
+		v = int(i)
+	case schema.TypeFloat:
+		v, _ = converted.AsBigFloat().Float64()
+	default:
+		v = converted.AsString()
+	}
+	if diags := s.ValidateDiagFunc(v, cty.GetAttrPath(key)); diags.HasError() {
+		return diags[0].Summary, false
+	}
+	return "", true
+}
+
+// jsonNames maps the names of behaviors, criteria or options in JSON to their schema keys
+func jsonNames(schemas map[string]*schema.Schema, nameMappings map[string]string) map[string]string {
+	names := make(map[string]string, len(schemas))
+	for key := range schemas {
+		names[jsonName(key, nameMappings)] = key
+	}
+	return names
+}
+
+func valueString(value any) string {
+	if s, ok := value.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	return fmt.Sprintf("%v", value)
+}
@@ -0,0 +1,145 @@
+package ruleformats
+
+import (
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/papi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpgrade(t *testing.T) {
+	origin := func(options papi.RuleOptionsMap) papi.RuleBehavior {
+		return papi.RuleBehavior{Name: "origin", Options: options}
+	}
+	rules := papi.Rules{
+		Name: "default",
+		Behaviors: []papi.RuleBehavior{
+			origin(papi.RuleOptionsMap{
+				"originType":   "CUSTOMER",
+				"tls13Support": true,
+				"customCertificates": []any{
+					map[string]any{"canBeCA": false, "issuerRDNs": map[string]any{"CN": "DigiCert TLS RSA SHA256 2020 CA1"}},
+				},
+			}),
+			{Name: "adScalerCircuitBreaker", Options: papi.RuleOptionsMap{"returnErrorResponseCodeBased": float64(502)}},
+		},
+		Children: []papi.Rules{{
+			Name:     "Static Content",
+			Criteria: []papi.RuleBehavior{{Name: "fileExtension", Options: papi.RuleOptionsMap{"values": []any{"css"}}}},
+			Behaviors: []papi.RuleBehavior{
+				{Name: "caching", Options: papi.RuleOptionsMap{"behavior": "FOREVER", "mustRevalidate": "yes"}},
+			},
+		}},
+	}
+
+	tests := map[string]struct {
+		rules                     papi.Rules
+		from, to                  RuleVersion
+		expectedRules             *papi.Rules
+		expectedIncompatibilities []Incompatibility
+		expectedErr               string
+	}{
+		"removed option and invalid values": {
+			rules: rules,
+			from:  "rules_v2024_02_12",
+			to:    "rules_v2024_10_21",
+			expectedRules: &papi.Rules{
+				Name: "default",
+				Behaviors: []papi.RuleBehavior{
+					origin(papi.RuleOptionsMap{
+						"originType": "CUSTOMER",
+						"customCertificates": []any{
+							map[string]any{"canBeCA": false, "issuerRDNs": map[string]any{"CN": "DigiCert TLS RSA SHA256 2020 CA1"}},
+						},
+					}),
+					{Name: "adScalerCircuitBreaker", Options: papi.RuleOptionsMap{"returnErrorResponseCodeBased": float64(502)}},
+				},
+				Children: []papi.Rules{{
+					Name:     "Static Content",
+					Criteria: []papi.RuleBehavior{{Name: "fileExtension", Options: papi.RuleOptionsMap{"values": []any{"css"}}}},
+					Behaviors: []papi.RuleBehavior{
+						{Name: "caching", Options: papi.RuleOptionsMap{"behavior": "FOREVER", "mustRevalidate": "yes"}},
+					},
+				}},
+			},
+			expectedIncompatibilities: []Incompatibility{
+				{
+					Rule:    "default",
+					Type:    "behavior",
+					Name:    "origin",
+					Option:  "tls13Support",
+					Kind:    IncompatibilityRemoved,
+					Message: `option "tls13Support" does not exist in rule format v2024-10-21`,
+				},
+				{
+					Rule:    "default/Static Content",
+					Type:    "behavior",
+					Name:    "caching",
+					Option:  "behavior",
+					Kind:    IncompatibilityInvalidValue,
+					Message: `value "FOREVER" of option "behavior" is not valid in rule format v2024-10-21: expected behavior to be one of ["MAX_AGE" "NO_STORE" "BYPASS_CACHE" "CACHE_CONTROL_AND_EXPIRES" "CACHE_CONTROL" "EXPIRES"], got FOREVER`,
+				},
+				{
+					Rule:    "default/Static Content",
+					Type:    "behavior",
+					Name:    "caching",
+					Option:  "mustRevalidate",
+					Kind:    IncompatibilityInvalidValue,
+					Message: `value "yes" of option "mustRevalidate" is not valid in rule format v2024-10-21: type assertion failed, want 'TypeBool', got 'string'`,
+				},
+			},
+		},
+		"removed behavior": {
+			rules: papi.Rules{
+				Name: "default",
+				Behaviors: []papi.RuleBehavior{
+					{Name: "shutr", Options: papi.RuleOptionsMap{}},
+					{Name: "caching", Options: papi.RuleOptionsMap{"behavior": "NO_STORE"}},
+				},
+			},
+			from: "rules_v2023_01_05",
+			to:   "rules_v2024_10_21",
+			expectedRules: &papi.Rules{
+				Name: "default",
+				Behaviors: []papi.RuleBehavior{
+					{Name: "caching", Options: papi.RuleOptionsMap{"behavior": "NO_STORE"}},
+				},
+			},
+			expectedIncompatibilities: []Incompatibility{{
+				Rule:    "default",
+				Type:    "behavior",
+				Name:    "shutr",
+				Kind:    IncompatibilityRemoved,
+				Message: `behavior "shutr" does not exist in rule format v2024-10-21`,
+			}},
+		},
+		"behavior not in source rule format": {
+			rules: papi.Rules{
+				Name:      "default",
+				Behaviors: []papi.RuleBehavior{{Name: "commonMediaClientData", Options: papi.RuleOptionsMap{}}},
+			},
+			from:        "rules_v2023_01_05",
+			to:          "rules_v2024_10_21",
+			expectedErr: `default: behavior "commonMediaClientData": does not exist in rule format v2023-01-05`,
+		},
+		"unknown rule format": {
+			rules:       rules,
+			from:        "rules_v2024_02_12",
+			to:          "rules_v2030_01_01",
+			expectedErr: "unknown rule format: v2030-01-01",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			upgraded, incompatibilities, err := Upgrade(test.rules, test.from, test.to)
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedRules, upgraded)
+			assert.Equal(t, test.expectedIncompatibilities, incompatibilities)
+		})
+	}
+}
@@ -0,0 +1,281 @@
+package property
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/papi"
+)
+
+type (
+	// rulesDiffer collects the changes between two rule trees, one line per change
+	rulesDiffer struct {
+		lines []string
+	}
+
+	// ruleItem is a behavior or criterion of a rule, or a child rule, identified by its name
+	// and the number of preceding items of the same name
+	ruleItem struct {
+		name       string
+		occurrence int
+	}
+)
+
+// rulesDiff describes the changes between two normalized rule trees, in the order of the rule tree, one change per line:
+// '+' marks added rules, behaviors, criteria, variables and options, '-' removed ones, and '~' changed or reordered ones.
+// Each line starts with the path of rule names, e.g. default/Static Content.
+func rulesDiff(oldRules, newRules papi.RulesUpdate) string {
+	var d rulesDiffer
+	if oldRules.Comments != newRules.Comments {
+		d.add("~", "", "comments changed from %s to %s", jsonValue(oldRules.Comments), jsonValue(newRules.Comments))
+	}
+	d.rule(newRules.Rules.Name, oldRules.Rules, newRules.Rules)
+	return strings.Join(d.lines, "\n")
+}
+
+func (d *rulesDiffer) add(symbol, path, format string, args ...any) {
+	line := symbol + " "
+	if path != "" {
+		line += path + ": "
+	}
+	d.lines = append(d.lines, line+fmt.Sprintf(format, args...))
+}
+
+func (d *rulesDiffer) rule(path string, oldRule, newRule papi.Rules) {
+	d.fields(path, "", ruleFields(oldRule), ruleFields(newRule))
+	d.variables(path, oldRule.Variables, newRule.Variables)
+	d.items(path, "criterion", oldRule.Criteria, newRule.Criteria)
+	d.items(path, "behavior", oldRule.Behaviors, newRule.Behaviors)
+	d.children(path, oldRule.Children, newRule.Children)
+}
+
+// fields reports changes of the attributes of a rule, behavior or criterion other than the nested ones
+func (d *rulesDiffer) fields(path, of string, oldFields, newFields map[string]any) {
+	for _, key := range unionKeys(oldFields, newFields) {
+		oldValue, inOld := oldFields[key]
+		newValue, inNew := newFields[key]
+		switch {
+		case !inOld:
+			d.add("+", path, "%s%s set to %s", key, of, jsonValue(newValue))
+		case !inNew:
+			d.add("-", path, "%s%s removed, was %s", key, of, jsonValue(oldValue))
+		case !reflect.DeepEqual(oldValue, newValue):
+			d.add("~", path, "%s%s changed from %s to %s", key, of, jsonValue(oldValue), jsonValue(newValue))
+		}
+	}
+}
+
+func (d *rulesDiffer) variables(path string, oldVariables, newVariables []papi.RuleVariable) {
+	oldByName, newByName := map[string]any{}, map[string]any{}
+	for _, v := range oldVariables {
+		oldByName[v.Name] = v
+	}
+	for _, v := range newVariables {
+		newByName[v.Name] = v
+	}
+	for _, name := range unionKeys(oldByName, newByName) {
+		oldVariable, inOld := oldByName[name]
+		newVariable, inNew := newByName[name]
+		switch {
+		case !inOld:
+			d.add("+", path, "variable %q added", name)
+		case !inNew:
+			d.add("-", path, "variable %q removed", name)
+		case !reflect.DeepEqual(oldVariable, newVariable):
+			d.add("~", path, "variable %q changed from %s to %s", name, jsonValue(oldVariable), jsonValue(newVariable))
+		}
+	}
+}
+
+// items reports removed, added and reordered behaviors or criteria, and changes of their options
+func (d *rulesDiffer) items(path, itemType string, oldItems, newItems []papi.RuleBehavior) {
+	oldByItem, oldOrder := map[ruleItem]papi.RuleBehavior{}, make([]ruleItem, 0, len(oldItems))
+	for _, item := range oldItems {
+		key := nextRuleItem(item.Name, oldOrder)
+		oldByItem[key], oldOrder = item, append(oldOrder, key)
+	}
+	newByItem, newOrder := map[ruleItem]papi.RuleBehavior{}, make([]ruleItem, 0, len(newItems))
+	for _, item := range newItems {
+		key := nextRuleItem(item.Name, newOrder)
+		newByItem[key], newOrder = item, append(newOrder, key)
+	}
+
+	for _, key := range oldOrder {
+		if _, ok := newByItem[key]; !ok {
+			d.add("-", path, "%s %s removed", itemType, key)
+		}
+	}
+	for _, key := range newOrder {
+		if _, ok := oldByItem[key]; !ok {
+			d.add("+", path, "%s %s added", itemType, key)
+		}
+	}
+	if oldCommon, newCommon := commonRuleItems(oldOrder, newByItem), commonRuleItems(newOrder, oldByItem); !reflect.DeepEqual(oldCommon, newCommon) {
+		d.add("~", path, "%s order changed from %s to %s", itemType, ruleItemNames(oldCommon), ruleItemNames(newCommon))
+	}
+
+	for _, key := range newOrder {
+		oldItem, ok := oldByItem[key]
+		if !ok {
+			continue
+		}
+		newItem := newByItem[key]
+		of := fmt.Sprintf(" of %s %s", itemType, key)
+		d.fields(path, of, itemFields(oldItem), itemFields(newItem))
+		d.options(path, "", of, oldItem.Options, newItem.Options)
+	}
+}
+
+// options reports added, removed and changed options, comparing the options of nested objects one by one
+func (d *rulesDiffer) options(path, prefix, of string, oldOptions, newOptions map[string]any) {
+	for _, key := range unionKeys(oldOptions, newOptions) {
+		oldValue, inOld := oldOptions[key]
+		newValue, inNew := newOptions[key]
+		option := prefix + key
+		switch {
+		case !inOld:
+			d.add("+", path, "option %q%s set to %s", option, of, jsonValue(newValue))
+		case !inNew:
+			d.add("-", path, "option %q%s removed, was %s", option, of, jsonValue(oldValue))
+		default:
+			oldObject, oldIsObject := oldValue.(map[string]any)
+			newObject, newIsObject := newValue.(map[string]any)
+			if oldIsObject && newIsObject {
+				d.options(path, option+".", of, oldObject, newObject)
+				continue
+			}
+			if !reflect.DeepEqual(oldValue, newValue) {
+				d.add("~", path, "option %q%s changed from %s to %s", option, of, jsonValue(oldValue), jsonValue(newValue))
+			}
+		}
+	}
+}
+
+// children reports removed, added and moved child rules, matching them by name, and the changes of the remaining ones
+func (d *rulesDiffer) children(path string, oldChildren, newChildren []papi.Rules) {
+	oldByItem, oldOrder := map[ruleItem]papi.Rules{}, make([]ruleItem, 0, len(oldChildren))
+	for _, child := range oldChildren {
+		key := nextRuleItem(child.Name, oldOrder)
+		oldByItem[key], oldOrder = child, append(oldOrder, key)
+	}
+	newByItem, newOrder := map[ruleItem]papi.Rules{}, make([]ruleItem, 0, len(newChildren))
+	for _, child := range newChildren {
+		key := nextRuleItem(child.Name, newOrder)
+		newByItem[key], newOrder = child, append(newOrder, key)
+	}
+
+	for _, key := range oldOrder {
+		if _, ok := newByItem[key]; !ok {
+			d.add("-", path+"/"+key.name, "rule removed")
+		}
+	}
+	for _, key := range newOrder {
+		if _, ok := oldByItem[key]; !ok {
+			d.add("+", path+"/"+key.name, "rule added")
+		}
+	}
+	oldCommon, newCommon := commonRuleItems(oldOrder, newByItem), commonRuleItems(newOrder, oldByItem)
+	for i, key := range newCommon {
+		if oldCommon[i] == key {
+			continue
+		}
+		for j := range oldCommon {
+			if oldCommon[j] == key {
+				d.add("~", path+"/"+key.name, "rule moved from position %d to %d", j+1, i+1)
+			}
+		}
+	}
+
+	for _, key := range newOrder {
+		if oldChild, ok := oldByItem[key]; ok {
+			d.rule(path+"/"+key.name, oldChild, newByItem[key])
+		}
+	}
+}
+
+// String returns the quoted name of the item, followed by its number when there are more items of the same name
+func (i ruleItem) String() string {
+	if i.occurrence == 0 {
+		return fmt.Sprintf("%q", i.name)
+	}
+	return fmt.Sprintf("%q #%d", i.name, i.occurrence+1)
+}
+
+func nextRuleItem(name string, preceding []ruleItem) ruleItem {
+	item := ruleItem{name: name}
+	for _, p := range preceding {
+		if p.name == name {
+			item.occurrence++
+		}
+	}
+	return item
+}
+
+// commonRuleItems returns the items in the given order which are also present in the other collection
+func commonRuleItems[T any](order []ruleItem, other map[ruleItem]T) []ruleItem {
+	common := make([]ruleItem, 0, len(order))
+	for _, key := range order {
+		if _, ok := other[key]; ok {
+			common = append(common, key)
+		}
+	}
+	return common
+}
+
+func ruleItemNames(items []ruleItem) string {
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		names = append(names, item.String())
+	}
+	return "[" + strings.Join(names, ", ") + "]"
+}
+
+// ruleFields returns the JSON attributes of the rule without the nested rules, behaviors, criteria and variables
+func ruleFields(rule papi.Rules) map[string]any {
+	rule.Children, rule.Behaviors, rule.Criteria, rule.Variables = nil, nil, nil, nil
+	return jsonFields(rule)
+}
+
+// itemFields returns the JSON attributes of the behavior or criterion without its name and options
+func itemFields(item papi.RuleBehavior) map[string]any {
+	item.Name, item.Options = "", nil
+	fields := jsonFields(item)
+	delete(fields, "name")
+	delete(fields, "options")
+	return fields
+}
+
+func jsonFields(v any) map[string]any {
+	fields := map[string]any{}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fields
+	}
+	_ = json.Unmarshal(data, &fields)
+	return fields
+}
+
+func jsonValue(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(data)
+}
+
+func unionKeys[T any](a, b map[string]T) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
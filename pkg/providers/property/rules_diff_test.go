@@ -0,0 +1,120 @@
+package property
+
+import (
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v9/pkg/papi"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/ptr"
+	"github.com/tj/assert"
+)
+
+func TestRulesDiff(t *testing.T) {
+	caching := func(options papi.RuleOptionsMap) papi.RuleBehavior {
+		return papi.RuleBehavior{Name: "caching", Options: options}
+	}
+	rules := func(rule papi.Rules) papi.RulesUpdate {
+		return papi.RulesUpdate{Rules: rule}
+	}
+
+	tests := map[string]struct {
+		old, new papi.RulesUpdate
+		expected string
+	}{
+		"equal rules": {
+			old: rules(papi.Rules{Name: "default", Behaviors: []papi.RuleBehavior{caching(papi.RuleOptionsMap{"behavior": "NO_STORE"})}}),
+			new: rules(papi.Rules{Name: "default", Behaviors: []papi.RuleBehavior{caching(papi.RuleOptionsMap{"behavior": "NO_STORE"})}}),
+		},
+		"changed, added and removed options": {
+			old: rules(papi.Rules{
+				Name: "default",
+				Behaviors: []papi.RuleBehavior{
+					caching(papi.RuleOptionsMap{"behavior": "MAX_AGE", "ttl": "1d", "mustRevalidate": false}),
+					{Name: "origin", Options: papi.RuleOptionsMap{"hostname": "a.example.com", "customCertificateAuthorities": map[string]any{"canBeCA": false, "issuerRDNs": map[string]any{"CN": "A"}}}},
+				},
+			}),
+			new: rules(papi.Rules{
+				Name: "default",
+				Behaviors: []papi.RuleBehavior{
+					caching(papi.RuleOptionsMap{"behavior": "MAX_AGE", "ttl": "2d", "defaultTtl": "1h"}),
+					{Name: "origin", Options: papi.RuleOptionsMap{"hostname": "a.example.com", "customCertificateAuthorities": map[string]any{"canBeCA": false, "issuerRDNs": map[string]any{"CN": "B"}}}},
+				},
+			}),
+			expected: `+ default: option "defaultTtl" of behavior "caching" set to "1h"
+- default: option "mustRevalidate" of behavior "caching" removed, was false
+~ default: option "ttl" of behavior "caching" changed from "1d" to "2d"
+~ default: option "customCertificateAuthorities.issuerRDNs.CN" of behavior "origin" changed from "A" to "B"`,
+		},
+		"added, removed and reordered behaviors and criteria": {
+			old: rules(papi.Rules{
+				Name:      "default",
+				Criteria:  []papi.RuleBehavior{{Name: "path", Options: papi.RuleOptionsMap{"values": []any{"/a"}}}},
+				Behaviors: []papi.RuleBehavior{{Name: "cpCode"}, {Name: "origin"}, {Name: "gzipResponse"}},
+			}),
+			new: rules(papi.Rules{
+				Name:      "default",
+				Criteria:  []papi.RuleBehavior{{Name: "path", Options: papi.RuleOptionsMap{"values": []any{"/a", "/b"}}, Locked: true}},
+				Behaviors: []papi.RuleBehavior{{Name: "origin"}, {Name: "cpCode"}, {Name: "origin"}},
+			}),
+			expected: `+ default: locked of criterion "path" set to true
+~ default: option "values" of criterion "path" changed from ["/a"] to ["/a","/b"]
+- default: behavior "gzipResponse" removed
++ default: behavior "origin" #2 added
+~ default: behavior order changed from ["cpCode", "origin"] to ["origin", "cpCode"]`,
+		},
+		"added, removed, moved and changed child rules": {
+			old: rules(papi.Rules{
+				Name: "default",
+				Children: []papi.Rules{
+					{Name: "Static Content", CriteriaMustSatisfy: papi.RuleCriteriaMustSatisfyAll},
+					{Name: "Dynamic Content", Comments: "dynamic"},
+					{Name: "Old"},
+				},
+			}),
+			new: rules(papi.Rules{
+				Name: "default",
+				Children: []papi.Rules{
+					{Name: "Dynamic Content", Children: []papi.Rules{{Name: "New"}}},
+					{Name: "Static Content", CriteriaMustSatisfy: papi.RuleCriteriaMustSatisfyAny},
+				},
+			}),
+			expected: `- default/Old: rule removed
+~ default/Dynamic Content: rule moved from position 2 to 1
+~ default/Static Content: rule moved from position 1 to 2
+- default/Dynamic Content: comments removed, was "dynamic"
++ default/Dynamic Content/New: rule added
+~ default/Static Content: criteriaMustSatisfy changed from "all" to "any"`,
+		},
+		"comments and variables": {
+			old: papi.RulesUpdate{
+				Comments: "first",
+				Rules: papi.Rules{
+					Name: "default",
+					Variables: []papi.RuleVariable{
+						{Name: "PMUSER_A", Value: ptr.To("a"), Description: ptr.To("")},
+						{Name: "PMUSER_B", Value: ptr.To("b"), Description: ptr.To("")},
+					},
+				},
+			},
+			new: papi.RulesUpdate{
+				Comments: "second",
+				Rules: papi.Rules{
+					Name: "default",
+					Variables: []papi.RuleVariable{
+						{Name: "PMUSER_B", Value: ptr.To("c"), Description: ptr.To("")},
+						{Name: "PMUSER_C", Value: ptr.To("c"), Description: ptr.To("")},
+					},
+				},
+			},
+			expected: `~ comments changed from "first" to "second"
+- default: variable "PMUSER_A" removed
+~ default: variable "PMUSER_B" changed from {"description":"","hidden":false,"name":"PMUSER_B","sensitive":false,"value":"b"} to {"description":"","hidden":false,"name":"PMUSER_B","sensitive":false,"value":"c"}
++ default: variable "PMUSER_C" added`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, rulesDiff(test.old, test.new))
+		})
+	}
+}
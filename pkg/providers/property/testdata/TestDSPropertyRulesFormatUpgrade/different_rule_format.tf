@@ -0,0 +1,9 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+data "akamai_property_rules_format_upgrade" "test" {
+  rules              = file("testdata/TestDSPropertyRulesBuilder/default_v2024_10_21.json")
+  source_rule_format = "v2024-02-12"
+  target_rule_format = "v2024-10-21"
+}
@@ -0,0 +1,14 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+data "akamai_property_rules_format_upgrade" "test" {
+  rules = jsonencode({
+    rules = {
+      name      = "default"
+      behaviors = [{ name = "unknownBehavior", options = {} }]
+    }
+  })
+  source_rule_format = "v2023-01-05"
+  target_rule_format = "v2024-10-21"
+}
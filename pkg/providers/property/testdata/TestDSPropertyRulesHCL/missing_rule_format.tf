@@ -0,0 +1,7 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+data "akamai_property_rules_hcl" "test" {
+  rules = file("testdata/TestDSPropertyRulesHCL/rules.json")
+}
@@ -0,0 +1,29 @@
+data "akamai_property_rules_builder" "example_default" {
+  rules_v2024_10_21 {
+    name      = "default"
+    is_secure = false
+    behavior {
+      origin {
+        forward_host_header = "REQUEST_HOST_HEADER"
+        hostname            = "origin.example.com"
+        origin_type         = "CUSTOMER"
+      }
+    }
+    children = [
+      data.akamai_property_rules_builder.example_performance.json,
+    ]
+  }
+}
+
+data "akamai_property_rules_builder" "example_performance" {
+  rules_v2024_10_21 {
+    name                  = "Performance"
+    criteria_must_satisfy = "all"
+    behavior {
+      sure_route {
+        enabled = true
+        type    = "PERFORMANCE"
+      }
+    }
+  }
+}
@@ -0,0 +1,9 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+data "akamai_property_rules_hcl" "test" {
+  rules        = file("testdata/TestDSPropertyRulesHCL/rules.json")
+  rule_format  = "v2024-10-21"
+  label_prefix = "example_"
+}
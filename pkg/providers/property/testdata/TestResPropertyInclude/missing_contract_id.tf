@@ -0,0 +1,11 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+resource "akamai_property_include" "test" {
+  group_id    = "grp_123"
+  name        = "test_include"
+  type        = "MICROSERVICES"
+  rule_format = "v2022-06-28"
+  product_id  = "prd_Object_Delivery"
+}
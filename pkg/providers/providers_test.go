@@ -0,0 +1,12 @@
+package providers
+
+import (
+	"testing"
+
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/testutils"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/providers/registry"
+)
+
+func TestSchemaSnapshot(t *testing.T) {
+	testutils.CheckSchemaSnapshot(t, "testdata/schema.json", registry.Subproviders()...)
+}
@@ -44,8 +44,16 @@ import (
 	"time"
 
 	cleanhttp "github.com/hashicorp/go-cleanhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// tracerName is the name of the tracer of requests and their attempts
+const tracerName = "github.com/akamai/terraform-provider-akamai/v6/pkg/retryablehttp"
+
 var (
 	// Default retry configuration
 	defaultRetryWaitMin = 1 * time.Second
@@ -610,7 +618,34 @@ func PassthroughErrorHandler(resp *http.Response, err error, _ int) (*http.Respo
 }
 
 // Do wraps calling an HTTP method with retries.
+// The request and each of its attempts are traced as spans of the tracer provider registered with otel.
 func (c *Client) Do(req *Request) (*http.Response, error) {
+	ctx, span := otel.Tracer(tracerName).Start(req.Context(), "HTTP "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodOriginal(req.Method),
+			semconv.URLPath(req.URL.Path),
+		))
+	req.Request = req.Request.WithContext(ctx)
+
+	resp, err := c.do(req)
+	if resp != nil {
+		span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	}
+	endSpan(span, err)
+	return resp, err
+}
+
+// endSpan ends the span, recording the error if any
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func (c *Client) do(req *Request) (*http.Response, error) {
 	c.clientInit.Do(func() {
 		if c.HTTPClient == nil {
 			c.HTTPClient = cleanhttp.DefaultPooledClient()
@@ -632,16 +667,22 @@ func (c *Client) Do(req *Request) (*http.Response, error) {
 	var attempt int
 	var shouldRetry bool
 	var doErr, respErr, checkErr, prepareErr error
+	reqCtx := req.Context()
 
 	for i := 0; ; i++ {
 		doErr, respErr, prepareErr = nil, nil, nil
 		attempt++
 
+		attemptCtx, attemptSpan := otel.Tracer(tracerName).Start(reqCtx, fmt.Sprintf("attempt %d", attempt),
+			trace.WithAttributes(semconv.HTTPRequestResendCount(i)))
+		req.Request = req.Request.WithContext(attemptCtx)
+
 		// Always rewind the request body when non-nil.
 		if req.body != nil {
 			body, err := req.body()
 			if err != nil {
 				c.HTTPClient.CloseIdleConnections()
+				endSpan(attemptSpan, err)
 				return resp, err
 			}
 			if c, ok := body.(io.ReadCloser); ok {
@@ -655,6 +696,7 @@ func (c *Client) Do(req *Request) (*http.Response, error) {
 		if attempt > 1 && c.PrepareRetry != nil {
 			if err := c.PrepareRetry(req.Request); err != nil {
 				prepareErr = err
+				endSpan(attemptSpan, err)
 				break
 			}
 		}
@@ -684,6 +726,9 @@ func (c *Client) Do(req *Request) (*http.Response, error) {
 		if respErr != nil {
 			err = respErr
 		}
+		if resp != nil {
+			attemptSpan.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+		}
 		if err != nil {
 			switch v := logger.(type) {
 			case LeveledLogger:
@@ -708,6 +753,7 @@ func (c *Client) Do(req *Request) (*http.Response, error) {
 		}
 
 		if !shouldRetry {
+			endSpan(attemptSpan, err)
 			break
 		}
 
@@ -715,6 +761,7 @@ func (c *Client) Do(req *Request) (*http.Response, error) {
 		// we're breaking out
 		remain := c.RetryMax - i
 		if remain <= 0 {
+			endSpan(attemptSpan, err)
 			break
 		}
 
@@ -736,6 +783,8 @@ func (c *Client) Do(req *Request) (*http.Response, error) {
 				v.Printf("[DEBUG] %s: retrying in %s (%d left)", desc, wait, remain)
 			}
 		}
+		attemptSpan.SetAttributes(attribute.String("retry.wait", wait.String()))
+		endSpan(attemptSpan, err)
 		timer := time.NewTimer(wait)
 		select {
 		case <-req.Context().Done():
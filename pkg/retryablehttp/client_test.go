@@ -24,6 +24,11 @@ import (
 	"time"
 
 	"github.com/hashicorp/go-hclog"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 func TestRequest(t *testing.T) {
@@ -1227,3 +1232,68 @@ func TestClient_RedirectWithBody(t *testing.T) {
 		t.Fatalf("Expected the client to be redirected 2 times, got: %d", atomic.LoadInt32(&redirects))
 	}
 }
+
+func TestClient_Do_tracing(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+
+	var attempts int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(500)
+			return
+		}
+		w.WriteHeader(200)
+	}))
+	defer ts.Close()
+
+	client := NewClient()
+	client.RetryWaitMin = 10 * time.Millisecond
+	client.RetryWaitMax = 10 * time.Millisecond
+
+	resp, err := client.Get(ts.URL + "/foo/bar")
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	resp.Body.Close()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 3 {
+		t.Fatalf("expected 3 spans, got: %d", len(spans))
+	}
+	first, second, request := spans[0], spans[1], spans[2]
+	if request.Name != "HTTP GET" || first.Name != "attempt 1" || second.Name != "attempt 2" {
+		t.Fatalf("unexpected span names: %q, %q, %q", request.Name, first.Name, second.Name)
+	}
+	for _, attempt := range []tracetest.SpanStub{first, second} {
+		if attempt.Parent.SpanID() != request.SpanContext.SpanID() {
+			t.Fatalf("span %q is not a child of the request span", attempt.Name)
+		}
+	}
+
+	attr := func(span tracetest.SpanStub, key attribute.Key) attribute.Value {
+		for _, kv := range span.Attributes {
+			if kv.Key == key {
+				return kv.Value
+			}
+		}
+		return attribute.Value{}
+	}
+	if v := attr(first, "http.response.status_code").AsInt64(); v != 500 {
+		t.Fatalf("expected status 500 of the first attempt, got: %d", v)
+	}
+	if v := attr(first, "retry.wait").AsString(); v != "10ms" {
+		t.Fatalf("expected retry wait 10ms of the first attempt, got: %q", v)
+	}
+	if v := attr(second, "http.request.resend_count").AsInt64(); v != 1 {
+		t.Fatalf("expected resend count 1 of the second attempt, got: %d", v)
+	}
+	if v := attr(request, "http.response.status_code").AsInt64(); v != 200 {
+		t.Fatalf("expected status 200 of the request, got: %d", v)
+	}
+	if v := attr(request, "url.path").AsString(); v != "/foo/bar" {
+		t.Fatalf("expected path /foo/bar of the request, got: %q", v)
+	}
+}
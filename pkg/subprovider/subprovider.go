@@ -2,7 +2,10 @@
 package subprovider
 
 import (
+	"context"
+
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -21,3 +24,25 @@ type Subprovider interface {
 	// FrameworkDataSources returns the data sources implemented using terraform-plugin-framework
 	FrameworkDataSources() []func() datasource.DataSource
 }
+
+// WithFunctions is implemented by the sub-providers which define provider functions
+type WithFunctions interface {
+	// FrameworkFunctions returns the provider functions implemented using terraform-plugin-framework
+	FrameworkFunctions() []func() function.Function
+}
+
+// WithDeletionProtection is implemented by the sub-providers whose resources can be active on the network.
+// Deletion of such resources is refused while they are active, unless their deletion protection is turned off.
+type WithDeletionProtection interface {
+	// SDKActiveVersions returns, per resource type, the function returning the active versions of the resource
+	SDKActiveVersions() map[string]ActiveVersionsFunc
+}
+
+// ActiveVersionsFunc returns the versions of the resource currently active on the staging and production networks
+type ActiveVersionsFunc func(ctx context.Context, d *schema.ResourceData, m any) (ActiveVersions, error)
+
+// ActiveVersions names the versions of a resource active on each network, e.g. version 3, empty when not active
+type ActiveVersions struct {
+	Staging    string
+	Production string
+}
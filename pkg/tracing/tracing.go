@@ -0,0 +1,187 @@
+// Package tracing provides OpenTelemetry tracing of provider operations and API calls
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const (
+	// TracerName is the name of the tracer of provider operations
+	TracerName = "github.com/akamai/terraform-provider-akamai/v6"
+
+	serviceName = "terraform-provider-akamai"
+
+	// flushTimeout bounds the time an operation waits for its spans to be exported, so that a slow or unreachable
+	// collector does not delay operations. Spans not exported by then are exported later or at shutdown.
+	flushTimeout = time.Second
+)
+
+// Attribute keys of spans created by the provider
+const (
+	OperationIDKey   = attribute.Key("akamai.operation_id")
+	ResourceTypeKey  = attribute.Key("akamai.resource_type")
+	PollWaitKey      = attribute.Key("akamai.poll.wait")
+	PollStatusKey    = attribute.Key("akamai.poll.status")
+	PollIterationKey = attribute.Key("akamai.poll.iteration")
+)
+
+type (
+	// Config selects where spans are exported to. Tracing is disabled when neither field is set.
+	Config struct {
+		// Endpoint is the URL of an OTLP/HTTP collector, e.g. http://localhost:4318
+		Endpoint string
+		// File is the path of a file to which spans are appended as JSON objects
+		File string
+	}
+
+	tracing struct {
+		mu       sync.Mutex
+		config   Config
+		provider *sdktrace.TracerProvider
+		file     *os.File
+	}
+)
+
+var (
+	// ErrConfig is returned when tracing cannot be configured
+	ErrConfig = errors.New("tracing configuration")
+
+	global = &tracing{}
+)
+
+// Configure sets up the global tracer provider exporting spans as requested by the config.
+// Configuring the same exporters again is a no-op, so that both providers served by the plugin
+// can configure tracing, and a previous configuration is shut down when exporters change.
+func Configure(ctx context.Context, cfg Config) error {
+	global.mu.Lock()
+	defer global.mu.Unlock()
+
+	if cfg == global.config {
+		return nil
+	}
+	if err := global.shutdown(ctx); err != nil {
+		return err
+	}
+	if cfg == (Config{}) {
+		return nil
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName)))
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrConfig, err)
+	}
+	opts := []sdktrace.TracerProviderOption{sdktrace.WithResource(res)}
+
+	if cfg.Endpoint != "" {
+		u, err := url.Parse(cfg.Endpoint)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("%w: endpoint %q has to be a URL like http://localhost:4318", ErrConfig, cfg.Endpoint)
+		}
+		exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(cfg.Endpoint))
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrConfig, err)
+		}
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+	if cfg.File != "" {
+		file, err := os.OpenFile(cfg.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrConfig, err)
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			_ = file.Close()
+			return fmt.Errorf("%w: %s", ErrConfig, err)
+		}
+		// spans are written as soon as they end, so that none is lost when the plugin is killed
+		opts = append(opts, sdktrace.WithSyncer(exporter))
+		global.file = file
+	}
+
+	global.provider = sdktrace.NewTracerProvider(opts...)
+	global.config = cfg
+	otel.SetTracerProvider(global.provider)
+	return nil
+}
+
+// Shutdown exports remaining spans and disables tracing
+func Shutdown(ctx context.Context) error {
+	global.mu.Lock()
+	defer global.mu.Unlock()
+	return global.shutdown(ctx)
+}
+
+func (t *tracing) shutdown(ctx context.Context) error {
+	if t.provider == nil {
+		return nil
+	}
+	otel.SetTracerProvider(noop.NewTracerProvider())
+	err := t.provider.Shutdown(ctx)
+	if t.file != nil {
+		err = errors.Join(err, t.file.Close())
+	}
+	t.provider, t.file, t.config = nil, nil, Config{}
+	if err != nil {
+		return fmt.Errorf("%w: shutdown failed: %s", ErrConfig, err)
+	}
+	return nil
+}
+
+// Flush exports spans which ended, but are still buffered, waiting at most flushTimeout even if the context is canceled
+func Flush(ctx context.Context) {
+	global.mu.Lock()
+	provider := global.provider
+	global.mu.Unlock()
+	if provider != nil {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), flushTimeout)
+		defer cancel()
+		_ = provider.ForceFlush(ctx)
+	}
+}
+
+// Start starts a span of the provider tracer, a child of the span in the context if any
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(TracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End ends the span, recording the error if any
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// StartPoll starts the span of an iteration of polling, e.g. of an activation status, made after the given wait
+func StartPoll(ctx context.Context, name string, iteration int, wait time.Duration) (context.Context, trace.Span) {
+	return Start(ctx, name,
+		PollIterationKey.Int(iteration),
+		PollWaitKey.String(wait.String()),
+	)
+}
+
+// EndPoll ends the span of a polling iteration, recording the polled status and the error if any
+func EndPoll(span trace.Span, status string, err error) {
+	if status != "" {
+		span.SetAttributes(PollStatusKey.String(status))
+	}
+	End(span, err)
+}
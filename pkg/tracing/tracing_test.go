@@ -0,0 +1,113 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigureFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spans.json")
+	ctx := context.Background()
+	require.NoError(t, Configure(ctx, Config{File: path}))
+	// the same configuration of the other provider is a no-op
+	require.NoError(t, Configure(ctx, Config{File: path}))
+
+	opCtx, op := Start(ctx, "akamai_property create", OperationIDKey.String("op-1"))
+	_, poll := StartPoll(opCtx, "poll property activation", 2, 30*time.Second)
+	EndPoll(poll, "PENDING", nil)
+	End(op, errors.New("activation failed"))
+	require.NoError(t, Shutdown(ctx))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	type span struct {
+		Name        string
+		SpanContext struct{ SpanID string }
+		Parent      struct{ SpanID string }
+		Attributes  []struct {
+			Key   string
+			Value struct{ Value any }
+		}
+		Status struct{ Code, Description string }
+	}
+	var spans []span
+	decoder := json.NewDecoder(strings.NewReader(string(content)))
+	for decoder.More() {
+		var s span
+		require.NoError(t, decoder.Decode(&s))
+		spans = append(spans, s)
+	}
+	require.Len(t, spans, 2)
+
+	attrs := func(s span) map[string]any {
+		m := make(map[string]any)
+		for _, a := range s.Attributes {
+			m[a.Key] = a.Value.Value
+		}
+		return m
+	}
+	assert.Equal(t, "poll property activation", spans[0].Name)
+	assert.Equal(t, spans[1].SpanContext.SpanID, spans[0].Parent.SpanID)
+	assert.Equal(t, map[string]any{
+		string(PollIterationKey): float64(2),
+		string(PollWaitKey):      "30s",
+		string(PollStatusKey):    "PENDING",
+	}, attrs(spans[0]))
+
+	assert.Equal(t, "akamai_property create", spans[1].Name)
+	assert.Equal(t, map[string]any{string(OperationIDKey): "op-1"}, attrs(spans[1]))
+	assert.Equal(t, "Error", spans[1].Status.Code)
+	assert.Equal(t, "activation failed", spans[1].Status.Description)
+}
+
+func TestFlushUnresponsiveEndpoint(t *testing.T) {
+	done := make(chan struct{})
+	collector := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		select {
+		case <-done:
+		case <-r.Context().Done():
+		}
+	}))
+	defer collector.Close()
+	defer close(done)
+
+	ctx := context.Background()
+	require.NoError(t, Configure(ctx, Config{Endpoint: collector.URL}))
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+		_ = Shutdown(shutdownCtx)
+	}()
+
+	_, op := Start(ctx, "akamai_property create")
+	End(op, nil)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	start := time.Now()
+	Flush(canceled)
+	assert.Less(t, time.Since(start), flushTimeout+time.Second)
+}
+
+func TestConfigureInvalidEndpoint(t *testing.T) {
+	err := Configure(context.Background(), Config{Endpoint: "localhost:4318"})
+	assert.ErrorIs(t, err, ErrConfig)
+	assert.ErrorContains(t, err, "has to be a URL")
+}
+
+func TestConfigureInvalidFile(t *testing.T) {
+	err := Configure(context.Background(), Config{File: filepath.Join(t.TempDir(), "missing", "spans.json")})
+	assert.ErrorIs(t, err, ErrConfig)
+}